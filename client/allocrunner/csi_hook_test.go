@@ -19,6 +19,7 @@ import (
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/csi"
 	"github.com/hashicorp/nomad/plugins/drivers"
 )
 
@@ -336,6 +337,11 @@ func (mgr mockPluginManager) MounterForPlugin(ctx context.Context, pluginID stri
 // no-op methods to fulfill the interface
 func (mgr mockPluginManager) PluginManager() pluginmanager.PluginManager { return nil }
 func (mgr mockPluginManager) Shutdown()                                  {}
+func (mgr mockPluginManager) RepublishNodeInfo()                         {}
+func (mgr mockPluginManager) UnpublishAllVolumes(context.Context) error  { return nil }
+func (mgr mockPluginManager) PluginCapabilities(string) (*csi.PluginCapabilitySet, error) {
+	return nil, nil
+}
 
 type mockAllocRunner struct {
 	res  *cstructs.AllocHookResources
@@ -1078,6 +1078,7 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 		AllocID:          tr.allocID,
 		NetworkIsolation: tr.networkIsolationSpec,
 		DNS:              dns,
+		KillSignal:       task.KillSignal,
 	}
 }
 
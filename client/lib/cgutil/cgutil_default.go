@@ -12,3 +12,9 @@ const (
 func FindCgroupMountpointDir() (string, error) {
 	return "", nil
 }
+
+// AvailableControllers returns the cgroup controllers enabled on this node.
+// Here it is a no-op implementation
+func AvailableControllers() ([]string, error) {
+	return nil, nil
+}
@@ -127,3 +127,10 @@ func FindCgroupMountpointDir() (string, error) {
 	}
 	return mount[0].Mountpoint, nil
 }
+
+// AvailableControllers returns the cgroup controllers enabled on this node,
+// which may only be some of them when cgroups v2 controllers are partially
+// delegated.
+func AvailableControllers() ([]string, error) {
+	return cgroups.GetAllSubsystems()
+}
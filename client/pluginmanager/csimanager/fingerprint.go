@@ -36,6 +36,11 @@ type pluginFingerprinter struct {
 	// is started. Removing this bool will require storing a cache of recent successful
 	// results that can be used by subscribers of the `hadFirstSuccessfulFingerprintCh`.
 	requiresStaging bool
+
+	// capabilities is set during the initial fingerprint to the capabilities
+	// the plugin reported from its identity service. Like requiresStaging it
+	// is safe to read once hadFirstSuccessfulFingerprintCh is closed.
+	capabilities *csi.PluginCapabilitySet
 }
 
 func (p *pluginFingerprinter) fingerprint(ctx context.Context) *structs.CSIInfo {
@@ -104,6 +109,7 @@ func (p *pluginFingerprinter) buildBasicFingerprint(ctx context.Context) (*struc
 		return info, err
 	}
 
+	p.capabilities = capabilities
 	info.RequiresControllerPlugin = capabilities.HasControllerService()
 	info.RequiresTopologies = capabilities.HasToplogies()
 
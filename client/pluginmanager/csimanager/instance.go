@@ -2,10 +2,12 @@ package csimanager
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/dynamicplugins"
+	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/csi"
 )
 
@@ -41,6 +43,37 @@ type instanceManager struct {
 	volumeManagerSetupCh chan struct{}
 
 	client csi.CSIPlugin
+
+	// newClient creates the client used to talk to the plugin over its socket
+	newClient func(addr string, logger hclog.Logger) csi.CSIPlugin
+
+	// fingerprintInterval is how often the plugin is fingerprinted
+	fingerprintInterval time.Duration
+
+	// healthy is whether the latest fingerprint of the plugin was healthy
+	healthy     bool
+	healthyLock sync.RWMutex
+
+	// healthyCh is closed the first time the plugin is fingerprinted as
+	// healthy, after that fingerprint has been recorded in lastInfo.
+	healthyCh chan struct{}
+
+	// draining is set when the instance is shut down because another
+	// instance of the plugin has taken over, in which case its final
+	// unhealthy fingerprint must not be reported for the plugin
+	draining bool
+
+	// lastInfo is the latest fingerprint of the plugin, kept so that it can
+	// be reported again if the node's update was lost. Holding lastInfoLock
+	// while reporting keeps reports in order.
+	lastInfo     *structs.CSIInfo
+	lastInfoLock sync.Mutex
+
+	// waiting is set while the instance waits to take over from the
+	// instance serving its plugin. Its fingerprints are kept in lastInfo
+	// but not reported until it's promoted, so the node only sees the
+	// serving instance. Guarded by lastInfoLock.
+	waiting bool
 }
 
 func newInstanceManager(logger hclog.Logger, eventer TriggerNodeEvent, updater UpdateNodeCSIInfoFunc, p *dynamicplugins.PluginInfo) *instanceManager {
@@ -63,6 +96,9 @@ func newInstanceManager(logger hclog.Logger, eventer TriggerNodeEvent, updater U
 		mountPoint:          p.Options["MountPoint"],
 		containerMountPoint: p.Options["ContainerMountPoint"],
 		allocID:             p.AllocID,
+		newClient:           csi.NewClient,
+		fingerprintInterval: managerFingerprintInterval,
+		healthyCh:           make(chan struct{}),
 
 		volumeManagerSetupCh: make(chan struct{}),
 
@@ -73,15 +109,17 @@ func newInstanceManager(logger hclog.Logger, eventer TriggerNodeEvent, updater U
 }
 
 func (i *instanceManager) run() {
-	c := csi.NewClient(i.info.ConnectionInfo.SocketPath, i.logger)
+	c := i.newClient(i.info.ConnectionInfo.SocketPath, i.logger)
 	i.client = c
 	i.fp.client = c
 
-	go i.setupVolumeManager()
+	go i.setupVolumeManager(c)
 	go i.runLoop()
 }
 
-func (i *instanceManager) setupVolumeManager() {
+// setupVolumeManager creates the volume manager of a node plugin, using the
+// given client rather than i.client, which is cleared on shutdown.
+func (i *instanceManager) setupVolumeManager(client csi.CSIPlugin) {
 	if i.info.Type != dynamicplugins.PluginTypeCSINode {
 		i.logger.Debug("not a node plugin, skipping volume manager setup", "type", i.info.Type)
 		return
@@ -91,7 +129,7 @@ func (i *instanceManager) setupVolumeManager() {
 	case <-i.shutdownCtx.Done():
 		return
 	case <-i.fp.hadFirstSuccessfulFingerprintCh:
-		i.volumeManager = newVolumeManager(i.logger, i.eventer, i.client, i.mountPoint, i.containerMountPoint, i.fp.requiresStaging)
+		i.volumeManager = newVolumeManager(i.logger, i.eventer, client, i.mountPoint, i.containerMountPoint, i.fp.requiresStaging)
 		i.logger.Debug("volume manager setup complete")
 		close(i.volumeManagerSetupCh)
 		return
@@ -110,12 +148,24 @@ func (i *instanceManager) VolumeMounter(ctx context.Context) (VolumeMounter, err
 	}
 }
 
+// PluginCapabilities returns the capabilities the plugin reported during its
+// initial fingerprint, or false if it hasn't completed one yet.
+func (i *instanceManager) PluginCapabilities() (*csi.PluginCapabilitySet, bool) {
+	select {
+	case <-i.fp.hadFirstSuccessfulFingerprintCh:
+		return i.fp.capabilities, true
+	default:
+		return nil, false
+	}
+}
+
 func (i *instanceManager) requestCtxWithTimeout(timeout time.Duration) (context.Context, context.CancelFunc) {
 	return context.WithTimeout(i.shutdownCtx, timeout)
 }
 
 func (i *instanceManager) runLoop() {
 	timer := time.NewTimer(0)
+	wasHealthy := false
 	for {
 		select {
 		case <-i.shutdownCtx.Done():
@@ -130,25 +180,87 @@ func (i *instanceManager) runLoop() {
 			ctx, cancelFn := i.requestCtxWithTimeout(time.Second)
 			info := i.fp.fingerprint(ctx)
 			cancelFn()
-			if info != nil {
-				i.updater(i.info.Name, info)
+			if info != nil && !i.draining {
+				i.report(info)
 			}
 			close(i.shutdownCh)
 			return
 
 		case <-timer.C:
-			ctx, cancelFn := i.requestCtxWithTimeout(managerFingerprintInterval)
+			ctx, cancelFn := i.requestCtxWithTimeout(i.fingerprintInterval)
 			info := i.fp.fingerprint(ctx)
 			cancelFn()
+			healthy := info != nil && info.Healthy
+			i.setHealthy(healthy)
 			if info != nil {
-				i.updater(i.info.Name, info)
+				i.report(info)
+			}
+			if healthy && !wasHealthy {
+				wasHealthy = true
+				close(i.healthyCh)
 			}
-			timer.Reset(managerFingerprintInterval)
+			timer.Reset(i.fingerprintInterval)
 		}
 	}
 }
 
+// report updates the node with a fingerprint of the plugin, unless the
+// instance is waiting to take over the plugin.
+func (i *instanceManager) report(info *structs.CSIInfo) {
+	i.lastInfoLock.Lock()
+	defer i.lastInfoLock.Unlock()
+	i.lastInfo = info
+	if !i.waiting {
+		i.updater(i.info.Name, info)
+	}
+}
+
+// promote makes an instance that was waiting to take over its plugin report
+// its fingerprints, starting with its latest one. It does nothing if the
+// instance is already serving the plugin.
+func (i *instanceManager) promote() {
+	i.lastInfoLock.Lock()
+	defer i.lastInfoLock.Unlock()
+	if !i.waiting {
+		return
+	}
+	i.waiting = false
+	if i.lastInfo != nil {
+		i.updater(i.info.Name, i.lastInfo.Copy())
+	}
+}
+
+// republish updates the node again with the latest fingerprint of the
+// plugin, if it has been fingerprinted.
+func (i *instanceManager) republish() {
+	i.lastInfoLock.Lock()
+	defer i.lastInfoLock.Unlock()
+	if i.lastInfo != nil {
+		i.updater(i.info.Name, i.lastInfo.Copy())
+	}
+}
+
+func (i *instanceManager) setHealthy(healthy bool) {
+	i.healthyLock.Lock()
+	defer i.healthyLock.Unlock()
+	i.healthy = healthy
+}
+
+// isHealthy returns whether the latest fingerprint of the plugin was healthy.
+func (i *instanceManager) isHealthy() bool {
+	i.healthyLock.RLock()
+	defer i.healthyLock.RUnlock()
+	return i.healthy
+}
+
 func (i *instanceManager) shutdown() {
 	i.shutdownCtxCancelFn()
 	<-i.shutdownCh
 }
+
+// drain shuts down an instance that has been replaced by another instance of
+// the same plugin, without reporting the plugin as unhealthy.
+func (i *instanceManager) drain() {
+	i.draining = true
+	i.shutdown()
+}
@@ -23,9 +23,11 @@ func setupTestNodeInstanceManager(t *testing.T) (*fake.Client, *instanceManager)
 	}
 
 	return tp, &instanceManager{
-		logger: logger,
-		info:   pinfo,
-		client: tp,
+		logger:              logger,
+		info:                pinfo,
+		client:              tp,
+		fingerprintInterval: managerFingerprintInterval,
+		healthyCh:           make(chan struct{}),
 		fp: &pluginFingerprinter{
 			logger:                          logger.Named("fingerprinter"),
 			info:                            pinfo,
@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/nomad/client/pluginmanager"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/csi"
 )
 
 type MountInfo struct {
@@ -50,9 +51,24 @@ type Manager interface {
 	PluginManager() pluginmanager.PluginManager
 
 	// MounterForPlugin returns a VolumeMounter for the plugin ID associated
-	// with the volume.	Returns an error if this plugin isn't registered.
+	// with the volume.	Returns an error if this plugin isn't registered or
+	// none of its instances are healthy.
 	MounterForPlugin(ctx context.Context, pluginID string) (VolumeMounter, error)
 
+	// PluginCapabilities returns the capabilities, such as volume expansion,
+	// that the plugin reported when it was first fingerprinted. Returns an
+	// error if this plugin isn't registered or hasn't been fingerprinted yet.
+	PluginCapabilities(pluginID string) (*csi.PluginCapabilitySet, error)
+
+	// RepublishNodeInfo updates the node again with the latest fingerprint
+	// of every plugin, such as after reconnecting to the servers when an
+	// earlier update may have been lost.
+	RepublishNodeInfo()
+
+	// UnpublishAllVolumes unpublishes and unstages every volume mounted on
+	// the node by its node plugins, such as before the node shuts down.
+	UnpublishAllVolumes(ctx context.Context) error
+
 	// Shutdown shuts down the Manager and unmounts any locally attached volumes.
 	Shutdown()
 }
@@ -3,19 +3,28 @@ package csimanager
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
+	metrics "github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/nomad/client/dynamicplugins"
 	"github.com/hashicorp/nomad/client/pluginmanager"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/csi"
 )
 
 // defaultPluginResyncPeriod is the time interval used to do a full resync
 // against the dynamicplugins, to account for missed updates.
 const defaultPluginResyncPeriod = 30 * time.Second
 
+// defaultMaxInstancesPerPlugin is the number of instances of a plugin that
+// may run at once, leaving room for a couple of upgrades in flight alongside
+// the instance serving the plugin.
+const defaultMaxInstancesPerPlugin = 3
+
 // UpdateNodeCSIInfoFunc is the callback used to update the node from
 // fingerprinting
 type UpdateNodeCSIInfoFunc func(string, *structs.CSIInfo)
@@ -27,6 +36,15 @@ type Config struct {
 	UpdateNodeCSIInfoFunc UpdateNodeCSIInfoFunc
 	PluginResyncPeriod    time.Duration
 	TriggerNodeEvent      TriggerNodeEvent
+
+	// MaxInstancesPerPlugin limits how many allocations of the same plugin
+	// get an instance manager at once. Defaults to 3 if unset.
+	MaxInstancesPerPlugin int
+
+	// RoundRobinMounters spreads mounts across every healthy instance of a
+	// node plugin in turn, rather than always using the instance serving
+	// the plugin.
+	RoundRobinMounters bool
 }
 
 // New returns a new PluginManager that will handle managing CSI plugins from
@@ -37,15 +55,37 @@ func New(config *Config) Manager {
 	if config.PluginResyncPeriod == 0 {
 		config.PluginResyncPeriod = defaultPluginResyncPeriod
 	}
+	if config.MaxInstancesPerPlugin <= 0 {
+		config.MaxInstancesPerPlugin = defaultMaxInstancesPerPlugin
+	}
+
+	// The callbacks are invoked from the instance managers' goroutines, so
+	// default them to no-ops rather than panicking later on a nil func.
+	if config.UpdateNodeCSIInfoFunc == nil {
+		config.Logger.Warn("no node update func provided, CSI plugin fingerprints will be discarded")
+		config.UpdateNodeCSIInfoFunc = func(string, *structs.CSIInfo) {}
+	}
+	if config.TriggerNodeEvent == nil {
+		config.TriggerNodeEvent = func(*structs.NodeEvent) {}
+	}
 
 	return &csiManager{
-		logger:    config.Logger,
-		eventer:   config.TriggerNodeEvent,
-		registry:  config.DynamicRegistry,
-		instances: make(map[string]map[string]*instanceManager),
+		logger:      config.Logger,
+		eventer:     config.TriggerNodeEvent,
+		registry:    config.DynamicRegistry,
+		instances:   make(map[string]map[string][]*instanceManager),
+		promoteCh:   make(chan *instanceManager),
+		republishCh: make(chan struct{}),
+		newClient:   csi.NewClient,
+
+		fingerprintInterval: managerFingerprintInterval,
 
 		updateNodeCSIInfoFunc: config.UpdateNodeCSIInfoFunc,
+		reportedInfo:          make(map[string]map[string]*structs.CSIInfo),
 		pluginResyncPeriod:    config.PluginResyncPeriod,
+		maxInstancesPerPlugin: config.MaxInstancesPerPlugin,
+		roundRobinMounters:    config.RoundRobinMounters,
+		nextMounter:           make(map[string]int),
 
 		shutdownCtx:         ctx,
 		shutdownCtxCancelFn: cancelFn,
@@ -54,17 +94,51 @@ func New(config *Config) Manager {
 }
 
 type csiManager struct {
-	// instances should only be accessed from the run() goroutine and the shutdown
-	// fn. It is a map of PluginType : [PluginName : *instanceManager]
-	instances map[string]map[string]*instanceManager
+	// instances is a map of PluginType : [PluginName : []*instanceManager].
+	// The first instance of a plugin serves its volumes, and any others are
+	// newer allocations of the plugin waiting to become healthy. It's only
+	// modified from the run() goroutine, holding instancesLock, which other
+	// goroutines must hold to read it. Its slices are never modified in place.
+	instances     map[string]map[string][]*instanceManager
+	instancesLock sync.RWMutex
+
+	// promoteCh receives instances that have become healthy while waiting to
+	// take over from the instance currently serving their plugin.
+	promoteCh chan *instanceManager
+
+	// republishCh receives requests to update the node again with the
+	// latest fingerprint of every plugin.
+	republishCh chan struct{}
+
+	// newClient creates the clients instances use to talk to their plugins
+	newClient func(addr string, logger hclog.Logger) csi.CSIPlugin
+
+	// fingerprintInterval is how often instances fingerprint their plugins
+	fingerprintInterval time.Duration
 
 	registry           dynamicplugins.Registry
 	logger             hclog.Logger
 	eventer            TriggerNodeEvent
 	pluginResyncPeriod time.Duration
 
+	// maxInstancesPerPlugin is how many instances of a plugin may run at once
+	maxInstancesPerPlugin int
+
+	// roundRobinMounters is whether mounts are spread across the healthy
+	// instances of a plugin, in which case nextMounter is a map of
+	// PluginName : index of the healthy instance to use for the next mount.
+	roundRobinMounters bool
+	nextMounter        map[string]int
+	nextMounterLock    sync.Mutex
+
 	updateNodeCSIInfoFunc UpdateNodeCSIInfoFunc
 
+	// reportedInfo is the fingerprint last reported to the node for each
+	// plugin, as a map of PluginType : [PluginName : *CSIInfo], so that
+	// unchanged fingerprints don't cause node updates.
+	reportedInfo     map[string]map[string]*structs.CSIInfo
+	reportedInfoLock sync.Mutex
+
 	shutdownCtx         context.Context
 	shutdownCtxCancelFn context.CancelFunc
 	shutdownCh          chan struct{}
@@ -75,17 +149,106 @@ func (c *csiManager) PluginManager() pluginmanager.PluginManager {
 }
 
 func (c *csiManager) MounterForPlugin(ctx context.Context, pluginID string) (VolumeMounter, error) {
+	c.instancesLock.RLock()
 	nodePlugins, hasAnyNodePlugins := c.instances["csi-node"]
+	mgrs, hasPlugin := nodePlugins[pluginID]
+	c.instancesLock.RUnlock()
+
 	if !hasAnyNodePlugins {
 		return nil, fmt.Errorf("no storage node plugins found")
 	}
-
-	mgr, hasPlugin := nodePlugins[pluginID]
 	if !hasPlugin {
 		return nil, fmt.Errorf("plugin %s for type csi-node not found", pluginID)
 	}
 
-	return mgr.VolumeMounter(ctx)
+	// Instances are ordered with the one serving the plugin first, but skip
+	// any whose latest fingerprint was unhealthy.
+	healthy := make([]*instanceManager, 0, len(mgrs))
+	for _, mgr := range mgrs {
+		if mgr.isHealthy() {
+			healthy = append(healthy, mgr)
+		}
+	}
+	if len(healthy) == 0 {
+		return nil, fmt.Errorf("no healthy instance of plugin %s for type csi-node", pluginID)
+	}
+
+	if !c.roundRobinMounters {
+		return healthy[0].VolumeMounter(ctx)
+	}
+	return healthy[c.nextMounterIndex(pluginID, len(healthy))].VolumeMounter(ctx)
+}
+
+// nextMounterIndex returns the index of the healthy instance of the plugin to
+// mount the next volume with, cycling through the n healthy instances.
+func (c *csiManager) nextMounterIndex(pluginID string, n int) int {
+	c.nextMounterLock.Lock()
+	defer c.nextMounterLock.Unlock()
+
+	idx := c.nextMounter[pluginID] % n
+	c.nextMounter[pluginID] = idx + 1
+	return idx
+}
+
+func (c *csiManager) PluginCapabilities(pluginID string) (*csi.PluginCapabilitySet, error) {
+	found := false
+	for _, ptype := range []string{dynamicplugins.PluginTypeCSINode, dynamicplugins.PluginTypeCSIController} {
+		mgrs := c.instancesFor(ptype, pluginID)
+		if len(mgrs) == 0 {
+			continue
+		}
+		found = true
+
+		// Capabilities come from the plugin's identity service, so any
+		// instance that has completed its handshake can answer for it.
+		for _, mgr := range mgrs {
+			if caps, ok := mgr.PluginCapabilities(); ok {
+				return caps, nil
+			}
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("plugin %s not found", pluginID)
+	}
+	return nil, fmt.Errorf("plugin %s has not completed its initial fingerprint", pluginID)
+}
+
+func (c *csiManager) RepublishNodeInfo() {
+	select {
+	case c.republishCh <- struct{}{}:
+	case <-c.shutdownCtx.Done():
+	}
+}
+
+func (c *csiManager) UnpublishAllVolumes(ctx context.Context) error {
+	// Unmounting can take a while, so work from a snapshot of the node
+	// plugins rather than holding the lock the run loop needs throughout.
+	c.instancesLock.RLock()
+	nodePlugins := make(map[string][]*instanceManager, len(c.instances[dynamicplugins.PluginTypeCSINode]))
+	for name, mgrs := range c.instances[dynamicplugins.PluginTypeCSINode] {
+		nodePlugins[name] = mgrs
+	}
+	c.instancesLock.RUnlock()
+
+	var mErr *multierror.Error
+	for name, mgrs := range nodePlugins {
+		for _, mgr := range mgrs {
+			// Instances that never completed their initial fingerprint have
+			// no volume manager, so can't have mounted any volumes.
+			select {
+			case <-mgr.volumeManagerSetupCh:
+			default:
+				continue
+			}
+
+			if err := mgr.volumeManager.unmountAllVolumes(ctx); err != nil {
+				prefix := fmt.Sprintf("plugin %s (alloc %s):", name, mgr.allocID)
+				mErr = multierror.Append(mErr, multierror.Prefix(err, prefix))
+			}
+		}
+	}
+	return mErr.ErrorOrNil()
 }
 
 // Run starts a plugin manager and should return early
@@ -94,9 +257,18 @@ func (c *csiManager) Run() {
 }
 
 func (c *csiManager) runLoop() {
-	timer := time.NewTimer(0) // ensure we sync immediately in first pass
 	controllerUpdates := c.registry.PluginsUpdatedCh(c.shutdownCtx, "csi-controller")
 	nodeUpdates := c.registry.PluginsUpdatedCh(c.shutdownCtx, "csi-node")
+
+	// Plugins registered before we subscribed to updates, such as between
+	// New and Run, have no events to replay, so sync them right away. Any
+	// registered since are both listed here and sent as an event, which is
+	// harmless as instances are only created once per allocation.
+	c.resyncPluginsFromRegistry("csi-controller")
+	c.resyncPluginsFromRegistry("csi-node")
+
+	timer := time.NewTimer(c.pluginResyncPeriod)
+	defer timer.Stop()
 	for {
 		select {
 		case <-timer.C:
@@ -107,6 +279,10 @@ func (c *csiManager) runLoop() {
 			c.handlePluginEvent(event)
 		case event := <-nodeUpdates:
 			c.handlePluginEvent(event)
+		case mgr := <-c.promoteCh:
+			c.promoteInstance(mgr)
+		case <-c.republishCh:
+			c.republishNodeInfo()
 		case <-c.shutdownCtx.Done():
 			close(c.shutdownCh)
 			return
@@ -118,8 +294,12 @@ func (c *csiManager) runLoop() {
 // managers against those in the registry. we primarily will use update
 // events from the registry.
 func (c *csiManager) resyncPluginsFromRegistry(ptype string) {
+	defer metrics.MeasureSinceWithLabels([]string{"client", "csi", "resync_duration"}, time.Now(),
+		[]metrics.Label{{Name: "plugin_type", Value: ptype}})
+
 	plugins := c.registry.ListPlugins(ptype)
 	seen := make(map[string]struct{}, len(plugins))
+	c.logger.Trace("resyncing CSI plugins from registry", "plugin_type", ptype, "plugins", len(plugins))
 
 	// For every plugin in the registry, ensure that we have an existing plugin
 	// running. Also build the map of valid plugin names.
@@ -132,10 +312,11 @@ func (c *csiManager) resyncPluginsFromRegistry(ptype string) {
 
 	// For every instance manager, if we did not find it during the plugin
 	// iterator, shut it down and remove it from the table.
-	instances := c.instancesForType(ptype)
-	for name, mgr := range instances {
+	for name, mgrs := range c.instances[ptype] {
 		if _, ok := seen[name]; !ok {
-			c.ensureNoInstance(mgr.info)
+			for _, mgr := range mgrs {
+				c.ensureNoInstance(mgr.info)
+			}
 		}
 	}
 }
@@ -145,10 +326,8 @@ func (c *csiManager) handlePluginEvent(event *dynamicplugins.PluginUpdateEvent)
 	if event == nil {
 		return
 	}
-	c.logger.Trace("dynamic plugin event",
-		"event", event.EventType,
-		"plugin_id", event.Info.Name,
-		"plugin_alloc_id", event.Info.AllocID)
+	logger := c.pluginLogger(event.Info)
+	logger.Trace("dynamic plugin event", "event", event.EventType)
 
 	switch event.EventType {
 	case dynamicplugins.EventTypeRegistered:
@@ -156,8 +335,7 @@ func (c *csiManager) handlePluginEvent(event *dynamicplugins.PluginUpdateEvent)
 	case dynamicplugins.EventTypeDeregistered:
 		c.ensureNoInstance(event.Info)
 	default:
-		c.logger.Error("received unknown dynamic plugin event type",
-			"type", event.EventType)
+		logger.Error("received unknown dynamic plugin event type", "event", event.EventType)
 	}
 }
 
@@ -166,47 +344,227 @@ func (c *csiManager) handlePluginEvent(event *dynamicplugins.PluginUpdateEvent)
 func (c *csiManager) ensureInstance(plugin *dynamicplugins.PluginInfo) {
 	name := plugin.Name
 	ptype := plugin.Type
-	instances := c.instancesForType(ptype)
-	mgr, ok := instances[name]
-	if !ok {
-		c.logger.Debug("detected new CSI plugin", "name", name, "type", ptype, "alloc", plugin.AllocID)
-		mgr := newInstanceManager(c.logger, c.eventer, c.updateNodeCSIInfoFunc, plugin)
-		instances[name] = mgr
+	mgrs := c.instances[ptype][name]
+	for _, mgr := range mgrs {
+		if mgr.allocID == plugin.AllocID {
+			return
+		}
+	}
+
+	if len(mgrs) == 0 {
+		c.pluginLogger(plugin).Debug("detected new CSI plugin")
+		mgr := c.newInstance(plugin)
+		c.setInstances(ptype, name, []*instanceManager{mgr})
 		mgr.run()
-	} else if mgr.allocID != plugin.AllocID {
-		mgr.shutdown()
-		c.logger.Debug("detected update for CSI plugin", "name", name, "type", ptype, "alloc", plugin.AllocID)
-		mgr := newInstanceManager(c.logger, c.eventer, c.updateNodeCSIInfoFunc, plugin)
-		instances[name] = mgr
+		return
+	}
+
+	c.pluginLogger(plugin).Debug("detected update for CSI plugin")
+
+	// If the current instance isn't serving the plugin there's nothing to
+	// wait for, so the new instance replaces it right away. The same goes
+	// when the limit leaves no room for the new instance to wait.
+	if !mgrs[0].isHealthy() || c.maxInstancesPerPlugin == 1 {
+		for _, old := range mgrs {
+			old.shutdown()
+		}
+		mgr := c.newInstance(plugin)
+		c.setInstances(ptype, name, []*instanceManager{mgr})
 		mgr.run()
+		return
+	}
+
+	// Otherwise keep serving from the current instance until the new one is
+	// healthy, so that volumes aren't left without a working plugin while
+	// the new allocation starts up. Make room for it by evicting the oldest
+	// instances still waiting to take over, but never the serving instance.
+	if excess := len(mgrs) + 1 - c.maxInstancesPerPlugin; excess > 0 {
+		for _, old := range mgrs[1 : 1+excess] {
+			c.evictInstance(old)
+		}
+		mgrs = append(mgrs[:1:1], mgrs[1+excess:]...)
+	}
+
+	mgr := c.newInstance(plugin)
+	mgr.waiting = true
+	c.setInstances(ptype, name, append(mgrs[:len(mgrs):len(mgrs)], mgr))
+	mgr.run()
+	go c.waitForPromotion(mgr)
+}
+
+// newInstance returns an instance manager for the plugin that hasn't been
+// started yet.
+func (c *csiManager) newInstance(plugin *dynamicplugins.PluginInfo) *instanceManager {
+	ptype := plugin.Type
+	updater := func(name string, info *structs.CSIInfo) {
+		c.updateNodeCSIInfo(ptype, name, info)
+	}
+	mgr := newInstanceManager(c.logger, c.eventer, updater, plugin)
+	mgr.newClient = c.newClient
+	mgr.fingerprintInterval = c.fingerprintInterval
+	return mgr
+}
+
+// updateNodeCSIInfo updates the node with a fingerprint of a plugin, unless
+// it's the same as the fingerprint last reported for the plugin.
+func (c *csiManager) updateNodeCSIInfo(ptype, name string, info *structs.CSIInfo) {
+	c.reportedInfoLock.Lock()
+	defer c.reportedInfoLock.Unlock()
+
+	reported, ok := c.reportedInfo[ptype]
+	if !ok {
+		reported = make(map[string]*structs.CSIInfo)
+		c.reportedInfo[ptype] = reported
+	}
+	if last, ok := reported[name]; ok && last.Equal(info) {
+		return
+	}
+	reported[name] = info.Copy()
+	c.updateNodeCSIInfoFunc(name, info)
+}
+
+// waitForPromotion hands the instance back to the run loop for promotion once
+// it has been fingerprinted as healthy.
+func (c *csiManager) waitForPromotion(mgr *instanceManager) {
+	select {
+	case <-mgr.healthyCh:
+	case <-mgr.shutdownCtx.Done():
+		return
+	case <-c.shutdownCtx.Done():
+		return
+	}
+
+	select {
+	case c.promoteCh <- mgr:
+	case <-c.shutdownCtx.Done():
+	}
+}
 
+// promoteInstance makes a healthy instance the one serving its plugin and
+// drains the older instances it replaces.
+func (c *csiManager) promoteInstance(mgr *instanceManager) {
+	name := mgr.info.Name
+	ptype := mgr.info.Type
+	mgrs := c.instances[ptype][name]
+
+	// The instance may have been deregistered while becoming healthy, or
+	// already have taken over from a serving instance that was deregistered.
+	idx := -1
+	for i, m := range mgrs {
+		if m == mgr {
+			idx = i
+			break
+		}
+	}
+	if idx <= 0 {
+		return
+	}
+
+	c.pluginLogger(mgr.info).Debug("promoting CSI plugin")
+	c.setInstances(ptype, name, mgrs[idx:])
+	for _, old := range mgrs[:idx] {
+		c.pluginLogger(old.info).Debug("draining CSI plugin")
+		old.drain()
+	}
+
+	// report the new instance only once the old ones can no longer report
+	mgr.promote()
+}
+
+// republishNodeInfo updates the node again with the latest fingerprint of
+// the instance serving each plugin.
+func (c *csiManager) republishNodeInfo() {
+	// The node's earlier updates may have been lost, so report every
+	// fingerprint again even if it's unchanged.
+	c.reportedInfoLock.Lock()
+	c.reportedInfo = make(map[string]map[string]*structs.CSIInfo)
+	c.reportedInfoLock.Unlock()
+
+	for _, pluginMap := range c.instances {
+		for _, mgrs := range pluginMap {
+			if len(mgrs) > 0 {
+				mgrs[0].republish()
+			}
+		}
 	}
 }
 
+// evictInstance drains an instance that was waiting to take over its plugin
+// to keep the plugin within the instance limit.
+func (c *csiManager) evictInstance(mgr *instanceManager) {
+	name := mgr.info.Name
+	ptype := mgr.info.Type
+	c.pluginLogger(mgr.info).Warn("too many instances of CSI plugin, evicting oldest",
+		"limit", c.maxInstancesPerPlugin)
+	mgr.drain()
+
+	c.eventer(structs.NewNodeEvent().
+		SetSubsystem(structs.NodeEventSubsystemStorage).
+		SetMessage("CSI plugin instance evicted").
+		AddDetail("plugin", name).
+		AddDetail("type", ptype).
+		AddDetail("alloc_id", mgr.allocID).
+		AddDetail("limit", strconv.Itoa(c.maxInstancesPerPlugin)))
+}
+
 // Shut down the instance manager for a plugin and remove it from
 // the CSI manager's tracking table for that plugin type.
 func (c *csiManager) ensureNoInstance(plugin *dynamicplugins.PluginInfo) {
-	name := plugin.Name
-	ptype := plugin.Type
-	instances := c.instancesForType(ptype)
-	if mgr, ok := instances[name]; ok {
+	mgrs := c.instances[plugin.Type][plugin.Name]
+	removedServing := false
+	for i, mgr := range mgrs {
 		if mgr.allocID == plugin.AllocID {
-			c.logger.Debug("shutting down CSI plugin", "name", name, "type", ptype, "alloc", plugin.AllocID)
+			c.pluginLogger(plugin).Debug("shutting down CSI plugin")
 			mgr.shutdown()
-			delete(instances, name)
+			mgrs = append(mgrs[:i:i], mgrs[i+1:]...)
+			removedServing = i == 0
+			break
 		}
 	}
+
+	c.setInstances(plugin.Type, plugin.Name, mgrs)
+
+	// The next instance takes over from the serving one even if it isn't
+	// healthy yet, as there's no other instance left to report the plugin.
+	if removedServing && len(mgrs) > 0 {
+		c.pluginLogger(mgrs[0].info).Debug("promoting CSI plugin")
+		mgrs[0].promote()
+	}
 }
 
-// Get the instance managers table for a specific plugin type,
-// ensuring it's been initialized if it doesn't exist.
-func (c *csiManager) instancesForType(ptype string) map[string]*instanceManager {
+// pluginLogger returns the manager's logger with the fields identifying an
+// allocation of a plugin.
+func (c *csiManager) pluginLogger(plugin *dynamicplugins.PluginInfo) hclog.Logger {
+	return c.logger.With(
+		"plugin_id", plugin.Name,
+		"plugin_type", plugin.Type,
+		"alloc_id", plugin.AllocID)
+}
+
+// instancesFor returns the instance managers of a plugin, with the one
+// serving the plugin first.
+func (c *csiManager) instancesFor(ptype, name string) []*instanceManager {
+	c.instancesLock.RLock()
+	defer c.instancesLock.RUnlock()
+	return c.instances[ptype][name]
+}
+
+// setInstances replaces the instance managers of a plugin in the CSI
+// manager's tracking table, removing the plugin if there are none.
+func (c *csiManager) setInstances(ptype, name string, mgrs []*instanceManager) {
+	c.instancesLock.Lock()
+	defer c.instancesLock.Unlock()
+
 	pluginMap, ok := c.instances[ptype]
 	if !ok {
-		pluginMap = make(map[string]*instanceManager)
+		pluginMap = make(map[string][]*instanceManager)
 		c.instances[ptype] = pluginMap
 	}
-	return pluginMap
+	if len(mgrs) == 0 {
+		delete(pluginMap, name)
+	} else {
+		pluginMap[name] = mgrs
+	}
 }
 
 // Shutdown should gracefully shutdown all plugins managed by the manager.
@@ -223,12 +581,14 @@ func (c *csiManager) Shutdown() {
 	// Shutdown all the instance managers in parallel
 	var wg sync.WaitGroup
 	for _, pluginMap := range c.instances {
-		for _, mgr := range pluginMap {
-			wg.Add(1)
-			go func(mgr *instanceManager) {
-				mgr.shutdown()
-				wg.Done()
-			}(mgr)
+		for _, mgrs := range pluginMap {
+			for _, mgr := range mgrs {
+				wg.Add(1)
+				go func(mgr *instanceManager) {
+					mgr.shutdown()
+					wg.Done()
+				}(mgr)
+			}
 		}
 	}
 	wg.Wait()
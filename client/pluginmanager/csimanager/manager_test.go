@@ -1,15 +1,27 @@
 package csimanager
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	metrics "github.com/armon/go-metrics"
+	csipbv1 "github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/dynamicplugins"
 	"github.com/hashicorp/nomad/client/pluginmanager"
 	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/csi"
+	"github.com/hashicorp/nomad/plugins/csi/fake"
 	"github.com/stretchr/testify/require"
 )
 
@@ -49,6 +61,47 @@ func setupRegistry(reg *MemDB) dynamicplugins.Registry {
 		})
 }
 
+// slowRegistry is a Registry whose ListPlugins blocks for delay, simulating
+// a slow storage backend.
+type slowRegistry struct {
+	dynamicplugins.Registry
+	delay time.Duration
+}
+
+func (r *slowRegistry) ListPlugins(ptype string) []*dynamicplugins.PluginInfo {
+	time.Sleep(r.delay)
+	return r.Registry.ListPlugins(ptype)
+}
+
+func TestManager_ResyncDurationMetric(t *testing.T) {
+	inm := metrics.NewInmemSink(10*time.Second, time.Minute)
+	cfg := metrics.DefaultConfig("")
+	cfg.EnableHostname = false
+	cfg.EnableRuntimeMetrics = false
+	_, err := metrics.NewGlobal(cfg, inm)
+	require.NoError(t, err)
+
+	registry := setupRegistry(nil)
+	defer registry.Shutdown()
+	pm := testManager(t, &slowRegistry{Registry: registry, delay: 20 * time.Millisecond}, time.Hour)
+	defer pm.Shutdown()
+
+	pm.Run()
+
+	var sample metrics.SampledValue
+	require.Eventually(t, func() bool {
+		data := inm.Data()
+		if len(data) == 0 {
+			return false
+		}
+		var ok bool
+		sample, ok = data[len(data)-1].Samples["client.csi.resync_duration;plugin_type=csi-node"]
+		return ok
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, 1, sample.Count)
+	require.GreaterOrEqual(t, sample.Max, float64(20))
+}
+
 func TestManager_RegisterPlugin(t *testing.T) {
 	registry := setupRegistry(nil)
 	defer registry.Shutdown()
@@ -62,16 +115,39 @@ func TestManager_RegisterPlugin(t *testing.T) {
 	pm.Run()
 
 	require.Eventually(t, func() bool {
-		pmap, ok := pm.instances[plugin.Type]
-		if !ok {
-			return false
-		}
-
-		_, ok = pmap[plugin.Name]
-		return ok
+		return len(pm.instancesFor(plugin.Type, plugin.Name)) > 0
 	}, 5*time.Second, 10*time.Millisecond)
 }
 
+// missedEventsRegistry is a Registry whose update events are never delivered,
+// simulating events fired before the manager subscribed to them.
+type missedEventsRegistry struct {
+	dynamicplugins.Registry
+}
+
+func (r *missedEventsRegistry) PluginsUpdatedCh(ctx context.Context, ptype string) <-chan *dynamicplugins.PluginUpdateEvent {
+	return make(chan *dynamicplugins.PluginUpdateEvent)
+}
+
+// TestManager_RegisterPluginBeforeRun ensures that a plugin registered before
+// the manager is run gets an instance from the initial resync, without
+// relying on its registration event or the periodic resync.
+func TestManager_RegisterPluginBeforeRun(t *testing.T) {
+	registry := setupRegistry(nil)
+	defer registry.Shutdown()
+	pm := testManager(t, &missedEventsRegistry{Registry: registry}, time.Hour)
+	defer pm.Shutdown()
+
+	plugin := fakePlugin(0, dynamicplugins.PluginTypeCSINode)
+	require.NoError(t, registry.RegisterPlugin(plugin))
+
+	pm.Run()
+
+	require.Eventually(t, func() bool {
+		return len(pm.instancesFor(plugin.Type, plugin.Name)) > 0
+	}, 5*time.Second, 10*time.Millisecond, "plugin was not synced from the registry")
+}
+
 func TestManager_DeregisterPlugin(t *testing.T) {
 	registry := setupRegistry(nil)
 	defer registry.Shutdown()
@@ -85,19 +161,102 @@ func TestManager_DeregisterPlugin(t *testing.T) {
 	pm.Run()
 
 	require.Eventually(t, func() bool {
-		_, ok := pm.instances[plugin.Type][plugin.Name]
-		return ok
+		return len(pm.instancesFor(plugin.Type, plugin.Name)) > 0
 	}, 5*time.Second, 10*time.Millisecond)
 
 	err = registry.DeregisterPlugin(plugin.Type, plugin.Name, "alloc-0")
 	require.NoError(t, err)
 
 	require.Eventually(t, func() bool {
-		_, ok := pm.instances[plugin.Type][plugin.Name]
-		return !ok
+		return len(pm.instancesFor(plugin.Type, plugin.Name)) == 0
 	}, 5*time.Second, 10*time.Millisecond)
 }
 
+// TestManager_PluginLogFields ensures that the manager's log lines about a
+// plugin identify the plugin and its allocation.
+func TestManager_PluginLogFields(t *testing.T) {
+	registry := setupRegistry(nil)
+	defer registry.Shutdown()
+
+	var buf bytes.Buffer
+	pm := New(&Config{
+		Logger: hclog.New(&hclog.LoggerOptions{
+			Name:       "csi_manager",
+			Level:      hclog.Trace,
+			Output:     &buf,
+			JSONFormat: true,
+		}),
+		DynamicRegistry:    registry,
+		PluginResyncPeriod: time.Hour,
+	}).(*csiManager)
+	pm.Run()
+
+	plugin := fakePlugin(0, dynamicplugins.PluginTypeCSIController)
+	require.NoError(t, registry.RegisterPlugin(plugin))
+	require.Eventually(t, func() bool {
+		return len(pm.instancesFor(plugin.Type, plugin.Name)) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, registry.DeregisterPlugin(plugin.Type, plugin.Name, plugin.AllocID))
+	require.Eventually(t, func() bool {
+		return len(pm.instancesFor(plugin.Type, plugin.Name)) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// stop logging before reading the buffer
+	pm.Shutdown()
+
+	messages := map[string]bool{
+		"dynamic plugin event":     false,
+		"detected new CSI plugin":  false,
+		"shutting down CSI plugin": false,
+	}
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var line map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		msg, _ := line["@message"].(string)
+		if _, ok := messages[msg]; !ok || line["@module"] != "csi_manager" {
+			continue
+		}
+		messages[msg] = true
+		require.Equal(t, plugin.Name, line["plugin_id"], msg)
+		require.Equal(t, plugin.Type, line["plugin_type"], msg)
+		require.Equal(t, plugin.AllocID, line["alloc_id"], msg)
+	}
+	require.NoError(t, scanner.Err())
+	for msg, found := range messages {
+		require.True(t, found, "missing log line %q", msg)
+	}
+}
+
+// TestManager_NilCallbacks ensures that a manager built without callbacks
+// doesn't panic when its instance managers fingerprint plugins.
+func TestManager_NilCallbacks(t *testing.T) {
+	registry := setupRegistry(nil)
+	defer registry.Shutdown()
+
+	pm := New(&Config{
+		Logger:             testlog.HCLogger(t),
+		DynamicRegistry:    registry,
+		PluginResyncPeriod: time.Hour,
+	}).(*csiManager)
+	require.NotNil(t, pm.updateNodeCSIInfoFunc)
+	require.NotNil(t, pm.eventer)
+
+	plugin := fakePlugin(0, dynamicplugins.PluginTypeCSINode)
+	require.NoError(t, registry.RegisterPlugin(plugin))
+
+	pm.Run()
+
+	require.Eventually(t, func() bool {
+		return len(pm.instancesFor(plugin.Type, plugin.Name)) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// shutting down runs a final fingerprint which reports the plugin as
+	// unhealthy through the update callback
+	pm.Shutdown()
+}
+
 // TestManager_MultiplePlugins ensures that multiple plugins with the same
 // name but different types (as found with monolith plugins) don't interfere
 // with each other.
@@ -119,21 +278,18 @@ func TestManager_MultiplePlugins(t *testing.T) {
 	pm.Run()
 
 	require.Eventually(t, func() bool {
-		_, ok := pm.instances[controllerPlugin.Type][controllerPlugin.Name]
-		return ok
+		return len(pm.instancesFor(controllerPlugin.Type, controllerPlugin.Name)) > 0
 	}, 5*time.Second, 10*time.Millisecond)
 
 	require.Eventually(t, func() bool {
-		_, ok := pm.instances[nodePlugin.Type][nodePlugin.Name]
-		return ok
+		return len(pm.instancesFor(nodePlugin.Type, nodePlugin.Name)) > 0
 	}, 5*time.Second, 10*time.Millisecond)
 
 	err = registry.DeregisterPlugin(controllerPlugin.Type, controllerPlugin.Name, "alloc-0")
 	require.NoError(t, err)
 
 	require.Eventually(t, func() bool {
-		_, ok := pm.instances[controllerPlugin.Type][controllerPlugin.Name]
-		return !ok
+		return len(pm.instancesFor(controllerPlugin.Type, controllerPlugin.Name)) == 0
 	}, 5*time.Second, 10*time.Millisecond)
 }
 
@@ -154,7 +310,11 @@ func TestManager_ConcurrentPlugins(t *testing.T) {
 		require.NoError(t, registry.RegisterPlugin(plugin0))
 		require.NoError(t, registry.RegisterPlugin(plugin1))
 		require.Eventuallyf(t, func() bool {
-			im, _ := pm.instances[plugin0.Type][plugin0.Name]
+			mgrs := pm.instancesFor(plugin0.Type, plugin0.Name)
+			if len(mgrs) == 0 {
+				return false
+			}
+			im := mgrs[0]
 			return im.info.ConnectionInfo.SocketPath == "/var/data/alloc/alloc-1/csi.sock" &&
 				im.allocID == "alloc-1"
 		}, 5*time.Second, 10*time.Millisecond, "alloc-1 plugin did not become active plugin")
@@ -172,7 +332,11 @@ func TestManager_ConcurrentPlugins(t *testing.T) {
 		pm.Run()
 
 		require.Eventuallyf(t, func() bool {
-			im, _ := pm.instances[plugin0.Type][plugin0.Name]
+			mgrs := pm.instancesFor(plugin0.Type, plugin0.Name)
+			if len(mgrs) == 0 {
+				return false
+			}
+			im := mgrs[0]
 			return im.info.ConnectionInfo.SocketPath == "/var/data/alloc/alloc-1/csi.sock" &&
 				im.allocID == "alloc-1"
 		}, 5*time.Second, 10*time.Millisecond, "alloc-1 plugin was not active after state reload")
@@ -183,7 +347,11 @@ func TestManager_ConcurrentPlugins(t *testing.T) {
 
 		require.NoError(t, registry.RegisterPlugin(plugin2))
 		require.Eventuallyf(t, func() bool {
-			im, _ := pm.instances[plugin0.Type][plugin0.Name]
+			mgrs := pm.instancesFor(plugin0.Type, plugin0.Name)
+			if len(mgrs) == 0 {
+				return false
+			}
+			im := mgrs[0]
 			return im.info.ConnectionInfo.SocketPath == "/var/data/alloc/alloc-2/csi.sock" &&
 				im.allocID == "alloc-2"
 		}, 5*time.Second, 10*time.Millisecond, "alloc-2 plugin was not active after replacement")
@@ -206,7 +374,11 @@ func TestManager_ConcurrentPlugins(t *testing.T) {
 		require.NoError(t, registry.RegisterPlugin(plugin1))
 
 		require.Eventuallyf(t, func() bool {
-			im, _ := pm.instances[plugin0.Type][plugin0.Name]
+			mgrs := pm.instancesFor(plugin0.Type, plugin0.Name)
+			if len(mgrs) == 0 {
+				return false
+			}
+			im := mgrs[0]
 			return im.info.ConnectionInfo.SocketPath == "/var/data/alloc/alloc-1/csi.sock" &&
 				im.allocID == "alloc-1"
 		}, 5*time.Second, 10*time.Millisecond, "alloc-1 plugin did not become active plugin")
@@ -214,13 +386,671 @@ func TestManager_ConcurrentPlugins(t *testing.T) {
 		registry.DeregisterPlugin(dynamicplugins.PluginTypeCSINode, "my-plugin", "alloc-0")
 
 		require.Eventuallyf(t, func() bool {
-			im, _ := pm.instances[plugin0.Type][plugin0.Name]
-			return im != nil &&
-				im.info.ConnectionInfo.SocketPath == "/var/data/alloc/alloc-1/csi.sock"
+			mgrs := pm.instancesFor(plugin0.Type, plugin0.Name)
+			if len(mgrs) == 0 {
+				return false
+			}
+			im := mgrs[0]
+			return im.info.ConnectionInfo.SocketPath == "/var/data/alloc/alloc-1/csi.sock"
 		}, 5*time.Second, 10*time.Millisecond, "alloc-1 plugin should still be active plugin")
 	})
 }
 
+// healthyNodeClient returns a fake node plugin that fingerprints as healthy.
+func healthyNodeClient() *fake.Client {
+	return &fake.Client{
+		NextPluginGetCapabilitiesResponse: &csi.PluginCapabilitySet{},
+		NextNodeGetInfoResponse:           &csi.NodeGetInfoResponse{NodeID: "foo"},
+		NextNodeGetCapabilitiesResponse:   &csi.NodeCapabilitySet{},
+		NextPluginProbeResponse:           true,
+	}
+}
+
+// gatedClient is a fake plugin whose fingerprint blocks until gate is
+// closed, simulating a plugin that is still starting up.
+type gatedClient struct {
+	*fake.Client
+	gate chan struct{}
+}
+
+func (c *gatedClient) PluginGetCapabilities(ctx context.Context) (*csi.PluginCapabilitySet, error) {
+	select {
+	case <-c.gate:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return c.Client.PluginGetCapabilities(ctx)
+}
+
+// TestManager_UpgradePlugin ensures that when a new allocation of a plugin is
+// registered, mounts are served by the old allocation until the new one is
+// healthy, and the old one is then drained.
+func TestManager_UpgradePlugin(t *testing.T) {
+	registry := setupRegistry(nil)
+	defer registry.Shutdown()
+
+	pm := testManager(t, registry, time.Hour) // no resync except from events
+	defer pm.Shutdown()
+
+	var lock sync.Mutex
+	var lastInfo *structs.CSIInfo
+	pm.updateNodeCSIInfoFunc = func(_ string, info *structs.CSIInfo) {
+		lock.Lock()
+		defer lock.Unlock()
+		lastInfo = info
+	}
+
+	plugin0 := fakePlugin(0, dynamicplugins.PluginTypeCSINode)
+	plugin1 := fakePlugin(1, dynamicplugins.PluginTypeCSINode)
+	gate := make(chan struct{})
+	clients := map[string]csi.CSIPlugin{
+		plugin0.ConnectionInfo.SocketPath: healthyNodeClient(),
+		plugin1.ConnectionInfo.SocketPath: &gatedClient{Client: healthyNodeClient(), gate: gate},
+	}
+	pm.newClient = func(addr string, _ hclog.Logger) csi.CSIPlugin {
+		return clients[addr]
+	}
+	pm.Run()
+
+	require.NoError(t, registry.RegisterPlugin(plugin0))
+	var old *instanceManager
+	require.Eventually(t, func() bool {
+		mgrs := pm.instancesFor(plugin0.Type, plugin0.Name)
+		if len(mgrs) != 1 {
+			return false
+		}
+		old = mgrs[0]
+		return old.isHealthy()
+	}, 5*time.Second, 10*time.Millisecond, "alloc-0 plugin did not become healthy")
+
+	oldMounter, err := old.VolumeMounter(context.Background())
+	require.NoError(t, err)
+
+	// the new allocation can't fingerprint yet, so the old one keeps serving
+	require.NoError(t, registry.RegisterPlugin(plugin1))
+	require.Eventually(t, func() bool {
+		return len(pm.instancesFor(plugin0.Type, plugin0.Name)) == 2
+	}, 5*time.Second, 10*time.Millisecond, "alloc-1 plugin was not started")
+
+	mounter, err := pm.MounterForPlugin(context.Background(), plugin0.Name)
+	require.NoError(t, err)
+	require.Same(t, oldMounter, mounter)
+
+	// once the new allocation is healthy it takes over
+	close(gate)
+	require.Eventually(t, func() bool {
+		mgrs := pm.instancesFor(plugin0.Type, plugin0.Name)
+		return len(mgrs) == 1 && mgrs[0].allocID == plugin1.AllocID
+	}, 5*time.Second, 10*time.Millisecond, "alloc-1 plugin was not promoted")
+
+	mounter, err = pm.MounterForPlugin(context.Background(), plugin0.Name)
+	require.NoError(t, err)
+	require.NotSame(t, oldMounter, mounter)
+
+	select {
+	case <-old.shutdownCh:
+	default:
+		t.Fatal("alloc-0 plugin was not drained")
+	}
+
+	// draining the old allocation doesn't report the plugin as unhealthy
+	lock.Lock()
+	defer lock.Unlock()
+	require.True(t, lastInfo.Healthy)
+	require.Equal(t, plugin1.AllocID, lastInfo.AllocID)
+}
+
+// unhealthyClient is a fake plugin that fingerprints as unhealthy for its
+// first probes, simulating a plugin that is still starting up.
+type unhealthyClient struct {
+	*fake.Client
+	probes int32
+}
+
+func (c *unhealthyClient) PluginProbe(ctx context.Context) (bool, error) {
+	if atomic.AddInt32(&c.probes, -1) >= 0 {
+		return false, nil
+	}
+	return c.Client.PluginProbe(ctx)
+}
+
+// TestManager_UpgradePlugin_UnhealthyWhileWaiting ensures that the node only
+// sees fingerprints from the instance serving a plugin, and not the unhealthy
+// fingerprints of a new allocation still waiting to take over.
+func TestManager_UpgradePlugin_UnhealthyWhileWaiting(t *testing.T) {
+	registry := setupRegistry(nil)
+	defer registry.Shutdown()
+
+	pm := testManager(t, registry, time.Hour)
+	defer pm.Shutdown()
+	pm.fingerprintInterval = 10 * time.Millisecond
+
+	var lock sync.Mutex
+	var reported []*structs.CSIInfo
+	pm.updateNodeCSIInfoFunc = func(_ string, info *structs.CSIInfo) {
+		lock.Lock()
+		defer lock.Unlock()
+		reported = append(reported, info.Copy())
+	}
+
+	plugin0 := fakePlugin(0, dynamicplugins.PluginTypeCSINode)
+	plugin1 := fakePlugin(1, dynamicplugins.PluginTypeCSINode)
+	starting := &unhealthyClient{Client: healthyNodeClient(), probes: 5}
+	clients := map[string]csi.CSIPlugin{
+		plugin0.ConnectionInfo.SocketPath: healthyNodeClient(),
+		plugin1.ConnectionInfo.SocketPath: starting,
+	}
+	pm.newClient = func(addr string, _ hclog.Logger) csi.CSIPlugin {
+		return clients[addr]
+	}
+	pm.Run()
+
+	require.NoError(t, registry.RegisterPlugin(plugin0))
+	require.Eventually(t, func() bool {
+		mgrs := pm.instancesFor(plugin0.Type, plugin0.Name)
+		return len(mgrs) == 1 && mgrs[0].isHealthy()
+	}, 5*time.Second, 10*time.Millisecond, "alloc-0 plugin did not become healthy")
+
+	require.NoError(t, registry.RegisterPlugin(plugin1))
+	require.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		last := reported[len(reported)-1]
+		return last.AllocID == plugin1.AllocID && last.Healthy
+	}, 5*time.Second, 10*time.Millisecond, "alloc-1 plugin was not promoted")
+	require.Less(t, atomic.LoadInt32(&starting.probes), int32(0), "alloc-1 plugin was never unhealthy")
+
+	// the node sees the old allocation until the new one takes over, and
+	// never sees the new one unhealthy
+	lock.Lock()
+	defer lock.Unlock()
+	promoted := false
+	for _, info := range reported {
+		if info.AllocID == plugin1.AllocID {
+			promoted = true
+			require.True(t, info.Healthy, "reported unhealthy fingerprint of waiting instance: %s", info.HealthDescription)
+		} else {
+			require.False(t, promoted, "reported old allocation after promotion")
+			require.True(t, info.Healthy)
+		}
+	}
+}
+
+// TestManager_UpgradePlugin_DeregisterServing ensures that when the allocation
+// serving a plugin is deregistered while a new one is waiting to take over,
+// the new one takes over and its fingerprints reach the node.
+func TestManager_UpgradePlugin_DeregisterServing(t *testing.T) {
+	registry := setupRegistry(nil)
+	defer registry.Shutdown()
+
+	pm := testManager(t, registry, time.Hour)
+	defer pm.Shutdown()
+
+	var lock sync.Mutex
+	var lastInfo *structs.CSIInfo
+	pm.updateNodeCSIInfoFunc = func(_ string, info *structs.CSIInfo) {
+		lock.Lock()
+		defer lock.Unlock()
+		lastInfo = info
+	}
+
+	plugin0 := fakePlugin(0, dynamicplugins.PluginTypeCSINode)
+	plugin1 := fakePlugin(1, dynamicplugins.PluginTypeCSINode)
+	gate := make(chan struct{})
+	clients := map[string]csi.CSIPlugin{
+		plugin0.ConnectionInfo.SocketPath: healthyNodeClient(),
+		plugin1.ConnectionInfo.SocketPath: &gatedClient{Client: healthyNodeClient(), gate: gate},
+	}
+	pm.newClient = func(addr string, _ hclog.Logger) csi.CSIPlugin {
+		return clients[addr]
+	}
+	pm.Run()
+
+	require.NoError(t, registry.RegisterPlugin(plugin0))
+	require.Eventually(t, func() bool {
+		mgrs := pm.instancesFor(plugin0.Type, plugin0.Name)
+		return len(mgrs) == 1 && mgrs[0].isHealthy()
+	}, 5*time.Second, 10*time.Millisecond, "alloc-0 plugin did not become healthy")
+
+	require.NoError(t, registry.RegisterPlugin(plugin1))
+	require.Eventually(t, func() bool {
+		return len(pm.instancesFor(plugin0.Type, plugin0.Name)) == 2
+	}, 5*time.Second, 10*time.Millisecond, "alloc-1 plugin was not started")
+
+	// the serving allocation goes away before the new one is healthy
+	require.NoError(t, registry.DeregisterPlugin(plugin0.Type, plugin0.Name, plugin0.AllocID))
+	require.Eventually(t, func() bool {
+		mgrs := pm.instancesFor(plugin0.Type, plugin0.Name)
+		return len(mgrs) == 1 && mgrs[0].allocID == plugin1.AllocID
+	}, 5*time.Second, 10*time.Millisecond, "alloc-0 plugin was not removed")
+
+	close(gate)
+	require.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return lastInfo != nil && lastInfo.AllocID == plugin1.AllocID && lastInfo.Healthy
+	}, 5*time.Second, 10*time.Millisecond, "alloc-1 plugin fingerprint was not reported")
+}
+
+// TestManager_PluginLifecycle drives a node plugin through the manager from
+// registration, through mounting and unmounting a volume, to deregistration.
+func TestManager_PluginLifecycle(t *testing.T) {
+	if !checkMountSupport() {
+		t.Skip("mount point detection not supported for this platform")
+	}
+
+	registry := setupRegistry(nil)
+	defer registry.Shutdown()
+	pm := testManager(t, registry, time.Hour)
+	defer pm.Shutdown()
+
+	client := healthyNodeClient()
+	pm.newClient = func(string, hclog.Logger) csi.CSIPlugin {
+		return client
+	}
+	pm.Run()
+
+	mountDir := t.TempDir()
+	plugin := fakePlugin(0, dynamicplugins.PluginTypeCSINode)
+	plugin.Options = map[string]string{
+		"MountPoint":          mountDir,
+		"ContainerMountPoint": mountDir,
+	}
+	require.NoError(t, registry.RegisterPlugin(plugin))
+
+	var mgr *instanceManager
+	require.Eventually(t, func() bool {
+		mgrs := pm.instancesFor(plugin.Type, plugin.Name)
+		if len(mgrs) != 1 {
+			return false
+		}
+		mgr = mgrs[0]
+		return mgr.isHealthy()
+	}, 5*time.Second, 10*time.Millisecond, "plugin did not become healthy")
+
+	mounter, err := pm.MounterForPlugin(context.Background(), plugin.Name)
+	require.NoError(t, err)
+
+	vol := &structs.CSIVolume{ID: "vol", Namespace: "ns"}
+	alloc := mock.Alloc()
+	usage := &UsageOptions{
+		AttachmentMode: structs.CSIVolumeAttachmentModeFilesystem,
+		AccessMode:     structs.CSIVolumeAccessModeMultiNodeMultiWriter,
+	}
+	_, err = mounter.MountVolume(context.Background(), vol, alloc, usage, map[string]string{})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, client.NodePublishVolumeCallCount)
+
+	err = mounter.UnmountVolume(context.Background(), vol.ID, vol.RemoteID(), alloc.ID, usage)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, client.NodeUnpublishVolumeCallCount)
+
+	require.NoError(t, registry.DeregisterPlugin(plugin.Type, plugin.Name, plugin.AllocID))
+	require.Eventually(t, func() bool {
+		return len(pm.instancesFor(plugin.Type, plugin.Name)) == 0
+	}, 5*time.Second, 10*time.Millisecond, "plugin was not removed")
+
+	select {
+	case <-mgr.shutdownCh:
+	default:
+		t.Fatal("plugin was not shut down")
+	}
+
+	_, err = pm.MounterForPlugin(context.Background(), plugin.Name)
+	require.EqualError(t, err, "plugin my-plugin for type csi-node not found")
+}
+
+// TestManager_MounterForPlugin_SkipsUnhealthy ensures that mounts aren't
+// routed to an instance whose latest fingerprint was unhealthy.
+func TestManager_MounterForPlugin_SkipsUnhealthy(t *testing.T) {
+	registry := setupRegistry(nil)
+	defer registry.Shutdown()
+	pm := testManager(t, registry, time.Hour)
+	logger := testlog.HCLogger(t)
+
+	plugin0 := fakePlugin(0, dynamicplugins.PluginTypeCSINode)
+	plugin1 := fakePlugin(1, dynamicplugins.PluginTypeCSINode)
+	unhealthy := newInstanceManager(logger, pm.eventer, pm.updateNodeCSIInfoFunc, plugin0)
+	healthy := newInstanceManager(logger, pm.eventer, pm.updateNodeCSIInfoFunc, plugin1)
+	healthy.volumeManager = newVolumeManager(logger, pm.eventer, healthyNodeClient(), t.TempDir(), t.TempDir(), false)
+	close(healthy.volumeManagerSetupCh)
+	healthy.setHealthy(true)
+
+	pm.setInstances(plugin0.Type, plugin0.Name, []*instanceManager{unhealthy, healthy})
+
+	mounter, err := pm.MounterForPlugin(context.Background(), plugin0.Name)
+	require.NoError(t, err)
+	require.Same(t, healthy.volumeManager, mounter)
+
+	healthy.setHealthy(false)
+	_, err = pm.MounterForPlugin(context.Background(), plugin0.Name)
+	require.EqualError(t, err, "no healthy instance of plugin my-plugin for type csi-node")
+}
+
+// TestManager_MounterForPlugin_RoundRobin ensures that mounts alternate
+// between the healthy instances of a plugin when round-robin is enabled.
+func TestManager_MounterForPlugin_RoundRobin(t *testing.T) {
+	registry := setupRegistry(nil)
+	defer registry.Shutdown()
+	pm := New(&Config{
+		Logger:             testlog.HCLogger(t),
+		DynamicRegistry:    registry,
+		RoundRobinMounters: true,
+	}).(*csiManager)
+	logger := testlog.HCLogger(t)
+
+	var mgrs []*instanceManager
+	for i := 0; i < 3; i++ {
+		mgr := newInstanceManager(logger, pm.eventer, pm.updateNodeCSIInfoFunc, fakePlugin(i, dynamicplugins.PluginTypeCSINode))
+		mgr.volumeManager = newVolumeManager(logger, pm.eventer, healthyNodeClient(), t.TempDir(), t.TempDir(), false)
+		close(mgr.volumeManagerSetupCh)
+		mgr.setHealthy(true)
+		mgrs = append(mgrs, mgr)
+	}
+	pm.setInstances(dynamicplugins.PluginTypeCSINode, "my-plugin", mgrs)
+
+	// an unhealthy instance is skipped
+	mgrs[1].setHealthy(false)
+	for _, expected := range []*instanceManager{mgrs[0], mgrs[2], mgrs[0], mgrs[2]} {
+		mounter, err := pm.MounterForPlugin(context.Background(), "my-plugin")
+		require.NoError(t, err)
+		require.Same(t, expected.volumeManager, mounter)
+	}
+}
+
+// TestManager_UnpublishAllVolumes ensures that every volume mounted by a node
+// plugin is unpublished, and unstaged once unused, with errors aggregated.
+func TestManager_UnpublishAllVolumes(t *testing.T) {
+	registry := setupRegistry(nil)
+	defer registry.Shutdown()
+	pm := testManager(t, registry, time.Hour)
+	logger := testlog.HCLogger(t)
+
+	plugin0 := fakePlugin(0, dynamicplugins.PluginTypeCSINode)
+	plugin1 := fakePlugin(1, dynamicplugins.PluginTypeCSINode)
+	plugin2 := fakePlugin(2, dynamicplugins.PluginTypeCSINode)
+
+	usage := &UsageOptions{
+		AttachmentMode: structs.CSIVolumeAttachmentModeFilesystem,
+		AccessMode:     structs.CSIVolumeAccessModeMultiNodeMultiWriter,
+	}
+
+	client0 := healthyNodeClient()
+	mgr0 := newInstanceManager(logger, pm.eventer, pm.updateNodeCSIInfoFunc, plugin0)
+	mgr0.volumeManager = newVolumeManager(logger, pm.eventer, client0, t.TempDir(), t.TempDir(), true)
+	close(mgr0.volumeManagerSetupCh)
+	mgr0.volumeManager.usageTracker.Claim("alloc-a", "vol-1", "remote-1", usage)
+	mgr0.volumeManager.usageTracker.Claim("alloc-b", "vol-1", "remote-1", usage)
+	mgr0.volumeManager.usageTracker.Claim("alloc-a", "vol-2", "remote-2", usage)
+
+	client1 := healthyNodeClient()
+	client1.NextNodeUnpublishVolumeErr = errors.New("unpublish failed")
+	mgr1 := newInstanceManager(logger, pm.eventer, pm.updateNodeCSIInfoFunc, plugin1)
+	mgr1.volumeManager = newVolumeManager(logger, pm.eventer, client1, t.TempDir(), t.TempDir(), true)
+	close(mgr1.volumeManagerSetupCh)
+	mgr1.volumeManager.usageTracker.Claim("alloc-c", "vol-3", "remote-3", usage)
+
+	// an instance that was never fingerprinted has no volumes to unpublish
+	mgr2 := newInstanceManager(logger, pm.eventer, pm.updateNodeCSIInfoFunc, plugin2)
+
+	pm.setInstances(plugin0.Type, plugin0.Name, []*instanceManager{mgr0, mgr1, mgr2})
+
+	err := pm.UnpublishAllVolumes(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(),
+		`plugin my-plugin (alloc alloc-1): failed to unmount volume "vol-3" for alloc "alloc-c": unpublish failed`)
+
+	// each volume is unpublished for every alloc, and unstaged once
+	require.EqualValues(t, 3, client0.NodeUnpublishVolumeCallCount)
+	require.EqualValues(t, 2, client0.NodeUnstageVolumeCallCount)
+	require.Empty(t, mgr0.volumeManager.usageTracker.Usages())
+
+	// a volume that failed to unpublish is left staged and tracked
+	require.EqualValues(t, 1, client1.NodeUnpublishVolumeCallCount)
+	require.Zero(t, client1.NodeUnstageVolumeCallCount)
+	require.Len(t, mgr1.volumeManager.usageTracker.Usages(), 1)
+}
+
+// TestManager_UnpublishAllVolumes_Concurrent ensures that volumes can be
+// unpublished while the run loop is adding and removing plugin instances.
+func TestManager_UnpublishAllVolumes_Concurrent(t *testing.T) {
+	registry := setupRegistry(nil)
+	defer registry.Shutdown()
+	pm := testManager(t, registry, time.Hour)
+	defer pm.Shutdown()
+	pm.newClient = func(string, hclog.Logger) csi.CSIPlugin {
+		return healthyNodeClient()
+	}
+
+	// the registry broadcasts events while holding its lock, so wait for the
+	// run loop to have listed the plugins at startup before churning them
+	seed := fakePlugin(0, dynamicplugins.PluginTypeCSINode)
+	require.NoError(t, registry.RegisterPlugin(seed))
+	pm.Run()
+	require.Eventually(t, func() bool {
+		return len(pm.instancesFor(seed.Type, seed.Name)) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 1; i <= 20; i++ {
+			plugin := fakePlugin(i, dynamicplugins.PluginTypeCSINode)
+			plugin.Name = fmt.Sprintf("plugin-%d", i)
+			require.NoError(t, registry.RegisterPlugin(plugin))
+			require.NoError(t, registry.DeregisterPlugin(plugin.Type, plugin.Name, plugin.AllocID))
+		}
+	}()
+
+	for running := true; running; {
+		require.NoError(t, pm.UnpublishAllVolumes(context.Background()))
+		select {
+		case <-done:
+			running = false
+		default:
+		}
+	}
+
+	// events are delivered in order, so once the seed is gone the run loop
+	// has consumed them all and the registry can be shut down
+	require.NoError(t, registry.DeregisterPlugin(seed.Type, seed.Name, seed.AllocID))
+	require.Eventually(t, func() bool {
+		return len(pm.instancesFor(seed.Type, seed.Name)) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// TestManager_PluginCapabilities ensures that the capabilities a plugin
+// reports during its initial fingerprint are exposed by the manager.
+func TestManager_PluginCapabilities(t *testing.T) {
+	registry := setupRegistry(nil)
+	defer registry.Shutdown()
+	pm := testManager(t, registry, time.Hour)
+	defer pm.Shutdown()
+
+	plugin := fakePlugin(0, dynamicplugins.PluginTypeCSINode)
+	client := healthyNodeClient()
+	client.NextPluginGetCapabilitiesResponse = csi.NewPluginCapabilitySet(
+		&csipbv1.GetPluginCapabilitiesResponse{
+			Capabilities: []*csipbv1.PluginCapability{{
+				Type: &csipbv1.PluginCapability_VolumeExpansion_{
+					VolumeExpansion: &csipbv1.PluginCapability_VolumeExpansion{
+						Type: csipbv1.PluginCapability_VolumeExpansion_ONLINE,
+					},
+				},
+			}},
+		})
+	gate := make(chan struct{})
+	pm.newClient = func(string, hclog.Logger) csi.CSIPlugin {
+		return &gatedClient{Client: client, gate: gate}
+	}
+
+	_, err := pm.PluginCapabilities(plugin.Name)
+	require.EqualError(t, err, "plugin my-plugin not found")
+
+	pm.Run()
+	require.NoError(t, registry.RegisterPlugin(plugin))
+	require.Eventually(t, func() bool {
+		return len(pm.instancesFor(plugin.Type, plugin.Name)) > 0
+	}, 5*time.Second, 10*time.Millisecond, "plugin was not started")
+
+	_, err = pm.PluginCapabilities(plugin.Name)
+	require.EqualError(t, err, "plugin my-plugin has not completed its initial fingerprint")
+
+	close(gate)
+	var caps *csi.PluginCapabilitySet
+	require.Eventually(t, func() bool {
+		caps, err = pm.PluginCapabilities(plugin.Name)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond, "plugin was not fingerprinted")
+	require.True(t, caps.HasVolumeExpansion())
+	require.False(t, caps.HasControllerService())
+}
+
+// TestManager_RepublishNodeInfo ensures that the node is updated again with
+// the latest fingerprint of each plugin on demand.
+func TestManager_RepublishNodeInfo(t *testing.T) {
+	registry := setupRegistry(nil)
+	defer registry.Shutdown()
+	pm := testManager(t, registry, time.Hour)
+	defer pm.Shutdown()
+
+	var lock sync.Mutex
+	var updates []*structs.CSIInfo
+	pm.updateNodeCSIInfoFunc = func(_ string, info *structs.CSIInfo) {
+		lock.Lock()
+		defer lock.Unlock()
+		updates = append(updates, info)
+	}
+	numUpdates := func() int {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(updates)
+	}
+
+	plugin := fakePlugin(0, dynamicplugins.PluginTypeCSINode)
+	pm.newClient = func(string, hclog.Logger) csi.CSIPlugin {
+		return healthyNodeClient()
+	}
+	pm.Run()
+
+	// republishing before any plugin is fingerprinted is a no-op
+	pm.RepublishNodeInfo()
+
+	require.NoError(t, registry.RegisterPlugin(plugin))
+	require.Eventually(t, func() bool {
+		return numUpdates() == 1
+	}, 5*time.Second, 10*time.Millisecond, "plugin was not fingerprinted")
+
+	pm.RepublishNodeInfo()
+	require.Eventually(t, func() bool {
+		return numUpdates() == 2
+	}, 5*time.Second, 10*time.Millisecond, "node info was not republished")
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.True(t, updates[1].Healthy)
+	require.Equal(t, plugin.AllocID, updates[1].AllocID)
+	require.Equal(t, "foo", updates[1].NodeInfo.ID)
+	require.Equal(t, updates[0], updates[1])
+}
+
+// TestManager_DedupNodeInfoUpdates ensures that the node is only updated
+// when a plugin's fingerprint changes.
+func TestManager_DedupNodeInfoUpdates(t *testing.T) {
+	registry := setupRegistry(nil)
+	defer registry.Shutdown()
+	pm := testManager(t, registry, time.Hour)
+
+	var updates []*structs.CSIInfo
+	pm.updateNodeCSIInfoFunc = func(_ string, info *structs.CSIInfo) {
+		updates = append(updates, info)
+	}
+
+	client := healthyNodeClient()
+	mgr := pm.newInstance(fakePlugin(0, dynamicplugins.PluginTypeCSINode))
+	mgr.fp.client = client
+	fingerprint := func() {
+		mgr.report(mgr.fp.fingerprint(context.Background()))
+	}
+
+	// an identical fingerprint doesn't update the node again
+	fingerprint()
+	fingerprint()
+	require.Len(t, updates, 1)
+	require.True(t, updates[0].Healthy)
+
+	// a changed fingerprint does
+	client.NextPluginProbeResponse = false
+	fingerprint()
+	require.Len(t, updates, 2)
+	require.False(t, updates[1].Healthy)
+
+	// the same fingerprint from another type of the plugin is reported
+	// separately
+	controller := pm.newInstance(fakePlugin(0, dynamicplugins.PluginTypeCSIController))
+	controller.report(updates[1])
+	require.Len(t, updates, 3)
+}
+
+// TestManager_MaxInstancesPerPlugin ensures that registering more allocations
+// of a plugin than the limit evicts the oldest instances waiting to take over,
+// and never the one serving the plugin.
+func TestManager_MaxInstancesPerPlugin(t *testing.T) {
+	registry := setupRegistry(nil)
+	defer registry.Shutdown()
+
+	var lock sync.Mutex
+	var events []*structs.NodeEvent
+	pm := New(&Config{
+		Logger:             testlog.HCLogger(t),
+		DynamicRegistry:    registry,
+		PluginResyncPeriod: time.Hour, // no resync except from events
+		TriggerNodeEvent: func(event *structs.NodeEvent) {
+			lock.Lock()
+			defer lock.Unlock()
+			events = append(events, event)
+		},
+		MaxInstancesPerPlugin: 2,
+	}).(*csiManager)
+	defer pm.Shutdown()
+
+	// only the first allocation ever becomes healthy
+	gate := make(chan struct{})
+	defer close(gate)
+	plugin0 := fakePlugin(0, dynamicplugins.PluginTypeCSINode)
+	pm.newClient = func(addr string, _ hclog.Logger) csi.CSIPlugin {
+		if addr == plugin0.ConnectionInfo.SocketPath {
+			return healthyNodeClient()
+		}
+		return &gatedClient{Client: healthyNodeClient(), gate: gate}
+	}
+	pm.Run()
+
+	require.NoError(t, registry.RegisterPlugin(plugin0))
+	require.Eventually(t, func() bool {
+		mgrs := pm.instancesFor(plugin0.Type, plugin0.Name)
+		return len(mgrs) == 1 && mgrs[0].isHealthy()
+	}, 5*time.Second, 10*time.Millisecond, "alloc-0 plugin did not become healthy")
+
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, registry.RegisterPlugin(fakePlugin(i, dynamicplugins.PluginTypeCSINode)))
+	}
+
+	require.Eventually(t, func() bool {
+		mgrs := pm.instancesFor(plugin0.Type, plugin0.Name)
+		return len(mgrs) == 2 &&
+			mgrs[0].allocID == "alloc-0" &&
+			mgrs[1].allocID == "alloc-3"
+	}, 5*time.Second, 10*time.Millisecond, "instances were not limited")
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.Len(t, events, 2)
+	require.Equal(t, "alloc-1", events[0].Details["alloc_id"])
+	require.Equal(t, "alloc-2", events[1].Details["alloc_id"])
+	require.Equal(t, structs.NodeEventSubsystemStorage, events[0].Subsystem)
+}
+
 // MemDB implements a StateDB that stores data in memory and should only be
 // used for testing. All methods are safe for concurrent use. This is a
 // partial implementation of the MemDB in the client/state package, copied
@@ -7,13 +7,19 @@ import (
 // volumeUsageTracker tracks the allocations that depend on a given volume
 type volumeUsageTracker struct {
 	// state is a map of volumeUsageKey to a slice of allocation ids
-	state   map[volumeUsageKey][]string
+	state map[volumeUsageKey][]string
+
+	// remoteIDs is a map of the volume ids in state to the id of each volume
+	// in the storage provider
+	remoteIDs map[string]string
+
 	stateMu sync.Mutex
 }
 
 func newVolumeUsageTracker() *volumeUsageTracker {
 	return &volumeUsageTracker{
-		state: make(map[volumeUsageKey][]string),
+		state:     make(map[volumeUsageKey][]string),
+		remoteIDs: make(map[string]string),
 	}
 }
 
@@ -45,15 +51,24 @@ func (v *volumeUsageTracker) removeAlloc(key volumeUsageKey, needle string) {
 		delete(v.state, key)
 	} else {
 		v.state[key] = newAllocs
+		return
+	}
+
+	for k := range v.state {
+		if k.id == key.id {
+			return
+		}
 	}
+	delete(v.remoteIDs, key.id)
 }
 
-func (v *volumeUsageTracker) Claim(allocID, volID string, usage *UsageOptions) {
+func (v *volumeUsageTracker) Claim(allocID, volID, remoteID string, usage *UsageOptions) {
 	v.stateMu.Lock()
 	defer v.stateMu.Unlock()
 
 	key := volumeUsageKey{id: volID, usageOpts: *usage}
 	v.appendAlloc(key, allocID)
+	v.remoteIDs[volID] = remoteID
 }
 
 // Free removes the allocation from the state list for the given alloc. If the
@@ -67,3 +82,30 @@ func (v *volumeUsageTracker) Free(allocID, volID string, usage *UsageOptions) bo
 	allocs := v.allocsForKey(key)
 	return len(allocs) == 0
 }
+
+// volumeUsage is the use of a volume by an allocation.
+type volumeUsage struct {
+	volID     string
+	remoteID  string
+	allocID   string
+	usageOpts UsageOptions
+}
+
+// Usages returns every allocation's use of every volume being tracked.
+func (v *volumeUsageTracker) Usages() []volumeUsage {
+	v.stateMu.Lock()
+	defer v.stateMu.Unlock()
+
+	var usages []volumeUsage
+	for key, allocs := range v.state {
+		for _, allocID := range allocs {
+			usages = append(usages, volumeUsage{
+				volID:     key.id,
+				remoteID:  v.remoteIDs[key.id],
+				allocID:   allocID,
+				usageOpts: key.usageOpts,
+			})
+		}
+	}
+	return usages
+}
@@ -47,7 +47,7 @@ func TestUsageTracker(t *testing.T) {
 				ID: "foo",
 			}
 			for _, alloc := range tc.RegisterAllocs {
-				tracker.Claim(alloc.ID, volume.ID, &UsageOptions{})
+				tracker.Claim(alloc.ID, volume.ID, volume.RemoteID(), &UsageOptions{})
 			}
 
 			result := false
@@ -60,3 +60,22 @@ func TestUsageTracker(t *testing.T) {
 		})
 	}
 }
+
+func TestUsageTracker_Usages(t *testing.T) {
+	tracker := newVolumeUsageTracker()
+	usage := &UsageOptions{ReadOnly: true}
+
+	tracker.Claim("alloc-a", "vol-1", "remote-1", usage)
+	tracker.Claim("alloc-b", "vol-1", "remote-1", usage)
+	require.ElementsMatch(t, []volumeUsage{
+		{volID: "vol-1", remoteID: "remote-1", allocID: "alloc-a", usageOpts: *usage},
+		{volID: "vol-1", remoteID: "remote-1", allocID: "alloc-b", usageOpts: *usage},
+	}, tracker.Usages())
+
+	// the remote ID is forgotten once the volume is no longer used
+	require.False(t, tracker.Free("alloc-a", "vol-1", usage))
+	require.Equal(t, "remote-1", tracker.remoteIDs["vol-1"])
+	require.True(t, tracker.Free("alloc-b", "vol-1", usage))
+	require.Empty(t, tracker.remoteIDs)
+	require.Empty(t, tracker.Usages())
+}
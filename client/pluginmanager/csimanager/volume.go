@@ -252,7 +252,7 @@ func (v *volumeManager) MountVolume(ctx context.Context, vol *structs.CSIVolume,
 	}
 
 	if err == nil {
-		v.usageTracker.Claim(alloc.ID, vol.ID, usage)
+		v.usageTracker.Claim(alloc.ID, vol.ID, vol.RemoteID(), usage)
 	}
 
 	event := structs.NewNodeEvent().
@@ -373,3 +373,16 @@ func (v *volumeManager) UnmountVolume(ctx context.Context, volID, remoteID, allo
 
 	return err
 }
+
+// unmountAllVolumes unmounts every volume the manager has mounted for an
+// allocation, unstaging each volume once no allocation uses it.
+func (v *volumeManager) unmountAllVolumes(ctx context.Context) error {
+	var mErr *multierror.Error
+	for _, u := range v.usageTracker.Usages() {
+		usage := u.usageOpts
+		if err := v.UnmountVolume(ctx, u.volID, u.remoteID, u.allocID, &usage); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("failed to unmount volume %q for alloc %q: %v", u.volID, u.allocID, err))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
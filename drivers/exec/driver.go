@@ -2,28 +2,50 @@ package exec
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
 	"os"
+	osexec "os/exec"
+	"os/user"
 	"path/filepath"
+	"reflect"
+	"regexp"
 	"runtime"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/hashicorp/nomad/client/allocdir"
 	"github.com/hashicorp/nomad/client/lib/cgutil"
+	"github.com/hashicorp/nomad/client/taskenv"
 	"github.com/hashicorp/nomad/drivers/shared/capabilities"
+	"github.com/hashicorp/nomad/lib/cpuset"
 
+	"github.com/armon/circbuf"
 	"github.com/hashicorp/consul-template/signals"
+	envparse "github.com/hashicorp/go-envparse"
 	hclog "github.com/hashicorp/go-hclog"
+	multierror "github.com/hashicorp/go-multierror"
+	plugin "github.com/hashicorp/go-plugin"
 	"github.com/hashicorp/nomad/drivers/shared/eventer"
 	"github.com/hashicorp/nomad/drivers/shared/executor"
 	"github.com/hashicorp/nomad/drivers/shared/resolvconf"
 	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/escapingfs"
 	"github.com/hashicorp/nomad/helper/pluginutils/loader"
 	"github.com/hashicorp/nomad/plugins/base"
 	"github.com/hashicorp/nomad/plugins/drivers"
+	dproto "github.com/hashicorp/nomad/plugins/drivers/proto"
 	"github.com/hashicorp/nomad/plugins/drivers/utils"
 	"github.com/hashicorp/nomad/plugins/shared/hclspec"
 	pstructs "github.com/hashicorp/nomad/plugins/shared/structs"
+	"github.com/opencontainers/selinux/go-selinux"
+	"github.com/shirou/gopsutil/v3/mem"
 )
 
 const (
@@ -33,12 +55,52 @@ const (
 	// fingerprintPeriod is the interval at which the driver will send fingerprint responses
 	fingerprintPeriod = 30 * time.Second
 
+	// fingerprintCommandTimeout bounds how long each of the operator's
+	// fingerprint commands may run
+	fingerprintCommandTimeout = 5 * time.Second
+
+	// defaultExecTimeout bounds commands exec'd without a timeout when the
+	// default_exec_timeout isn't set
+	defaultExecTimeout = 30 * time.Second
+
 	// taskHandleVersion is the version of task handle which this driver sets
 	// and understands how to decode driver state
 	taskHandleVersion = 1
+
+	// onlineCPUsPath lists the CPUs which are online on the node
+	onlineCPUsPath = "/sys/devices/system/cpu/online"
+
+	// onlineMemsPath lists the NUMA memory nodes which are online on the node
+	onlineMemsPath = "/sys/devices/system/node/online"
+
+	// apparmorProfilesPath lists the AppArmor profiles loaded on the node
+	apparmorProfilesPath = "/sys/kernel/security/apparmor/profiles"
+
+	// nrOpenPath holds the most open files the kernel allows any process,
+	// which bounds the nofile rlimit
+	nrOpenPath = "/proc/sys/fs/nr_open"
+
+	// vaultTokenEnv is the environment variable holding the Vault token of
+	// the task, which is redacted from errors
+	vaultTokenEnv = "VAULT_TOKEN"
+
+	// redactedValue replaces secrets redacted from errors and logs
+	redactedValue = "<redacted>"
+
+	// maxRuntimeKillTimeout is how long a task stopped for exceeding its
+	// max_runtime has to exit before it is killed
+	maxRuntimeKillTimeout = 5 * time.Second
+
+	// minStatsInterval is the shortest interval task stats are sampled at,
+	// as each sample reads the task's cgroup and every one of its processes
+	minStatsInterval = 100 * time.Millisecond
 )
 
 var (
+	// hostLocaltimePath is the host file describing the local timezone which
+	// is mounted into tasks that set propagate_timezone
+	hostLocaltimePath = "/etc/localtime"
+
 	// PluginID is the exec plugin metadata registered in the plugin
 	// catalog.
 	PluginID = loader.PluginID{
@@ -75,21 +137,81 @@ var (
 			hclspec.NewAttr("default_ipc_mode", "string", false),
 			hclspec.NewLiteral(`"private"`),
 		),
+		"userns_id_offset": hclspec.NewDefault(
+			hclspec.NewAttr("userns_id_offset", "number", false),
+			hclspec.NewLiteral("100000"),
+		),
 		"allow_caps": hclspec.NewDefault(
 			hclspec.NewAttr("allow_caps", "list(string)", false),
 			hclspec.NewLiteral(capabilities.HCLSpecLiteral),
 		),
+		"system_reserved_memory_mb":  hclspec.NewAttr("system_reserved_memory_mb", "number", false),
+		"max_concurrent_starts":      hclspec.NewAttr("max_concurrent_starts", "number", false),
+		"default_user":               hclspec.NewAttr("default_user", "string", false),
+		"allowed_users":              hclspec.NewAttr("allowed_users", "list(string)", false),
+		"secret_patterns":            hclspec.NewAttr("secret_patterns", "list(string)", false),
+		"disable_cgroups":            hclspec.NewAttr("disable_cgroups", "bool", false),
+		"fallback_to_host_isolation": hclspec.NewAttr("fallback_to_host_isolation", "bool", false),
+		"start_timeout":              hclspec.NewAttr("start_timeout", "string", false),
+		"kill_signal_grace":          hclspec.NewAttr("kill_signal_grace", "string", false),
+		"signal_tasks_on_shutdown":   hclspec.NewAttr("signal_tasks_on_shutdown", "bool", false),
+		"default_exec_timeout":       hclspec.NewAttr("default_exec_timeout", "string", false),
+		"fingerprint_commands":       hclspec.NewAttr("fingerprint_commands", "map(list(string))", false),
+		"default_oom_score_adj":      hclspec.NewAttr("default_oom_score_adj", "number", false),
+		"default_no_new_privileges":  hclspec.NewAttr("default_no_new_privileges", "bool", false),
+		"default_pids_limit":         hclspec.NewAttr("default_pids_limit", "number", false),
+		"validate_mount_sources":     hclspec.NewAttr("validate_mount_sources", "bool", false),
+		"allowed_device_globs":       hclspec.NewAttr("allowed_device_globs", "list(string)", false),
+		"default_seccomp_profile":    hclspec.NewAttr("default_seccomp_profile", "string", false),
 	})
 
 	// taskConfigSpec is the hcl specification for the driver config section of
 	// a task within a job. It is returned in the TaskConfigSchema RPC
 	taskConfigSpec = hclspec.NewObject(map[string]*hclspec.Spec{
-		"command":  hclspec.NewAttr("command", "string", true),
-		"args":     hclspec.NewAttr("args", "list(string)", false),
-		"pid_mode": hclspec.NewAttr("pid_mode", "string", false),
-		"ipc_mode": hclspec.NewAttr("ipc_mode", "string", false),
-		"cap_add":  hclspec.NewAttr("cap_add", "list(string)", false),
-		"cap_drop": hclspec.NewAttr("cap_drop", "list(string)", false),
+		"command":               hclspec.NewAttr("command", "string", true),
+		"args":                  hclspec.NewAttr("args", "list(string)", false),
+		"pid_mode":              hclspec.NewAttr("pid_mode", "string", false),
+		"ipc_mode":              hclspec.NewAttr("ipc_mode", "string", false),
+		"cap_add":               hclspec.NewAttr("cap_add", "list(string)", false),
+		"cap_drop":              hclspec.NewAttr("cap_drop", "list(string)", false),
+		"nice":                  hclspec.NewAttr("nice", "number", false),
+		"io_class":              hclspec.NewAttr("io_class", "string", false),
+		"io_priority":           hclspec.NewAttr("io_priority", "number", false),
+		"cpuset_cpus":           hclspec.NewAttr("cpuset_cpus", "string", false),
+		"cpuset_mems":           hclspec.NewAttr("cpuset_mems", "string", false),
+		"propagate_timezone":    hclspec.NewAttr("propagate_timezone", "bool", false),
+		"env_file":              hclspec.NewAttr("env_file", "string", false),
+		"mount_namespace_only":  hclspec.NewAttr("mount_namespace_only", "bool", false),
+		"max_runtime":           hclspec.NewAttr("max_runtime", "string", false),
+		"memory_swappiness":     hclspec.NewAttr("memory_swappiness", "number", false),
+		"memory_swap_mb":        hclspec.NewAttr("memory_swap_mb", "number", false),
+		"pids_limit":            hclspec.NewAttr("pids_limit", "number", false),
+		"chown_task_dir":        hclspec.NewAttr("chown_task_dir", "bool", false),
+		"healthy_cpu_threshold": hclspec.NewAttr("healthy_cpu_threshold", "number", false),
+		"healthy_quiet_period":  hclspec.NewAttr("healthy_quiet_period", "string", false),
+		"apparmor_profile":      hclspec.NewAttr("apparmor_profile", "string", false),
+		"oom_score_adj":         hclspec.NewAttr("oom_score_adj", "number", false),
+		"no_new_privileges":     hclspec.NewAttr("no_new_privileges", "bool", false),
+		"userns_mode":           hclspec.NewAttr("userns_mode", "string", false),
+		"stop_signal":           hclspec.NewAttr("stop_signal", "string", false),
+		"hostname":              hclspec.NewAttr("hostname", "string", false),
+		"uts_mode":              hclspec.NewAttr("uts_mode", "string", false),
+		"seccomp_profile":       hclspec.NewAttr("seccomp_profile", "string", false),
+		"groups":                hclspec.NewAttr("groups", "list(string)", false),
+		"work_dir":              hclspec.NewAttr("work_dir", "string", false),
+		"selinux_relabel":       hclspec.NewAttr("selinux_relabel", "bool", false),
+		"rlimits": hclspec.NewBlockMap("rlimits", []string{"name"}, hclspec.NewObject(map[string]*hclspec.Spec{
+			"soft": hclspec.NewAttr("soft", "number", true),
+			"hard": hclspec.NewAttr("hard", "number", true),
+		})),
+		"tmpfs": hclspec.NewBlockList("tmpfs", hclspec.NewObject(map[string]*hclspec.Spec{
+			"path":    hclspec.NewAttr("path", "string", true),
+			"size_mb": hclspec.NewAttr("size_mb", "number", true),
+			"mode": hclspec.NewDefault(
+				hclspec.NewAttr("mode", "string", false),
+				hclspec.NewLiteral(`"1777"`),
+			),
+		})),
 	})
 
 	// driverCapabilities represents the RPC response for what features are
@@ -103,6 +225,7 @@ var (
 			drivers.NetIsolationModeGroup,
 		},
 		MountConfigs: drivers.MountConfigSupportAll,
+		TailLogs:     true,
 	}
 )
 
@@ -133,6 +256,31 @@ type Driver struct {
 	// whether it has been successful
 	fingerprintSuccess *bool
 	fingerprintLock    sync.Mutex
+
+	// availableMemory returns the memory available on the node in bytes
+	availableMemory func() (uint64, error)
+
+	// createExecutor launches the executor plugin used to run a task
+	createExecutor func(hclog.Logger, *base.ClientDriverConfig, *executor.ExecutorConfig) (executor.Executor, *plugin.Client, error)
+
+	// startSem bounds the number of tasks being started concurrently when
+	// max_concurrent_starts is set
+	startSem chan struct{}
+
+	// startTimeout bounds how long launching a task may take when
+	// start_timeout is set
+	startTimeout time.Duration
+
+	// killSignalGrace is how long a stopped task is given to exit before it
+	// is killed when kill_signal_grace is set
+	killSignalGrace time.Duration
+
+	// defaultExecTimeout bounds commands exec'd without a timeout when
+	// default_exec_timeout is set
+	defaultExecTimeout time.Duration
+
+	// secretPatterns are the compiled secret_patterns
+	secretPatterns []*regexp.Regexp
 }
 
 // Config is the driver configuration set by the SetConfig RPC call
@@ -152,27 +300,194 @@ type Config struct {
 	// AllowCaps configures which Linux Capabilities are enabled for tasks
 	// running on this node.
 	AllowCaps []string `codec:"allow_caps"`
+
+	// SystemReservedMemoryMB is the amount of memory which must remain
+	// available on the node for the system. Tasks whose memory would cut into
+	// this reservation are refused.
+	SystemReservedMemoryMB int `codec:"system_reserved_memory_mb"`
+
+	// MaxConcurrentStarts limits the number of tasks which may be started
+	// at the same time. Additional starts wait for a slot. Zero means no limit.
+	MaxConcurrentStarts int `codec:"max_concurrent_starts"`
+
+	// DefaultUser is the user tasks run as when they do not set one. Tasks
+	// run as nobody if unset.
+	DefaultUser string `codec:"default_user"`
+
+	// AllowedUsers restricts the users tasks may run as. An empty list allows
+	// any user.
+	AllowedUsers []string `codec:"allowed_users"`
+
+	// SecretPatterns are regular expressions matching secrets which are
+	// redacted from task start errors and logs.
+	SecretPatterns []string `codec:"secret_patterns"`
+
+	// DisableCgroups runs tasks without a cgroup of their own. Resource
+	// limits are not enforced and resource usage is not measured.
+	DisableCgroups bool `codec:"disable_cgroups"`
+
+	// FallbackToHostIsolation runs tasks in the host IPC namespace, rather
+	// than failing them, when a private IPC namespace isn't available.
+	FallbackToHostIsolation bool `codec:"fallback_to_host_isolation"`
+
+	// StartTimeout bounds how long setting up a task's isolation and
+	// launching it may take, as a duration such as "1m". Zero means no limit.
+	StartTimeout string `codec:"start_timeout"`
+
+	// KillSignalGrace is how long a task is given to exit after being sent
+	// its stop signal before it is killed with SIGKILL, as a duration such
+	// as "10s". It takes the place of the timeout given when stopping the
+	// task, unless empty.
+	KillSignalGrace string `codec:"kill_signal_grace"`
+
+	// SignalTasksOnShutdown sends every running task its stop signal when
+	// the driver shuts down. Otherwise tasks keep running, so that they can
+	// be recovered when the driver is restarted.
+	SignalTasksOnShutdown bool `codec:"signal_tasks_on_shutdown"`
+
+	// DefaultExecTimeout bounds how long commands exec'd in a task may run
+	// when no timeout is given, as a duration such as "30s". Defaults to 30
+	// seconds.
+	DefaultExecTimeout string `codec:"default_exec_timeout"`
+
+	// FingerprintCommands are commands, keyed by name, which are run when
+	// fingerprinting the node. The output of each is reported as the
+	// driver.exec.custom.<name> attribute.
+	FingerprintCommands map[string][]string `codec:"fingerprint_commands"`
+
+	// DefaultOOMScoreAdj is the oom_score_adj of tasks which do not set
+	// their own, from -1000 to 1000.
+	DefaultOOMScoreAdj int `codec:"default_oom_score_adj"`
+
+	// DefaultNoNewPrivileges prevents tasks from gaining privileges, such as
+	// by running setuid binaries. Tasks may not opt out of it.
+	DefaultNoNewPrivileges bool `codec:"default_no_new_privileges"`
+
+	// DefaultPidsLimit is the maximum number of processes in each task's
+	// pids cgroup. Tasks may lower it but not raise it. Unlimited if zero.
+	DefaultPidsLimit int64 `codec:"default_pids_limit"`
+
+	// ValidateMountSources checks that the host path of every mount exists
+	// before a task is started, so that a missing path is reported clearly
+	// rather than failing while the task's isolation is set up.
+	ValidateMountSources bool `codec:"validate_mount_sources"`
+
+	// UsernsIDOffset is the host user and group ID that root maps to in the
+	// user namespace of tasks with a private userns_mode.
+	UsernsIDOffset int64 `codec:"userns_id_offset"`
+
+	// AllowedDeviceGlobs are glob patterns, such as "/dev/nvidia*", of host
+	// devices which are made available to every task with read, write and
+	// mknod access.
+	AllowedDeviceGlobs []string `codec:"allowed_device_globs"`
+
+	// DefaultSeccompProfile is the host path of an OCI seccomp profile, in
+	// JSON, which filters the syscalls of tasks that don't set their own.
+	DefaultSeccompProfile string `codec:"default_seccomp_profile"`
 }
 
 func (c *Config) validate() error {
+	var mErr *multierror.Error
+
 	switch c.DefaultModePID {
 	case executor.IsolationModePrivate, executor.IsolationModeHost:
 	default:
-		return fmt.Errorf("default_pid_mode must be %q or %q, got %q", executor.IsolationModePrivate, executor.IsolationModeHost, c.DefaultModePID)
+		mErr = multierror.Append(mErr, fmt.Errorf("default_pid_mode must be %q or %q, got %q", executor.IsolationModePrivate, executor.IsolationModeHost, c.DefaultModePID))
 	}
 
 	switch c.DefaultModeIPC {
 	case executor.IsolationModePrivate, executor.IsolationModeHost:
 	default:
-		return fmt.Errorf("default_ipc_mode must be %q or %q, got %q", executor.IsolationModePrivate, executor.IsolationModeHost, c.DefaultModeIPC)
+		mErr = multierror.Append(mErr, fmt.Errorf("default_ipc_mode must be %q or %q, got %q", executor.IsolationModePrivate, executor.IsolationModeHost, c.DefaultModeIPC))
 	}
 
 	badCaps := capabilities.Supported().Difference(capabilities.New(c.AllowCaps))
 	if !badCaps.Empty() {
-		return fmt.Errorf("allow_caps configured with capabilities not supported by system: %s", badCaps)
+		mErr = multierror.Append(mErr, fmt.Errorf("allow_caps configured with capabilities not supported by system: %s", badCaps))
 	}
 
-	return nil
+	if c.SystemReservedMemoryMB < 0 {
+		mErr = multierror.Append(mErr, fmt.Errorf("system_reserved_memory_mb must not be negative, got %d", c.SystemReservedMemoryMB))
+	}
+
+	if c.MaxConcurrentStarts < 0 {
+		mErr = multierror.Append(mErr, fmt.Errorf("max_concurrent_starts must not be negative, got %d", c.MaxConcurrentStarts))
+	}
+
+	for _, pattern := range c.SecretPatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("secret_patterns contains invalid pattern %q: %v", pattern, err))
+		}
+	}
+
+	if c.StartTimeout != "" {
+		if d, err := time.ParseDuration(c.StartTimeout); err != nil || d <= 0 {
+			mErr = multierror.Append(mErr, fmt.Errorf("start_timeout must be a positive duration, got %q", c.StartTimeout))
+		}
+	}
+
+	if c.KillSignalGrace != "" {
+		if d, err := time.ParseDuration(c.KillSignalGrace); err != nil || d <= 0 {
+			mErr = multierror.Append(mErr, fmt.Errorf("kill_signal_grace must be a positive duration, got %q", c.KillSignalGrace))
+		}
+	}
+
+	if c.DefaultExecTimeout != "" {
+		if d, err := time.ParseDuration(c.DefaultExecTimeout); err != nil || d <= 0 {
+			mErr = multierror.Append(mErr, fmt.Errorf("default_exec_timeout must be a positive duration, got %q", c.DefaultExecTimeout))
+		}
+	}
+
+	if c.UsernsIDOffset < 0 || c.UsernsIDOffset > math.MaxUint32-executor.UsernsIDCount+1 {
+		mErr = multierror.Append(mErr, fmt.Errorf("userns_id_offset must be between 0 and %d, got %d", math.MaxUint32-executor.UsernsIDCount+1, c.UsernsIDOffset))
+	}
+
+	for _, glob := range c.AllowedDeviceGlobs {
+		if !filepath.IsAbs(glob) {
+			mErr = multierror.Append(mErr, fmt.Errorf("allowed_device_globs must contain absolute paths, got %q", glob))
+		}
+		if _, err := filepath.Match(glob, ""); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("allowed_device_globs contains invalid pattern %q: %v", glob, err))
+		}
+	}
+
+	if c.DefaultSeccompProfile != "" && !filepath.IsAbs(c.DefaultSeccompProfile) {
+		mErr = multierror.Append(mErr, fmt.Errorf("default_seccomp_profile must be an absolute path, got %q", c.DefaultSeccompProfile))
+	}
+
+	if c.DefaultOOMScoreAdj < -1000 || c.DefaultOOMScoreAdj > 1000 {
+		mErr = multierror.Append(mErr, fmt.Errorf("default_oom_score_adj must be between -1000 and 1000, got %d", c.DefaultOOMScoreAdj))
+	}
+
+	if c.DefaultPidsLimit < 0 {
+		mErr = multierror.Append(mErr, fmt.Errorf("default_pids_limit must not be negative, got %d", c.DefaultPidsLimit))
+	}
+
+	for name, command := range c.FingerprintCommands {
+		if name == "" {
+			mErr = multierror.Append(mErr, fmt.Errorf("fingerprint_commands must not contain an empty name"))
+		}
+		if len(command) == 0 || command[0] == "" {
+			mErr = multierror.Append(mErr, fmt.Errorf("fingerprint_commands %q must not be empty", name))
+		}
+	}
+
+	if c.DefaultUser != "" {
+		if _, err := user.Lookup(c.DefaultUser); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("default_user %q not found on host: %v", c.DefaultUser, err))
+		}
+	}
+
+	return validationError(mErr)
+}
+
+// validationError returns the problems found validating a config: a single
+// problem as is, or all of them together.
+func validationError(mErr *multierror.Error) error {
+	if mErr != nil && len(mErr.Errors) == 1 {
+		return mErr.Errors[0]
+	}
+	return mErr.ErrorOrNil()
 }
 
 // TaskConfig is the driver configuration of a task within a job
@@ -196,31 +511,563 @@ type TaskConfig struct {
 
 	// CapDrop is a set of linux capabilities to disable.
 	CapDrop []string `codec:"cap_drop"`
+
+	// Nice is the scheduling priority of the task, from -20 (highest) to 19
+	// (lowest).
+	Nice int `codec:"nice"`
+
+	// IOClass is the I/O scheduling class of the task. Must be "realtime",
+	// "best-effort" or "idle" if set.
+	IOClass string `codec:"io_class"`
+
+	// IOPriority is the I/O scheduling priority within IOClass, from 0
+	// (highest) to 7 (lowest).
+	IOPriority int `codec:"io_priority"`
+
+	// CpusetCpus is the set of CPUs the task is pinned to, in the Linux cpuset
+	// list format (e.g. "0-3,8").
+	CpusetCpus string `codec:"cpuset_cpus"`
+
+	// CpusetMems is the set of NUMA memory nodes the task may allocate memory
+	// from, in the Linux cpuset list format (e.g. "0-1").
+	CpusetMems string `codec:"cpuset_mems"`
+
+	// PropagateTimezone bind mounts the host's /etc/localtime into the task
+	// so that it shares the timezone of the host.
+	PropagateTimezone bool `codec:"propagate_timezone"`
+
+	// EnvFile is the path, relative to the task directory, of a file of
+	// KEY=VALUE lines merged into the environment of the task.
+	EnvFile string `codec:"env_file"`
+
+	// MountNamespaceOnly runs the task with the host's root filesystem in a
+	// private mount namespace instead of chrooting it into the task directory.
+	MountNamespaceOnly bool `codec:"mount_namespace_only"`
+
+	// MaxRuntime is how long the task may run, as a duration such as "1h",
+	// before the driver stops it.
+	MaxRuntime string `codec:"max_runtime"`
+
+	// MemorySwappiness is the swappiness of the task's memory cgroup, from 0
+	// to 100, or -1 to inherit it. Swap is disabled for the task if unset.
+	MemorySwappiness *int `codec:"memory_swappiness"`
+
+	// MemorySwapMB limits the combined memory and swap usage of the task.
+	// It must be at least the task's memory limit.
+	MemorySwapMB int64 `codec:"memory_swap_mb"`
+
+	// PidsLimit is the maximum number of processes in the task's pids
+	// cgroup, capped at the driver's default_pids_limit.
+	PidsLimit int64 `codec:"pids_limit"`
+
+	// ChownTaskDir changes the owner of the task's local, secrets and tmp
+	// directories to the task user before the task starts.
+	ChownTaskDir bool `codec:"chown_task_dir"`
+
+	// HealthyCpuThreshold is the CPU usage, as a percentage of one core,
+	// which the task must stay below for HealthyQuietPeriod before it is
+	// reported as started. It lets a task finish a CPU heavy initialization
+	// before it is considered healthy.
+	HealthyCpuThreshold float64 `codec:"healthy_cpu_threshold"`
+
+	// HealthyQuietPeriod is how long the task's CPU usage must stay below
+	// HealthyCpuThreshold, as a duration such as "10s".
+	HealthyQuietPeriod string `codec:"healthy_quiet_period"`
+
+	// ApparmorProfile is the name of an AppArmor profile loaded on the host
+	// which the task is confined by.
+	ApparmorProfile string `codec:"apparmor_profile"`
+
+	// OOMScoreAdj is the oom_score_adj of the task, from -1000 to 1000,
+	// overriding the driver's default_oom_score_adj. Tasks with a lower
+	// value are killed later when the node runs out of memory.
+	OOMScoreAdj *int `codec:"oom_score_adj"`
+
+	// NoNewPrivileges prevents the task from gaining privileges, such as by
+	// running setuid binaries. It may only be disabled if the driver's
+	// default_no_new_privileges is too.
+	NoNewPrivileges *bool `codec:"no_new_privileges"`
+
+	// ModeUserns indicates whether the task runs in a private user namespace,
+	// where root maps to an unprivileged user on the host. Must be "private"
+	// or "host" if set.
+	ModeUserns string `codec:"userns_mode"`
+
+	// StopSignal is the signal sent to stop the task when the caller of
+	// StopTask doesn't give one, taking precedence over the kill signal.
+	StopSignal string `codec:"stop_signal"`
+
+	// Tmpfs are in-memory filesystems mounted into the task.
+	Tmpfs []TmpfsMount `codec:"tmpfs"`
+
+	// Hostname is the hostname of the task. Tasks with a hostname run in a
+	// private UTS namespace.
+	Hostname string `codec:"hostname"`
+
+	// ModeUTS indicates whether the task runs in a private UTS namespace
+	// with its own hostname, which defaults to the allocation name. Must be
+	// "private" or "host" if set, and defaults to "private" when a hostname
+	// is set.
+	ModeUTS string `codec:"uts_mode"`
+
+	// SeccompProfile is the path, relative to the task directory, of an OCI
+	// seccomp profile in JSON which filters the task's syscalls. It takes
+	// the place of the driver's default_seccomp_profile.
+	SeccompProfile string `codec:"seccomp_profile"`
+
+	// Rlimits are the resource limits of the task's processes, keyed by the
+	// name of the resource, such as "nofile".
+	Rlimits map[string]Rlimit `codec:"rlimits"`
+
+	// Groups are the names of host groups the task's processes are given as
+	// supplementary groups.
+	Groups []string `codec:"groups"`
+
+	// WorkDir is the absolute path, as seen by the task, of the directory the
+	// task starts in. Defaults to the task's local directory.
+	WorkDir string `codec:"work_dir"`
+
+	// SELinuxRelabel relabels the host paths mounted into the task, so that
+	// the task can access them on hosts where SELinux is enforcing. The
+	// label is shared, letting other tasks use the same paths.
+	SELinuxRelabel bool `codec:"selinux_relabel"`
+}
+
+// Rlimit is a resource limit of a task's processes.
+type Rlimit struct {
+	// Soft is the limit processes are held to.
+	Soft uint64 `codec:"soft"`
+
+	// Hard is the most processes may raise the soft limit to.
+	Hard uint64 `codec:"hard"`
+}
+
+// TmpfsMount is an in-memory filesystem mounted into a task, which counts
+// towards the task's memory usage.
+type TmpfsMount struct {
+	// Path is where the filesystem is mounted inside the task.
+	Path string `codec:"path"`
+
+	// SizeMB is the most the filesystem may hold.
+	SizeMB int64 `codec:"size_mb"`
+
+	// Mode is the octal permissions of the filesystem's root directory,
+	// such as "1777".
+	Mode string `codec:"mode"`
+}
+
+// mode returns the parsed permissions of the filesystem's root directory.
+func (m *TmpfsMount) mode() (uint32, error) {
+	mode, err := strconv.ParseUint(m.Mode, 8, 32)
+	if err != nil || mode > 07777 {
+		return 0, fmt.Errorf("tmpfs mode for %q must be octal permissions such as \"1777\", got %q", m.Path, m.Mode)
+	}
+	return uint32(mode), nil
 }
 
 func (tc *TaskConfig) validate() error {
+	var mErr *multierror.Error
+
 	switch tc.ModePID {
 	case "", executor.IsolationModePrivate, executor.IsolationModeHost:
 	default:
-		return fmt.Errorf("pid_mode must be %q or %q, got %q", executor.IsolationModePrivate, executor.IsolationModeHost, tc.ModePID)
+		mErr = multierror.Append(mErr, fmt.Errorf("pid_mode must be %q or %q, got %q", executor.IsolationModePrivate, executor.IsolationModeHost, tc.ModePID))
 	}
 
 	switch tc.ModeIPC {
 	case "", executor.IsolationModePrivate, executor.IsolationModeHost:
 	default:
-		return fmt.Errorf("ipc_mode must be %q or %q, got %q", executor.IsolationModePrivate, executor.IsolationModeHost, tc.ModeIPC)
+		mErr = multierror.Append(mErr, fmt.Errorf("ipc_mode must be %q or %q, got %q", executor.IsolationModePrivate, executor.IsolationModeHost, tc.ModeIPC))
+	}
+
+	switch tc.ModeUserns {
+	case "", executor.IsolationModePrivate, executor.IsolationModeHost:
+	default:
+		mErr = multierror.Append(mErr, fmt.Errorf("userns_mode must be %q or %q, got %q", executor.IsolationModePrivate, executor.IsolationModeHost, tc.ModeUserns))
+	}
+
+	switch tc.ModeUTS {
+	case "", executor.IsolationModePrivate, executor.IsolationModeHost:
+	default:
+		mErr = multierror.Append(mErr, fmt.Errorf("uts_mode must be %q or %q, got %q", executor.IsolationModePrivate, executor.IsolationModeHost, tc.ModeUTS))
+	}
+
+	if tc.Hostname != "" {
+		if tc.ModeUTS == executor.IsolationModeHost {
+			mErr = multierror.Append(mErr, fmt.Errorf("hostname cannot be set with uts_mode %q", executor.IsolationModeHost))
+		}
+		if len(tc.Hostname) > maxHostnameLen || !validHostname.MatchString(tc.Hostname) {
+			mErr = multierror.Append(mErr, fmt.Errorf("hostname must be a valid hostname of at most %d characters, got %q", maxHostnameLen, tc.Hostname))
+		}
+	}
+
+	if tc.ModeUserns == executor.IsolationModePrivate && tc.ModePID == executor.IsolationModeHost {
+		mErr = multierror.Append(mErr, fmt.Errorf("userns_mode %q cannot be used with pid_mode %q", executor.IsolationModePrivate, executor.IsolationModeHost))
 	}
 
 	supported := capabilities.Supported()
 	badAdds := supported.Difference(capabilities.New(tc.CapAdd))
 	if !badAdds.Empty() {
-		return fmt.Errorf("cap_add configured with capabilities not supported by system: %s", badAdds)
+		mErr = multierror.Append(mErr, fmt.Errorf("cap_add configured with capabilities not supported by system: %s", badAdds))
 	}
 	badDrops := supported.Difference(capabilities.New(tc.CapDrop))
 	if !badDrops.Empty() {
-		return fmt.Errorf("cap_drop configured with capabilities not supported by system: %s", badDrops)
+		mErr = multierror.Append(mErr, fmt.Errorf("cap_drop configured with capabilities not supported by system: %s", badDrops))
+	}
+
+	if tc.Nice < -20 || tc.Nice > 19 {
+		mErr = multierror.Append(mErr, fmt.Errorf("nice must be between -20 and 19, got %d", tc.Nice))
+	}
+
+	switch tc.IOClass {
+	case "", executor.IOClassRealtime, executor.IOClassBestEffort, executor.IOClassIdle:
+	default:
+		mErr = multierror.Append(mErr, fmt.Errorf("io_class must be %q, %q or %q, got %q", executor.IOClassRealtime, executor.IOClassBestEffort, executor.IOClassIdle, tc.IOClass))
+	}
+
+	if tc.IOPriority < 0 || tc.IOPriority > 7 {
+		mErr = multierror.Append(mErr, fmt.Errorf("io_priority must be between 0 and 7, got %d", tc.IOPriority))
+	}
+
+	if tc.IOPriority != 0 && (tc.IOClass == "" || tc.IOClass == executor.IOClassIdle) {
+		mErr = multierror.Append(mErr, fmt.Errorf("io_priority requires io_class to be %q or %q", executor.IOClassRealtime, executor.IOClassBestEffort))
+	}
+
+	if _, err := cpuset.Parse(tc.CpusetCpus); err != nil {
+		mErr = multierror.Append(mErr, fmt.Errorf("cpuset_cpus %q is not a valid cpuset: %v", tc.CpusetCpus, err))
+	}
+
+	if _, err := cpuset.Parse(tc.CpusetMems); err != nil {
+		mErr = multierror.Append(mErr, fmt.Errorf("cpuset_mems %q is not a valid cpuset: %v", tc.CpusetMems, err))
+	}
+
+	if tc.MaxRuntime != "" {
+		if d, err := time.ParseDuration(tc.MaxRuntime); err != nil || d <= 0 {
+			mErr = multierror.Append(mErr, fmt.Errorf("max_runtime must be a positive duration, got %q", tc.MaxRuntime))
+		}
+	}
+
+	if tc.MemorySwappiness != nil && (*tc.MemorySwappiness < -1 || *tc.MemorySwappiness > 100) {
+		mErr = multierror.Append(mErr, fmt.Errorf("memory_swappiness must be between 0 and 100, or -1 to inherit, got %d", *tc.MemorySwappiness))
+	}
+
+	if tc.MemorySwapMB < 0 {
+		mErr = multierror.Append(mErr, fmt.Errorf("memory_swap_mb must not be negative, got %d", tc.MemorySwapMB))
+	}
+
+	for _, group := range tc.Groups {
+		if group == "" {
+			mErr = multierror.Append(mErr, fmt.Errorf("groups must not contain an empty name"))
+		}
+	}
+
+	if tc.WorkDir != "" && !filepath.IsAbs(tc.WorkDir) {
+		mErr = multierror.Append(mErr, fmt.Errorf("work_dir must be an absolute path, got %q", tc.WorkDir))
+	}
+
+	if tc.PidsLimit < 0 {
+		mErr = multierror.Append(mErr, fmt.Errorf("pids_limit must not be negative, got %d", tc.PidsLimit))
+	}
+
+	if tc.OOMScoreAdj != nil && (*tc.OOMScoreAdj < -1000 || *tc.OOMScoreAdj > 1000) {
+		mErr = multierror.Append(mErr, fmt.Errorf("oom_score_adj must be between -1000 and 1000, got %d", *tc.OOMScoreAdj))
+	}
+
+	if tc.HealthyCpuThreshold < 0 {
+		mErr = multierror.Append(mErr, fmt.Errorf("healthy_cpu_threshold must not be negative, got %v", tc.HealthyCpuThreshold))
+	}
+	if tc.HealthyQuietPeriod != "" {
+		if d, err := time.ParseDuration(tc.HealthyQuietPeriod); err != nil || d <= 0 {
+			mErr = multierror.Append(mErr, fmt.Errorf("healthy_quiet_period must be a positive duration, got %q", tc.HealthyQuietPeriod))
+		}
+	}
+	if (tc.HealthyCpuThreshold != 0) != (tc.HealthyQuietPeriod != "") {
+		mErr = multierror.Append(mErr, fmt.Errorf("healthy_cpu_threshold and healthy_quiet_period must be set together"))
+	}
+
+	if tc.StopSignal != "" {
+		if _, ok := signals.SignalLookup[tc.StopSignal]; !ok {
+			mErr = multierror.Append(mErr, fmt.Errorf("stop_signal %q is not a known signal", tc.StopSignal))
+		}
+	}
+
+	rlimitNames := make([]string, 0, len(tc.Rlimits))
+	for name := range tc.Rlimits {
+		rlimitNames = append(rlimitNames, name)
+	}
+	sort.Strings(rlimitNames)
+	for _, name := range rlimitNames {
+		rlimit := tc.Rlimits[name]
+		if !helper.SliceStringContains(executor.RlimitNames, name) {
+			mErr = multierror.Append(mErr, fmt.Errorf("rlimits contains unknown rlimit %q, must be one of %s", name, strings.Join(executor.RlimitNames, ", ")))
+		} else if rlimit.Soft > rlimit.Hard {
+			mErr = multierror.Append(mErr, fmt.Errorf("rlimit %q soft limit %d exceeds its hard limit %d", name, rlimit.Soft, rlimit.Hard))
+		}
+	}
+
+	for _, m := range tc.Tmpfs {
+		if !filepath.IsAbs(m.Path) {
+			mErr = multierror.Append(mErr, fmt.Errorf("tmpfs path must be absolute, got %q", m.Path))
+		}
+		if m.SizeMB <= 0 {
+			mErr = multierror.Append(mErr, fmt.Errorf("tmpfs size_mb for %q must be positive, got %d", m.Path, m.SizeMB))
+		}
+		if _, err := m.mode(); err != nil {
+			mErr = multierror.Append(mErr, err)
+		}
+	}
+
+	return validationError(mErr)
+}
+
+// noNewPrivileges returns whether the task runs with no_new_privileges, given
+// the driver's default. Tasks may enable it but not disable the default.
+func (tc *TaskConfig) noNewPrivileges(defaultNoNewPrivileges bool) (bool, error) {
+	if tc.NoNewPrivileges == nil {
+		return defaultNoNewPrivileges, nil
+	}
+	if defaultNoNewPrivileges && !*tc.NoNewPrivileges {
+		return false, fmt.Errorf("no_new_privileges cannot be disabled, as default_no_new_privileges is enabled in the exec driver")
+	}
+	return *tc.NoNewPrivileges, nil
+}
+
+// pidsLimit returns the task's limit on the number of processes, given the
+// driver's default. Zero means unlimited.
+func (tc *TaskConfig) pidsLimit(defaultPidsLimit int64) int64 {
+	if tc.PidsLimit == 0 || (defaultPidsLimit > 0 && tc.PidsLimit > defaultPidsLimit) {
+		return defaultPidsLimit
+	}
+	return tc.PidsLimit
+}
+
+// TaskConfigChange is a kind of difference between two configurations of a
+// task, letting callers decide how to apply an update to a running task.
+type TaskConfigChange string
+
+const (
+	TaskConfigChangeCommand TaskConfigChange = "command"
+	TaskConfigChangeArgs    TaskConfigChange = "args"
+	TaskConfigChangeEnv     TaskConfigChange = "env"
+	TaskConfigChangeMounts  TaskConfigChange = "mounts"
+	TaskConfigChangeDevices TaskConfigChange = "devices"
+	TaskConfigChangeCaps    TaskConfigChange = "caps"
+
+	// TaskConfigChangeOther is a change to any other option of the task's
+	// driver config.
+	TaskConfigChangeOther TaskConfigChange = "other"
+)
+
+// TaskConfigChanges classifies how the updated configuration of a task
+// differs from the old one, in the order the kinds of change are declared.
+// It returns no changes if the configurations are equivalent.
+func TaskConfigChanges(old, updated *drivers.TaskConfig) ([]TaskConfigChange, error) {
+	var oldConfig, updatedConfig TaskConfig
+	if err := old.DecodeDriverConfig(&oldConfig); err != nil {
+		return nil, fmt.Errorf("failed to decode driver config: %v", err)
+	}
+	if err := updated.DecodeDriverConfig(&updatedConfig); err != nil {
+		return nil, fmt.Errorf("failed to decode driver config: %v", err)
+	}
+
+	var changes []TaskConfigChange
+	if oldConfig.Command != updatedConfig.Command {
+		changes = append(changes, TaskConfigChangeCommand)
+	}
+	if !stringsEqual(oldConfig.Args, updatedConfig.Args) {
+		changes = append(changes, TaskConfigChangeArgs)
+	}
+	if !envEqual(old.Env, updated.Env) || oldConfig.EnvFile != updatedConfig.EnvFile {
+		changes = append(changes, TaskConfigChangeEnv)
+	}
+	if !mountsEqual(old.Mounts, updated.Mounts) ||
+		!tmpfsEqual(oldConfig.Tmpfs, updatedConfig.Tmpfs) ||
+		oldConfig.PropagateTimezone != updatedConfig.PropagateTimezone ||
+		oldConfig.SELinuxRelabel != updatedConfig.SELinuxRelabel {
+		changes = append(changes, TaskConfigChangeMounts)
+	}
+	if !devicesEqual(old.Devices, updated.Devices) {
+		changes = append(changes, TaskConfigChangeDevices)
+	}
+	if !capsEqual(oldConfig.CapAdd, updatedConfig.CapAdd) || !capsEqual(oldConfig.CapDrop, updatedConfig.CapDrop) {
+		changes = append(changes, TaskConfigChangeCaps)
+	}
+
+	// clear the options classified above and compare whatever remains
+	for _, tc := range []*TaskConfig{&oldConfig, &updatedConfig} {
+		tc.Command, tc.Args, tc.EnvFile = "", nil, ""
+		tc.Tmpfs, tc.PropagateTimezone, tc.SELinuxRelabel = nil, false, false
+		tc.CapAdd, tc.CapDrop = nil, nil
+	}
+	if !reflect.DeepEqual(oldConfig, updatedConfig) {
+		changes = append(changes, TaskConfigChangeOther)
+	}
+	return changes, nil
+}
+
+func stringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// capsEqual compares lists of capabilities however their names are written.
+func capsEqual(a, b []string) bool {
+	return stringsEqual(capabilities.New(a).Slice(false), capabilities.New(b).Slice(false))
+}
+
+func envEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
+func mountsEqual(a, b []*drivers.MountConfig) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].IsEqual(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func tmpfsEqual(a, b []TmpfsMount) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func devicesEqual(a, b []*drivers.DeviceConfig) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if *a[i] != *b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// validateCpusetAvailable ensures the requested cpuset is a subset of the one
+// listed in the given sysfs file, such as the node's online CPUs.
+func validateCpusetAvailable(field, requested, sysfsPath string) error {
+	if requested == "" {
+		return nil
+	}
+
+	want, err := cpuset.Parse(requested)
+	if err != nil {
+		return fmt.Errorf("%s %q is not a valid cpuset: %v", field, requested, err)
 	}
 
+	b, err := ioutil.ReadFile(sysfsPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", sysfsPath, err)
+	}
+	available, err := cpuset.Parse(string(b))
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %v", sysfsPath, err)
+	}
+
+	if missing := want.Difference(available); missing.Size() > 0 {
+		return fmt.Errorf("%s configured with values not available on this node: %s", field, missing)
+	}
+	return nil
+}
+
+// validateCpusetReserved ensures the requested cpuset only contains cores
+// reserved for the task's allocation, when it has reserved cores.
+func validateCpusetReserved(requested string, cfg *drivers.TaskConfig) error {
+	if requested == "" || cfg.Resources == nil || cfg.Resources.NomadResources == nil {
+		return nil
+	}
+	reserved := cfg.Resources.NomadResources.Cpu.ReservedCores
+	if len(reserved) == 0 {
+		return nil
+	}
+
+	want, err := cpuset.Parse(requested)
+	if err != nil {
+		return fmt.Errorf("cpuset_cpus %q is not a valid cpuset: %v", requested, err)
+	}
+	if missing := want.Difference(cpuset.New(reserved...)); missing.Size() > 0 {
+		return fmt.Errorf("cpuset_cpus configured with cores not reserved for the allocation: %s", missing)
+	}
+	return nil
+}
+
+// validateApparmorProfile ensures the AppArmor profile is loaded on the node,
+// according to the given list of loaded profiles.
+func validateApparmorProfile(profile, profilesPath string) error {
+	if profile == "" {
+		return nil
+	}
+
+	b, err := ioutil.ReadFile(profilesPath)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("apparmor_profile requires AppArmor, which is not enabled on this node")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", profilesPath, err)
+	}
+
+	// Each line is a profile name followed by its mode, e.g.
+	// "nomad-task (enforce)".
+	for _, line := range strings.Split(string(b), "\n") {
+		if i := strings.LastIndex(line, " ("); i >= 0 && line[:i] == profile {
+			return nil
+		}
+	}
+	return fmt.Errorf("apparmor_profile %q is not loaded on this node", profile)
+}
+
+// validateNofileRlimit ensures the task's nofile rlimit, if any, can be
+// enforced. Its hard limit can't exceed the kernel's fs.nr_open, read from
+// nrOpenPath, and the task can't have CAP_SYS_RESOURCE, which would let its
+// processes raise the hard limit.
+func validateNofileRlimit(rlimits map[string]Rlimit, caps []string, nrOpenPath string) error {
+	rlimit, ok := rlimits["nofile"]
+	if !ok {
+		return nil
+	}
+
+	if helper.SliceStringContains(caps, "CAP_SYS_RESOURCE") {
+		return fmt.Errorf("rlimit \"nofile\" can't be enforced for tasks with the sys_resource capability")
+	}
+
+	b, err := ioutil.ReadFile(nrOpenPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", nrOpenPath, err)
+	}
+	nrOpen, err := strconv.ParseUint(strings.TrimSpace(string(b)), 10, 64)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %v", nrOpenPath, err)
+	}
+	if rlimit.Hard > nrOpen {
+		return fmt.Errorf("rlimit \"nofile\" hard limit %d exceeds this node's fs.nr_open of %d", rlimit.Hard, nrOpen)
+	}
 	return nil
 }
 
@@ -232,17 +1079,59 @@ type TaskState struct {
 	TaskConfig     *drivers.TaskConfig
 	Pid            int
 	StartedAt      time.Time
+	MaxRuntime     time.Duration
 }
 
 // NewExecDriver returns a new DrivePlugin implementation
 func NewExecDriver(ctx context.Context, logger hclog.Logger) drivers.DriverPlugin {
 	logger = logger.Named(pluginName)
-	return &Driver{
-		eventer: eventer.NewEventer(ctx, logger),
-		tasks:   newTaskStore(),
-		ctx:     ctx,
-		logger:  logger,
+	d := &Driver{
+		eventer:         eventer.NewEventer(ctx, logger),
+		tasks:           newTaskStore(),
+		ctx:             ctx,
+		logger:          logger,
+		availableMemory: hostAvailableMemory,
+		createExecutor:  executor.CreateExecutor,
+	}
+	go d.signalTasksOnShutdown()
+	return d
+}
+
+// signalTasksOnShutdown waits for the driver to shut down, then sends every
+// running task its stop signal if signal_tasks_on_shutdown is set, so that
+// tasks aren't orphaned along with their executors.
+func (d *Driver) signalTasksOnShutdown() {
+	<-d.ctx.Done()
+	if !d.config.SignalTasksOnShutdown {
+		return
+	}
+
+	for _, h := range d.tasks.List() {
+		if !h.IsRunning() {
+			continue
+		}
+
+		signal := stopSignal(h.taskConfig)
+		sig, ok := signals.SignalLookup[signal]
+		if !ok {
+			d.logger.Warn("unknown stop signal of task, using SIGINT instead", "signal", signal, "task_id", h.taskConfig.ID)
+			sig = os.Interrupt
+		}
+		d.logger.Info("driver shutting down, signalling task", "task_id", h.taskConfig.ID, "task_name", h.taskConfig.Name, "signal", signal)
+		if err := h.exec.Signal(sig); err != nil {
+			d.logger.Warn("failed to signal task on shutdown", "task_id", h.taskConfig.ID, "task_name", h.taskConfig.Name, "error", err)
+		}
+	}
+}
+
+// hostAvailableMemory returns the memory available for starting new
+// processes on the host in bytes.
+func hostAvailableMemory() (uint64, error) {
+	vm, err := mem.VirtualMemory()
+	if err != nil {
+		return 0, err
 	}
+	return vm.Available, nil
 }
 
 // setFingerprintSuccess marks the driver as having fingerprinted successfully
@@ -288,15 +1177,41 @@ func (d *Driver) SetConfig(cfg *base.Config) error {
 	}
 	d.config = config
 
-	if cfg != nil && cfg.AgentConfig != nil {
-		d.nomadConfig = cfg.AgentConfig.Driver
+	d.secretPatterns = make([]*regexp.Regexp, 0, len(config.SecretPatterns))
+	for _, pattern := range config.SecretPatterns {
+		d.secretPatterns = append(d.secretPatterns, regexp.MustCompile(pattern))
 	}
-	return nil
-}
 
-func (d *Driver) TaskConfigSchema() (*hclspec.Spec, error) {
-	return taskConfigSpec, nil
-}
+	if config.MaxConcurrentStarts > 0 {
+		d.startSem = make(chan struct{}, config.MaxConcurrentStarts)
+	} else {
+		d.startSem = nil
+	}
+
+	d.startTimeout = 0
+	if config.StartTimeout != "" {
+		d.startTimeout, _ = time.ParseDuration(config.StartTimeout)
+	}
+
+	d.killSignalGrace = 0
+	if config.KillSignalGrace != "" {
+		d.killSignalGrace, _ = time.ParseDuration(config.KillSignalGrace)
+	}
+
+	d.defaultExecTimeout = 0
+	if config.DefaultExecTimeout != "" {
+		d.defaultExecTimeout, _ = time.ParseDuration(config.DefaultExecTimeout)
+	}
+
+	if cfg != nil && cfg.AgentConfig != nil {
+		d.nomadConfig = cfg.AgentConfig.Driver
+	}
+	return nil
+}
+
+func (d *Driver) TaskConfigSchema() (*hclspec.Spec, error) {
+	return taskConfigSpec, nil
+}
 
 // Capabilities is returned by the Capabilities RPC and indicates what
 // optional features this driver supports
@@ -348,29 +1263,237 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 		return fp
 	}
 
-	mount, err := cgutil.FindCgroupMountpointDir()
-	if err != nil {
-		fp.Health = drivers.HealthStateUnhealthy
-		fp.HealthDescription = drivers.NoCgroupMountMessage
-		if d.fingerprintSuccessful() {
-			d.logger.Warn(fp.HealthDescription, "error", err)
+	// tasks don't need cgroups when the driver doesn't create them
+	if !d.config.DisableCgroups {
+		mount, err := cgutil.FindCgroupMountpointDir()
+		if err != nil {
+			fp.Health = drivers.HealthStateUnhealthy
+			fp.HealthDescription = drivers.NoCgroupMountMessage
+			if d.fingerprintSuccessful() {
+				d.logger.Warn(fp.HealthDescription, "error", err)
+			}
+			d.setFingerprintFailure()
+			return fp
 		}
-		d.setFingerprintFailure()
-		return fp
-	}
 
-	if mount == "" {
-		fp.Health = drivers.HealthStateUnhealthy
-		fp.HealthDescription = drivers.CgroupMountEmpty
-		d.setFingerprintFailure()
-		return fp
+		if mount == "" {
+			fp.Health = drivers.HealthStateUnhealthy
+			fp.HealthDescription = drivers.CgroupMountEmpty
+			d.setFingerprintFailure()
+			return fp
+		}
 	}
 
 	fp.Attributes["driver.exec"] = pstructs.NewBoolAttribute(true)
+	fp.Attributes["driver.exec.isolation.pid"] = pstructs.NewStringAttribute(supportedIsolationModes("pid"))
+	fp.Attributes["driver.exec.isolation.ipc"] = pstructs.NewStringAttribute(supportedIsolationModes("ipc"))
+	fp.Attributes["driver.exec.allow_caps"] = pstructs.NewStringAttribute(strings.Join(d.allowedCapabilities(), ","))
+	d.fingerprintCommands(fp)
 	d.setFingerprintSuccess()
 	return fp
 }
 
+// fingerprintCommands runs the operator's fingerprint commands and sets an
+// attribute to the output of each one that succeeds.
+func (d *Driver) fingerprintCommands(fp *drivers.Fingerprint) {
+	names := make([]string, 0, len(d.config.FingerprintCommands))
+	for name := range d.config.FingerprintCommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		out, err := runFingerprintCommand(d.ctx, d.config.FingerprintCommands[name])
+		if err != nil {
+			d.logger.Warn("fingerprint command failed", "name", name, "error", err)
+			continue
+		}
+		fp.Attributes["driver.exec.custom."+name] = pstructs.NewStringAttribute(out)
+	}
+}
+
+// runFingerprintCommand runs the command and returns its trimmed output,
+// killing it if it doesn't finish within the fingerprint command timeout.
+func runFingerprintCommand(ctx context.Context, command []string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, fingerprintCommandTimeout)
+	defer cancel()
+
+	out, err := osexec.CommandContext(ctx, command[0], command[1:]...).Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", fmt.Errorf("timed out after %s", fingerprintCommandTimeout)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// supportedIsolationModes returns a comma separated list of the isolation
+// modes supported for the given namespace type on this node.
+func supportedIsolationModes(ns string) string {
+	modes := []string{executor.IsolationModeHost}
+	if namespaceSupported(ns) {
+		modes = append(modes, executor.IsolationModePrivate)
+	}
+	return strings.Join(modes, ",")
+}
+
+// cgroupControllers returns the cgroup controllers available to the task,
+// emitting a task event listing the requested limits that can't be applied
+// because their controller isn't available on this node.
+func (d *Driver) cgroupControllers(cfg *drivers.TaskConfig, driverConfig *TaskConfig, pidsLimit int64) []string {
+	controllers, err := availableCgroupControllers()
+	if err != nil {
+		d.logger.Warn("failed to detect available cgroup controllers, assuming all are available",
+			"task_id", cfg.ID, "task_name", cfg.Name, "error", err)
+		return nil
+	}
+
+	limits := unavailableCgroupLimits(cfg, driverConfig, pidsLimit, controllers)
+	if len(limits) == 0 {
+		return controllers
+	}
+
+	d.logger.Warn("cgroup controllers are unavailable, not applying some resource limits",
+		"task_id", cfg.ID, "task_name", cfg.Name, "limits", limits)
+	d.eventer.EmitEvent(&drivers.TaskEvent{
+		TaskID:    cfg.ID,
+		AllocID:   cfg.AllocID,
+		TaskName:  cfg.Name,
+		Timestamp: time.Now(),
+		Message: fmt.Sprintf("Resource limits not applied because their cgroup controller is unavailable: %s",
+			strings.Join(limits, ", ")),
+		Annotations: map[string]string{
+			"unapplied_limits": strings.Join(limits, ","),
+		},
+	})
+	return controllers
+}
+
+// unavailableCgroupLimits returns the limits requested for the task that are
+// enforced by a cgroup controller which isn't among the available controllers.
+func unavailableCgroupLimits(cfg *drivers.TaskConfig, driverConfig *TaskConfig, pidsLimit int64, controllers []string) []string {
+	if len(controllers) == 0 {
+		return nil
+	}
+	available := make(map[string]bool, len(controllers))
+	for _, c := range controllers {
+		available[c] = true
+	}
+
+	var cpuShares int64
+	if cfg.Resources != nil && cfg.Resources.NomadResources != nil {
+		cpuShares = cfg.Resources.NomadResources.Cpu.CpuShares
+	}
+
+	requested := []struct {
+		limit      string
+		controller string
+		set        bool
+	}{
+		{"cpu", "cpu", cpuShares > 0},
+		{"memory", "memory", taskMemoryLimitMB(cfg) > 0},
+		{"memory_swap_mb", "memory", driverConfig.MemorySwapMB != 0},
+		{"memory_swappiness", "memory", driverConfig.MemorySwappiness != nil},
+		{"cpuset_cpus", "cpuset", driverConfig.CpusetCpus != ""},
+		{"cpuset_mems", "cpuset", driverConfig.CpusetMems != ""},
+		{"pids_limit", "pids", pidsLimit > 0},
+	}
+
+	var limits []string
+	for _, r := range requested {
+		if r.set && !available[r.controller] {
+			limits = append(limits, r.limit)
+		}
+	}
+	return limits
+}
+
+// availableCgroupControllers returns the cgroup controllers enabled on this
+// node.
+var availableCgroupControllers = cgutil.AvailableControllers
+
+// selinuxEnabled returns whether SELinux is enabled on this node.
+var selinuxEnabled = selinux.GetEnabled
+
+// allowedCapabilities returns the sorted list of capabilities tasks may be
+// granted, with "all" in allow_caps expanded to every capability supported by
+// the operating system.
+func (d *Driver) allowedCapabilities() []string {
+	return capabilities.New(d.config.AllowCaps).Slice(false)
+}
+
+// namespaceSupported returns whether tasks can be given a private namespace
+// of the given type on this node.
+var namespaceSupported = func(ns string) bool {
+	_, err := os.Stat(filepath.Join("/proc/self/ns", ns))
+	return err == nil
+}
+
+var (
+	// validHostname matches hostnames made of dot separated labels
+	validHostname = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]*[A-Za-z0-9])?(\.[A-Za-z0-9]([A-Za-z0-9-]*[A-Za-z0-9])?)*$`)
+
+	// invalidHostnameChars matches the characters of an allocation name,
+	// such as "example.cache[0]", which hostnames can't contain
+	invalidHostnameChars = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+)
+
+// maxHostnameLen is the longest hostname the kernel accepts
+const maxHostnameLen = 64
+
+// utsMode returns the UTS isolation mode the task is run with and, when it
+// is private, the task's hostname.
+func utsMode(cfg *drivers.TaskConfig, driverConfig *TaskConfig) (string, string) {
+	mode := driverConfig.ModeUTS
+	if mode == "" {
+		mode = executor.IsolationModeHost
+		if driverConfig.Hostname != "" {
+			mode = executor.IsolationModePrivate
+		}
+	}
+	if mode != executor.IsolationModePrivate {
+		return mode, ""
+	}
+	if driverConfig.Hostname != "" {
+		return mode, driverConfig.Hostname
+	}
+
+	name := cfg.Env[taskenv.AllocName]
+	if name == "" {
+		name = cfg.Name
+	}
+	hostname := invalidHostnameChars.ReplaceAllString(name, "-")
+	if len(hostname) > maxHostnameLen {
+		hostname = hostname[:maxHostnameLen]
+	}
+	return mode, strings.Trim(hostname, ".-")
+}
+
+// ipcMode returns the IPC isolation mode the task is run with, falling back
+// to host isolation when private isolation isn't available and the driver
+// is configured to allow it.
+func (d *Driver) ipcMode(cfg *drivers.TaskConfig, driverConfig *TaskConfig) string {
+	mode := executor.IsolationMode(d.config.DefaultModeIPC, driverConfig.ModeIPC)
+	if mode != executor.IsolationModePrivate || !d.config.FallbackToHostIsolation || namespaceSupported("ipc") {
+		return mode
+	}
+
+	d.logger.Warn("private IPC isolation is not available, falling back to host isolation",
+		"task_id", cfg.ID, "task_name", cfg.Name)
+	d.eventer.EmitEvent(&drivers.TaskEvent{
+		TaskID:    cfg.ID,
+		AllocID:   cfg.AllocID,
+		TaskName:  cfg.Name,
+		Timestamp: time.Now(),
+		Message:   "Private IPC isolation is not available, running task with host isolation",
+		Annotations: map[string]string{
+			"ipc_mode": executor.IsolationModeHost,
+		},
+	})
+	return executor.IsolationModeHost
+}
+
 func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
 	if handle == nil {
 		return fmt.Errorf("handle cannot be nil")
@@ -381,6 +1504,13 @@ func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
 		return d.recoverPre09Task(handle)
 	}
 
+	// Refuse state written by a driver version we don't know how to decode,
+	// such as after a downgrade, rather than reattaching with bad state.
+	if handle.Version != taskHandleVersion {
+		return fmt.Errorf("task handle version %d is not supported by this exec driver, which supports version %d",
+			handle.Version, taskHandleVersion)
+	}
+
 	// If already attached to handle there's nothing to recover.
 	if _, ok := d.tasks.Get(handle.Config.ID); ok {
 		d.logger.Trace("nothing to recover; task already exists",
@@ -396,6 +1526,9 @@ func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
 		d.logger.Error("failed to decode task state from handle", "error", err, "task_id", handle.Config.ID)
 		return fmt.Errorf("failed to decode task state from handle: %v", err)
 	}
+	if taskState.ReattachConfig == nil || taskState.TaskConfig == nil {
+		return fmt.Errorf("task state from handle is incomplete: missing reattach config or task config")
+	}
 
 	// Create client for reattached executor
 	plugRC, err := pstructs.ReattachConfigToGoPlugin(taskState.ReattachConfig)
@@ -418,8 +1551,10 @@ func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
 		taskConfig:   taskState.TaskConfig,
 		procState:    drivers.TaskStateRunning,
 		startedAt:    taskState.StartedAt,
+		maxRuntime:   taskState.MaxRuntime,
 		exitResult:   &drivers.ExitResult{},
 		logger:       d.logger,
+		emitEvent:    d.eventer.EmitEvent,
 	}
 
 	d.tasks.Set(taskState.TaskConfig.ID, h)
@@ -428,7 +1563,298 @@ func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
 	return nil
 }
 
+// checkMemoryReservation ensures that starting the task does not cut into the
+// memory reserved for the system by the driver configuration.
+func (d *Driver) checkMemoryReservation(cfg *drivers.TaskConfig) error {
+	reserved := int64(d.config.SystemReservedMemoryMB)
+	if reserved == 0 {
+		return nil
+	}
+
+	available, err := d.availableMemory()
+	if err != nil {
+		return fmt.Errorf("failed to determine available memory: %v", err)
+	}
+	availableMB := int64(available / 1024 / 1024)
+
+	var taskMB int64
+	if cfg.Resources != nil && cfg.Resources.NomadResources != nil {
+		taskMB = cfg.Resources.NomadResources.Memory.MemoryMB
+	}
+
+	if availableMB-taskMB < reserved {
+		return fmt.Errorf("insufficient memory to start task: requires %d MB but %d MB is available and %d MB is reserved for the system",
+			taskMB, availableMB, reserved)
+	}
+	return nil
+}
+
+// validateTaskPaths ensures no two mounts or devices are placed at the same
+// path in the task, where one would silently shadow the other.
+func validateTaskPaths(mounts []*drivers.MountConfig, devices []*drivers.DeviceConfig, tmpfs []TmpfsMount) error {
+	kinds := make(map[string]string, len(mounts)+len(devices)+len(tmpfs))
+	add := func(kind, path string) error {
+		path = filepath.Clean(path)
+		switch prev, ok := kinds[path]; {
+		case !ok:
+			kinds[path] = kind
+			return nil
+		case prev == kind:
+			return fmt.Errorf("task path %q is used by more than one %s", path, kind)
+		default:
+			return fmt.Errorf("task path %q is used by both a %s and a %s", path, prev, kind)
+		}
+	}
+
+	for _, m := range mounts {
+		if err := add("mount", m.TaskPath); err != nil {
+			return err
+		}
+	}
+	for _, dev := range devices {
+		if err := add("device", dev.TaskPath); err != nil {
+			return err
+		}
+	}
+	for _, m := range tmpfs {
+		if err := add("tmpfs mount", m.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// taskDevices returns the devices of a task along with the host devices
+// matching the allowed_device_globs. Devices requested by the task keep their
+// own permissions; matched devices are given read, write and mknod access.
+func taskDevices(devices []*drivers.DeviceConfig, globs []string) ([]*drivers.DeviceConfig, error) {
+	requested := make(map[string]struct{}, len(devices))
+	for _, dev := range devices {
+		if strings.Trim(dev.Permissions, "rwm") != "" {
+			return nil, fmt.Errorf("device %q has invalid permissions %q: must only contain r, w and m", dev.HostPath, dev.Permissions)
+		}
+		requested[filepath.Clean(dev.TaskPath)] = struct{}{}
+	}
+
+	// Copy the devices so that the task's own config isn't modified
+	result := append([]*drivers.DeviceConfig(nil), devices...)
+	for _, glob := range globs {
+		matches, err := filepath.Glob(glob)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed_device_globs pattern %q: %v", glob, err)
+		}
+		for _, path := range matches {
+			if _, ok := requested[path]; ok {
+				continue
+			}
+			info, err := os.Stat(path)
+			if err != nil || info.Mode()&os.ModeDevice == 0 {
+				continue
+			}
+			requested[path] = struct{}{}
+			result = append(result, &drivers.DeviceConfig{
+				TaskPath:    path,
+				HostPath:    path,
+				Permissions: "rwm",
+			})
+		}
+	}
+	return result, nil
+}
+
+// validateMountSources ensures the host path of every mount exists.
+func validateMountSources(mounts []*drivers.MountConfig) error {
+	for _, m := range mounts {
+		_, err := os.Stat(m.HostPath)
+		if os.IsNotExist(err) {
+			return fmt.Errorf("mount source %q for task path %q does not exist", m.HostPath, m.TaskPath)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to check mount source %q for task path %q: %v", m.HostPath, m.TaskPath, err)
+		}
+	}
+	return nil
+}
+
+// taskWorkDir returns the directory the task starts in, ensuring that one given
+// in work_dir exists for the task. Paths under a mount are checked in the
+// mount's source, as the mount is only made when the task is launched.
+func taskWorkDir(cfg *drivers.TaskConfig, driverConfig *TaskConfig) (string, error) {
+	taskDir := cfg.TaskDir()
+	if driverConfig.WorkDir == "" {
+		if driverConfig.MountNamespaceOnly {
+			return taskDir.LocalDir, nil
+		}
+		return "/" + allocdir.TaskLocal, nil
+	}
+
+	dir := filepath.Clean(driverConfig.WorkDir)
+	hostPath := dir
+	if !driverConfig.MountNamespaceOnly {
+		hostPath = filepath.Join(taskDir.Dir, dir)
+	}
+	for _, m := range cfg.Mounts {
+		if rel, err := filepath.Rel(m.TaskPath, dir); err == nil && rel != ".." && !strings.HasPrefix(rel, "../") {
+			hostPath = filepath.Join(m.HostPath, rel)
+		}
+	}
+
+	fi, err := os.Stat(hostPath)
+	if os.IsNotExist(err) {
+		return "", fmt.Errorf("work_dir %q does not exist in the task", driverConfig.WorkDir)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to check work_dir %q: %v", driverConfig.WorkDir, err)
+	}
+	if !fi.IsDir() {
+		return "", fmt.Errorf("work_dir %q is not a directory", driverConfig.WorkDir)
+	}
+	return dir, nil
+}
+
+// taskMemoryLimitMB returns the memory limit the task's cgroup is given,
+// which is its max memory if oversubscription is enabled.
+func taskMemoryLimitMB(cfg *drivers.TaskConfig) int64 {
+	if cfg.Resources == nil || cfg.Resources.NomadResources == nil {
+		return 0
+	}
+	mem := cfg.Resources.NomadResources.Memory
+	if mem.MemoryMaxMB > 0 {
+		return mem.MemoryMaxMB
+	}
+	return mem.MemoryMB
+}
+
+// lookupGroups resolves the names of host groups to their GIDs.
+func lookupGroups(names []string) ([]uint32, error) {
+	gids := make([]uint32, 0, len(names))
+	for _, name := range names {
+		g, err := user.LookupGroup(name)
+		if err != nil {
+			return nil, fmt.Errorf("group %q does not exist: %v", name, err)
+		}
+		gid, err := strconv.ParseUint(g.Gid, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gid %q of group %q: %v", g.Gid, name, err)
+		}
+		gids = append(gids, uint32(gid))
+	}
+	return gids, nil
+}
+
+// chownTaskDirs recursively changes the owner of the directories a task
+// writes to, so that tasks running as an unprivileged user can write to
+// them.
+func chownTaskDirs(taskDir *allocdir.TaskDir, username string) error {
+	u, err := user.Lookup(username)
+	if err != nil {
+		return err
+	}
+	uid, err := strconv.Atoi(u.Uid)
+	if err != nil {
+		return fmt.Errorf("invalid uid %q: %v", u.Uid, err)
+	}
+	gid, err := strconv.Atoi(u.Gid)
+	if err != nil {
+		return fmt.Errorf("invalid gid %q: %v", u.Gid, err)
+	}
+
+	dirs := []string{
+		taskDir.LocalDir,
+		taskDir.SecretsDir,
+		filepath.Join(taskDir.Dir, allocdir.TmpDirName),
+	}
+	for _, dir := range dirs {
+		err := filepath.Walk(dir, func(path string, _ os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			return os.Lchown(path, uid, gid)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readEnvFile parses the file of KEY=VALUE lines at path within the task
+// directory.
+func readEnvFile(taskDir, path string) (map[string]string, error) {
+	escapes, err := escapingfs.PathEscapesAllocDir(taskDir, "", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check env_file path: %v", err)
+	}
+	if escapes {
+		return nil, fmt.Errorf("env_file %q escapes the task directory", path)
+	}
+
+	f, err := os.Open(filepath.Join(taskDir, path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open env_file: %v", err)
+	}
+	defer f.Close()
+
+	vars, err := envparse.Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse env_file %q: %v", path, err)
+	}
+	return vars, nil
+}
+
+// seccompProfile returns the seccomp profile the task's syscalls are filtered
+// by: its seccomp_profile within the task directory, or else the driver's
+// default_seccomp_profile. It returns nil if neither is set.
+func (d *Driver) seccompProfile(taskDir string, driverConfig *TaskConfig) ([]byte, error) {
+	field, path := "seccomp_profile", driverConfig.SeccompProfile
+	if path != "" {
+		escapes, err := escapingfs.PathEscapesAllocDir(taskDir, "", path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check seccomp_profile path: %v", err)
+		}
+		if escapes {
+			return nil, fmt.Errorf("seccomp_profile %q escapes the task directory", path)
+		}
+		path = filepath.Join(taskDir, path)
+	} else if d.config.DefaultSeccompProfile != "" {
+		field, path = "default_seccomp_profile", d.config.DefaultSeccompProfile
+	} else {
+		return nil, nil
+	}
+
+	profile, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", field, err)
+	}
+	if err := executor.ValidateSeccompProfile(profile); err != nil {
+		return nil, fmt.Errorf("%s %q: %v", field, path, err)
+	}
+	return profile, nil
+}
+
+// redact replaces the secrets of the task and those matching secret_patterns
+// in s.
+func (d *Driver) redact(cfg *drivers.TaskConfig, s string) string {
+	if token := cfg.Env[vaultTokenEnv]; token != "" {
+		s = strings.ReplaceAll(s, token, redactedValue)
+	}
+	for _, re := range d.secretPatterns {
+		s = re.ReplaceAllString(s, redactedValue)
+	}
+	return s
+}
+
 func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	handle, net, err := d.startTask(cfg)
+	if err != nil {
+		// The error is surfaced in task events, so it must not leak secrets
+		// from the task's command or arguments
+		return nil, nil, errors.New(d.redact(cfg, err.Error()))
+	}
+	return handle, net, nil
+}
+
+func (d *Driver) startTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
 	if _, ok := d.tasks.Get(cfg.ID); ok {
 		return nil, nil, fmt.Errorf("task with ID %q already started", cfg.ID)
 	}
@@ -441,8 +1867,162 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 	if err := driverConfig.validate(); err != nil {
 		return nil, nil, fmt.Errorf("failed driver config validation: %v", err)
 	}
+	noNewPrivileges, err := driverConfig.noNewPrivileges(d.config.DefaultNoNewPrivileges)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed driver config validation: %v", err)
+	}
+
+	if err := validateCpusetAvailable("cpuset_cpus", driverConfig.CpusetCpus, onlineCPUsPath); err != nil {
+		return nil, nil, fmt.Errorf("failed driver config validation: %v", err)
+	}
+	if err := validateCpusetAvailable("cpuset_mems", driverConfig.CpusetMems, onlineMemsPath); err != nil {
+		return nil, nil, fmt.Errorf("failed driver config validation: %v", err)
+	}
+	if err := validateCpusetReserved(driverConfig.CpusetCpus, cfg); err != nil {
+		return nil, nil, fmt.Errorf("failed driver config validation: %v", err)
+	}
+	if err := validateApparmorProfile(driverConfig.ApparmorProfile, apparmorProfilesPath); err != nil {
+		return nil, nil, fmt.Errorf("failed driver config validation: %v", err)
+	}
+	if driverConfig.SELinuxRelabel && !selinuxEnabled() {
+		return nil, nil, fmt.Errorf("selinux_relabel requires SELinux, which is not enabled on this node")
+	}
+	groups, err := lookupGroups(driverConfig.Groups)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed driver config validation: %v", err)
+	}
+	workDir, err := taskWorkDir(cfg, &driverConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed driver config validation: %v", err)
+	}
+	if d.config.DisableCgroups && (driverConfig.CpusetCpus != "" || driverConfig.CpusetMems != "") {
+		return nil, nil, fmt.Errorf("cpuset_cpus and cpuset_mems require cgroups, which are disabled in the exec driver")
+	}
+	if d.config.DisableCgroups && driverConfig.MemorySwappiness != nil {
+		return nil, nil, fmt.Errorf("memory_swappiness requires cgroups, which are disabled in the exec driver")
+	}
+	if d.config.DisableCgroups && driverConfig.MemorySwapMB != 0 {
+		return nil, nil, fmt.Errorf("memory_swap_mb requires cgroups, which are disabled in the exec driver")
+	}
+	if d.config.DisableCgroups && driverConfig.PidsLimit != 0 {
+		return nil, nil, fmt.Errorf("pids_limit requires cgroups, which are disabled in the exec driver")
+	}
+	if driverConfig.ModeUserns == executor.IsolationModePrivate {
+		if executor.IsolationMode(d.config.DefaultModePID, driverConfig.ModePID) == executor.IsolationModeHost {
+			return nil, nil, fmt.Errorf("userns_mode %q cannot be used with the exec driver's default_pid_mode %q", executor.IsolationModePrivate, executor.IsolationModeHost)
+		}
+		if d.config.UsernsIDOffset == 0 {
+			return nil, nil, fmt.Errorf("userns_mode %q requires userns_id_offset to be set in the exec driver", executor.IsolationModePrivate)
+		}
+	}
+	if d.config.DisableCgroups && driverConfig.HealthyCpuThreshold > 0 {
+		return nil, nil, fmt.Errorf("healthy_cpu_threshold requires cgroups, which are disabled in the exec driver")
+	}
+	if limit := taskMemoryLimitMB(cfg); driverConfig.MemorySwapMB != 0 && driverConfig.MemorySwapMB < limit {
+		return nil, nil, fmt.Errorf("memory_swap_mb must be at least the task's memory limit of %d MB, got %d", limit, driverConfig.MemorySwapMB)
+	}
+
+	caps, err := capabilities.Calculate(
+		capabilities.NomadDefaults(), d.config.AllowCaps, driverConfig.CapAdd, driverConfig.CapDrop,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	d.logger.Debug("task capabilities", "capabilities", caps)
+	if err := validateNofileRlimit(driverConfig.Rlimits, caps, nrOpenPath); err != nil {
+		return nil, nil, fmt.Errorf("failed driver config validation: %v", err)
+	}
+
+	if err := d.checkMemoryReservation(cfg); err != nil {
+		return nil, nil, err
+	}
+
+	if limit := taskMemoryLimitMB(cfg); limit > 0 {
+		for _, m := range driverConfig.Tmpfs {
+			if m.SizeMB > limit {
+				return nil, nil, fmt.Errorf("tmpfs at %q of %d MB exceeds the task's memory limit of %d MB", m.Path, m.SizeMB, limit)
+			}
+		}
+	}
 
-	d.logger.Info("starting task", "driver_cfg", hclog.Fmt("%+v", driverConfig))
+	devices, err := taskDevices(cfg.Devices, d.config.AllowedDeviceGlobs)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := validateTaskPaths(cfg.Mounts, devices, driverConfig.Tmpfs); err != nil {
+		return nil, nil, err
+	}
+	if d.config.ValidateMountSources {
+		if err := validateMountSources(cfg.Mounts); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	user := cfg.User
+	if user == "" {
+		user = d.config.DefaultUser
+	}
+	if user == "" {
+		user = "nobody"
+	}
+	if len(d.config.AllowedUsers) > 0 && !helper.SliceStringContains(d.config.AllowedUsers, user) {
+		return nil, nil, fmt.Errorf("user %s is not in the allowed_users list of the exec driver", user)
+	}
+
+	if driverConfig.ChownTaskDir {
+		if err := chownTaskDirs(cfg.TaskDir(), user); err != nil {
+			return nil, nil, fmt.Errorf("failed to chown task directories to user %s: %v", user, err)
+		}
+	}
+
+	seccompProfile, err := d.seccompProfile(cfg.TaskDir().Dir, &driverConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if driverConfig.EnvFile != "" {
+		vars, err := readEnvFile(cfg.TaskDir().Dir, driverConfig.EnvFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		env := helper.CopyMapStringString(cfg.Env)
+		if env == nil {
+			env = make(map[string]string, len(vars))
+		}
+		for k, v := range vars {
+			env[k] = v
+		}
+		cfg.Env = env
+	}
+
+	// Without the chroot the task directory paths in the environment do not
+	// exist for the task, so point them at the host paths instead.
+	if driverConfig.MountNamespaceOnly {
+		env := helper.CopyMapStringString(cfg.Env)
+		if env == nil {
+			env = make(map[string]string, 3)
+		}
+		env[taskenv.AllocDir] = cfg.TaskDir().SharedAllocDir
+		env[taskenv.TaskLocalDir] = cfg.TaskDir().LocalDir
+		env[taskenv.SecretsDir] = cfg.TaskDir().SecretsDir
+		cfg.Env = env
+	}
+
+	// Wait for a start slot as setting up the executor and the task's
+	// isolation is expensive when many tasks start at once.
+	releaseStartSlot := func() {}
+	if sem := d.startSem; sem != nil {
+		select {
+		case sem <- struct{}{}:
+			var once sync.Once
+			releaseStartSlot = func() { once.Do(func() { <-sem }) }
+		case <-d.ctx.Done():
+			return nil, nil, fmt.Errorf("driver shutting down while waiting to start task: %v", d.ctx.Err())
+		}
+	}
+	defer releaseStartSlot()
+
+	d.logger.Info("starting task", "driver_cfg", d.redact(cfg, fmt.Sprintf("%+v", driverConfig)))
 	handle := drivers.NewTaskHandle(taskHandleVersion)
 	handle.Config = cfg
 
@@ -453,18 +2033,13 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		FSIsolation: true,
 	}
 
-	exec, pluginClient, err := executor.CreateExecutor(
+	exec, pluginClient, err := d.createExecutor(
 		d.logger.With("task_name", handle.Config.Name, "alloc_id", handle.Config.AllocID),
 		d.nomadConfig, executorConfig)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create executor: %v", err)
 	}
 
-	user := cfg.User
-	if user == "" {
-		user = "nobody"
-	}
-
 	if cfg.DNS != nil {
 		dnsMount, err := resolvconf.GenerateDNSMount(cfg.TaskDir().Dir, cfg.DNS)
 		if err != nil {
@@ -473,39 +2048,93 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		cfg.Mounts = append(cfg.Mounts, dnsMount)
 	}
 
-	caps, err := capabilities.Calculate(
-		capabilities.NomadDefaults(), d.config.AllowCaps, driverConfig.CapAdd, driverConfig.CapDrop,
-	)
-	if err != nil {
-		return nil, nil, err
+	if driverConfig.PropagateTimezone {
+		cfg.Mounts = append(cfg.Mounts, &drivers.MountConfig{
+			TaskPath: "/etc/localtime",
+			HostPath: hostLocaltimePath,
+			Readonly: true,
+		})
 	}
-	d.logger.Debug("task capabilities", "capabilities", caps)
 
 	execCmd := &executor.ExecCommand{
-		Cmd:              driverConfig.Command,
-		Args:             driverConfig.Args,
-		Env:              cfg.EnvList(),
-		User:             user,
-		ResourceLimits:   true,
-		NoPivotRoot:      d.config.NoPivotRoot,
-		Resources:        cfg.Resources,
-		TaskDir:          cfg.TaskDir().Dir,
-		StdoutPath:       cfg.StdoutPath,
-		StderrPath:       cfg.StderrPath,
-		Mounts:           cfg.Mounts,
-		Devices:          cfg.Devices,
-		NetworkIsolation: cfg.NetworkIsolation,
-		ModePID:          executor.IsolationMode(d.config.DefaultModePID, driverConfig.ModePID),
-		ModeIPC:          executor.IsolationMode(d.config.DefaultModeIPC, driverConfig.ModeIPC),
-		Capabilities:     caps,
-	}
-
-	ps, err := exec.Launch(execCmd)
+		Cmd:                driverConfig.Command,
+		Args:               driverConfig.Args,
+		Env:                cfg.EnvList(),
+		User:               user,
+		ResourceLimits:     true,
+		NoPivotRoot:        d.config.NoPivotRoot,
+		Resources:          cfg.Resources,
+		TaskDir:            cfg.TaskDir().Dir,
+		StdoutPath:         cfg.StdoutPath,
+		StderrPath:         cfg.StderrPath,
+		Mounts:             cfg.Mounts,
+		Devices:            devices,
+		NetworkIsolation:   cfg.NetworkIsolation,
+		ModePID:            executor.IsolationMode(d.config.DefaultModePID, driverConfig.ModePID),
+		ModeIPC:            d.ipcMode(cfg, &driverConfig),
+		Capabilities:       caps,
+		Nice:               driverConfig.Nice,
+		IOClass:            driverConfig.IOClass,
+		IOPriority:         driverConfig.IOPriority,
+		CpusetCpus:         driverConfig.CpusetCpus,
+		CpusetMems:         driverConfig.CpusetMems,
+		MountNamespaceOnly: driverConfig.MountNamespaceOnly,
+		DisableCgroups:     d.config.DisableCgroups,
+		MemorySwapMB:       driverConfig.MemorySwapMB,
+		PidsLimit:          driverConfig.pidsLimit(d.config.DefaultPidsLimit),
+		ApparmorProfile:    driverConfig.ApparmorProfile,
+		OOMScoreAdj:        d.config.DefaultOOMScoreAdj,
+		NoNewPrivileges:    noNewPrivileges,
+		ModeUserns:         executor.IsolationMode(executor.IsolationModeHost, driverConfig.ModeUserns),
+		UsernsIDOffset:     uint32(d.config.UsernsIDOffset),
+		AdditionalGroups:   groups,
+		WorkDir:            workDir,
+		SELinuxRelabel:     driverConfig.SELinuxRelabel,
+	}
+	execCmd.ModeUTS, execCmd.Hostname = utsMode(cfg, &driverConfig)
+	execCmd.SeccompProfile = seccompProfile
+	for name, rlimit := range driverConfig.Rlimits {
+		execCmd.Rlimits = append(execCmd.Rlimits, &executor.Rlimit{
+			Name: name,
+			Soft: rlimit.Soft,
+			Hard: rlimit.Hard,
+		})
+	}
+	for _, m := range driverConfig.Tmpfs {
+		mode, _ := m.mode()
+		execCmd.TmpfsMounts = append(execCmd.TmpfsMounts, &executor.TmpfsMount{
+			TaskPath:  m.Path,
+			SizeBytes: m.SizeMB * 1024 * 1024,
+			Mode:      mode,
+		})
+	}
+	if driverConfig.OOMScoreAdj != nil {
+		execCmd.OOMScoreAdj = *driverConfig.OOMScoreAdj
+	}
+	if driverConfig.MemorySwappiness != nil {
+		swappiness := int64(*driverConfig.MemorySwappiness)
+		execCmd.MemorySwappiness = &swappiness
+	}
+	if !d.config.DisableCgroups {
+		execCmd.CgroupControllers = d.cgroupControllers(cfg, &driverConfig, execCmd.PidsLimit)
+	}
+
+	ps, err := d.launch(exec, pluginClient, execCmd)
+	if err == errStartTimeout {
+		d.logger.Warn("task did not start within the start timeout, cleaning up",
+			"task_id", cfg.ID, "task_name", cfg.Name, "start_timeout", d.startTimeout)
+		return nil, nil, fmt.Errorf("task did not start within the start_timeout of %s", d.startTimeout)
+	}
 	if err != nil {
 		pluginClient.Kill()
 		return nil, nil, fmt.Errorf("failed to launch command with executor: %v", err)
 	}
 
+	var maxRuntime time.Duration
+	if driverConfig.MaxRuntime != "" {
+		maxRuntime, _ = time.ParseDuration(driverConfig.MaxRuntime)
+	}
+
 	h := &taskHandle{
 		exec:         exec,
 		pid:          ps.Pid,
@@ -513,7 +2142,9 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		taskConfig:   cfg,
 		procState:    drivers.TaskStateRunning,
 		startedAt:    time.Now().Round(time.Millisecond),
+		maxRuntime:   maxRuntime,
 		logger:       d.logger,
+		emitEvent:    d.eventer.EmitEvent,
 	}
 
 	driverState := TaskState{
@@ -521,6 +2152,7 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		Pid:            ps.Pid,
 		TaskConfig:     cfg,
 		StartedAt:      h.startedAt,
+		MaxRuntime:     maxRuntime,
 	}
 
 	if err := handle.SetDriverState(&driverState); err != nil {
@@ -532,9 +2164,74 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 
 	d.tasks.Set(cfg.ID, h)
 	go h.run()
+
+	if driverConfig.HealthyCpuThreshold > 0 {
+		// The task is running, so don't hold up other tasks' starts while
+		// waiting for it to settle.
+		releaseStartSlot()
+
+		quietPeriod, _ := time.ParseDuration(driverConfig.HealthyQuietPeriod)
+		ctx, cancel := context.WithTimeout(d.ctx, maxQuietPeriods*quietPeriod)
+		err := h.waitForQuiet(ctx, driverConfig.HealthyCpuThreshold, quietPeriod)
+		cancel()
+		if err != nil {
+			d.logger.Warn("task CPU usage did not settle, reporting task as started",
+				"task_id", cfg.ID, "task_name", cfg.Name, "error", err)
+		}
+	}
 	return handle, nil, nil
 }
 
+// maxQuietPeriods bounds how long starting a task waits for its CPU usage to
+// settle, in multiples of its healthy_quiet_period.
+const maxQuietPeriods = 5
+
+// errStartTimeout is returned by launch when the task didn't start within the
+// start timeout.
+var errStartTimeout = errors.New("start timeout exceeded")
+
+// launch starts the command with the executor, giving up once the start
+// timeout is exceeded. The executor is then cleaned up in the background,
+// stopping the task too if it does start.
+func (d *Driver) launch(exec executor.Executor, pluginClient *plugin.Client, cmd *executor.ExecCommand) (*executor.ProcessState, error) {
+	if d.startTimeout == 0 {
+		return exec.Launch(cmd)
+	}
+
+	type launchResult struct {
+		ps  *executor.ProcessState
+		err error
+	}
+	resultCh := make(chan launchResult, 1)
+	go func() {
+		ps, err := exec.Launch(cmd)
+		resultCh <- launchResult{ps, err}
+	}()
+
+	timer := time.NewTimer(d.startTimeout)
+	defer timer.Stop()
+
+	select {
+	case res := <-resultCh:
+		return res.ps, res.err
+	case <-timer.C:
+	}
+
+	go func() {
+		// Give a launch that is still in progress as long again to finish
+		// before killing the executor out from under it.
+		select {
+		case res := <-resultCh:
+			if res.err == nil {
+				_ = exec.Shutdown("", 0)
+			}
+		case <-time.After(d.startTimeout):
+		}
+		pluginClient.Kill()
+	}()
+	return nil, errStartTimeout
+}
+
 func (d *Driver) WaitTask(ctx context.Context, taskID string) (<-chan *drivers.ExitResult, error) {
 	handle, ok := d.tasks.Get(taskID)
 	if !ok {
@@ -557,8 +2254,10 @@ func (d *Driver) handleWait(ctx context.Context, handle *taskHandle, ch chan *dr
 		}
 	} else {
 		result = &drivers.ExitResult{
-			ExitCode: ps.ExitCode,
-			Signal:   ps.Signal,
+			ExitCode:  ps.ExitCode,
+			Signal:    ps.Signal,
+			OOMKilled: ps.OOMKilled,
+			Err:       handle.maxRuntimeErr(),
 		}
 	}
 
@@ -577,7 +2276,14 @@ func (d *Driver) StopTask(taskID string, timeout time.Duration, signal string) e
 		return drivers.ErrTaskNotFound
 	}
 
-	if err := handle.exec.Shutdown(signal, timeout); err != nil {
+	if signal == "" {
+		signal = stopSignal(handle.taskConfig)
+	}
+	if d.killSignalGrace > 0 {
+		timeout = d.killSignalGrace
+	}
+
+	if err := <-handle.shutdown(d.ctx, signal, timeout, d.eventer.EmitEvent); err != nil {
 		if handle.pluginClient.Exited() {
 			return nil
 		}
@@ -587,6 +2293,20 @@ func (d *Driver) StopTask(taskID string, timeout time.Duration, signal string) e
 	return nil
 }
 
+// stopSignal returns the signal used to stop a task when none is given: the
+// task's stop_signal, then the kill signal configured on the task, and
+// otherwise SIGINT as the executor defaults to.
+func stopSignal(cfg *drivers.TaskConfig) string {
+	var driverConfig TaskConfig
+	if err := cfg.DecodeDriverConfig(&driverConfig); err == nil && driverConfig.StopSignal != "" {
+		return driverConfig.StopSignal
+	}
+	if cfg.KillSignal != "" {
+		return cfg.KillSignal
+	}
+	return "SIGINT"
+}
+
 func (d *Driver) DestroyTask(taskID string, force bool) error {
 	handle, ok := d.tasks.Get(taskID)
 	if !ok {
@@ -618,13 +2338,93 @@ func (d *Driver) InspectTask(taskID string) (*drivers.TaskStatus, error) {
 	return handle.TaskStatus(), nil
 }
 
+// InspectTaskContext is like InspectTask, but gives up once ctx is done if
+// collecting the status of the task blocks.
+func (d *Driver) InspectTaskContext(ctx context.Context, taskID string) (*drivers.TaskStatus, error) {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	statusCh := make(chan *drivers.TaskStatus, 1)
+	go func() {
+		statusCh <- handle.TaskStatus()
+	}()
+
+	select {
+	case status := <-statusCh:
+		return status, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// ManagedTaskInfo describes a task managed by the driver.
+type ManagedTaskInfo struct {
+	TaskID    string
+	PID       int
+	State     drivers.TaskState
+	StartedAt time.Time
+}
+
+// ListManagedTasks returns every task the driver manages, including tasks
+// that have exited but not been destroyed, ordered by when they started.
+func (d *Driver) ListManagedTasks() []ManagedTaskInfo {
+	handles := d.tasks.List()
+	infos := make([]ManagedTaskInfo, 0, len(handles))
+	for _, h := range handles {
+		infos = append(infos, h.ManagedTaskInfo())
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		if !infos[i].StartedAt.Equal(infos[j].StartedAt) {
+			return infos[i].StartedAt.Before(infos[j].StartedAt)
+		}
+		return infos[i].TaskID < infos[j].TaskID
+	})
+	return infos
+}
+
+var _ drivers.TaskLogTailer = (*Driver)(nil)
+
+// TailTaskLogs returns up to the last lines lines of the task's stdout or
+// stderr.
+func (d *Driver) TailTaskLogs(taskID, stream string, lines int) ([]string, error) {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	return handle.TailLogs(stream, lines)
+}
+
 func (d *Driver) TaskStats(ctx context.Context, taskID string, interval time.Duration) (<-chan *drivers.TaskResourceUsage, error) {
 	handle, ok := d.tasks.Get(taskID)
 	if !ok {
 		return nil, drivers.ErrTaskNotFound
 	}
 
-	return handle.exec.Stats(ctx, interval)
+	// Streams are cancelled when the driver shuts down, as the caller may
+	// never cancel its context.
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		defer cancel()
+		select {
+		case <-ctx.Done():
+		case <-d.ctx.Done():
+		}
+	}()
+
+	if interval < minStatsInterval {
+		interval = minStatsInterval
+	}
+
+	ch, err := handle.exec.Stats(ctx, interval)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return ch, nil
 }
 
 func (d *Driver) TaskEvents(ctx context.Context) (<-chan *drivers.TaskEvent, error) {
@@ -661,7 +2461,7 @@ func (d *Driver) ExecTask(taskID string, cmd []string, timeout time.Duration) (*
 		args = cmd[1:]
 	}
 
-	out, exitCode, err := handle.exec.Exec(time.Now().Add(timeout), cmd[0], args)
+	out, exitCode, err := handle.exec.Exec(time.Now().Add(d.execTimeout(timeout)), cmd[0], args)
 	if err != nil {
 		return nil, err
 	}
@@ -674,6 +2474,95 @@ func (d *Driver) ExecTask(taskID string, cmd []string, timeout time.Duration) (*
 	}, nil
 }
 
+// execTimeout returns how long an exec'd command may run, using the
+// default_exec_timeout when no timeout is given.
+func (d *Driver) execTimeout(timeout time.Duration) time.Duration {
+	switch {
+	case timeout > 0:
+		return timeout
+	case d.defaultExecTimeout > 0:
+		return d.defaultExecTimeout
+	default:
+		return defaultExecTimeout
+	}
+}
+
+// ExecTaskWithInput runs cmd inside the task like ExecTask, feeding stdin
+// to the command until it is exhausted. The command's stdout and stderr are
+// returned together as the result's Stdout.
+func (d *Driver) ExecTaskWithInput(taskID string, cmd []string, stdin io.Reader, timeout time.Duration) (*drivers.ExecTaskResult, error) {
+	if len(cmd) == 0 {
+		return nil, fmt.Errorf("error cmd must have at least one value")
+	}
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(d.ctx, d.execTimeout(timeout))
+	defer cancel()
+
+	output, _ := circbuf.NewBuffer(int64(drivers.CheckBufSize))
+	stream := &inputExecStream{stdin: stdin, output: output}
+	if err := handle.exec.ExecStreaming(ctx, cmd, false, stream); err != nil {
+		return nil, err
+	}
+
+	return &drivers.ExecTaskResult{
+		Stdout: output.Bytes(),
+		ExitResult: &drivers.ExitResult{
+			ExitCode: stream.exitCode,
+		},
+	}, nil
+}
+
+// inputExecStream is an ExecTaskStream which sends a reader to an exec'd
+// command's stdin and collects its output.
+type inputExecStream struct {
+	stdin       io.Reader
+	stdinClosed bool
+
+	output   *circbuf.Buffer
+	exitCode int
+}
+
+func (s *inputExecStream) Recv() (*drivers.ExecTaskStreamingRequestMsg, error) {
+	if s.stdinClosed {
+		return nil, io.EOF
+	}
+
+	buf := make([]byte, 4096)
+	for {
+		n, err := s.stdin.Read(buf)
+		if n > 0 {
+			return &drivers.ExecTaskStreamingRequestMsg{
+				Stdin: &dproto.ExecTaskStreamingIOOperation{Data: buf[:n]},
+			}, nil
+		}
+		if err == io.EOF {
+			s.stdinClosed = true
+			return &drivers.ExecTaskStreamingRequestMsg{
+				Stdin: &dproto.ExecTaskStreamingIOOperation{Close: true},
+			}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (s *inputExecStream) Send(m *drivers.ExecTaskStreamingResponseMsg) error {
+	switch {
+	case m.Stdout != nil:
+		s.output.Write(m.Stdout.Data)
+	case m.Stderr != nil:
+		s.output.Write(m.Stderr.Data)
+	case m.Exited && m.Result != nil:
+		s.exitCode = int(m.Result.ExitCode)
+	}
+	return nil
+}
+
 var _ drivers.ExecTaskStreamingRawDriver = (*Driver)(nil)
 
 func (d *Driver) ExecTaskStreamingRaw(ctx context.Context,
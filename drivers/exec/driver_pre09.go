@@ -37,6 +37,7 @@ func (d *Driver) recoverPre09Task(h *drivers.TaskHandle) error {
 		startedAt:    time.Now(),
 		exitResult:   &drivers.ExitResult{},
 		logger:       d.logger,
+		emitEvent:    d.eventer.EmitEvent,
 	}
 
 	d.tasks.Set(h.Config.ID, th)
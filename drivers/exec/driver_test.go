@@ -7,18 +7,28 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	osexec "os/exec"
+	"os/user"
 	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"testing"
 	"time"
 
+	metrics "github.com/armon/go-metrics"
+	hclog "github.com/hashicorp/go-hclog"
+	multierror "github.com/hashicorp/go-multierror"
+	plugin "github.com/hashicorp/go-plugin"
 	"github.com/hashicorp/nomad/ci"
+	cstructs "github.com/hashicorp/nomad/client/structs"
 	ctestutils "github.com/hashicorp/nomad/client/testutil"
+	"github.com/hashicorp/nomad/drivers/shared/capabilities"
 	"github.com/hashicorp/nomad/drivers/shared/executor"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/pluginutils/hclutils"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/helper/testtask"
@@ -28,6 +38,7 @@ import (
 	"github.com/hashicorp/nomad/plugins/drivers"
 	dtestutil "github.com/hashicorp/nomad/plugins/drivers/testutils"
 	"github.com/hashicorp/nomad/testutil"
+	"github.com/opencontainers/selinux/go-selinux"
 	"github.com/stretchr/testify/require"
 )
 
@@ -93,6 +104,90 @@ func TestExecDriver_Fingerprint(t *testing.T) {
 	case finger := <-fingerCh:
 		require.Equal(drivers.HealthStateHealthy, finger.Health)
 		require.True(finger.Attributes["driver.exec"].GetBool())
+		for _, attr := range []string{"driver.exec.isolation.pid", "driver.exec.isolation.ipc"} {
+			modes, ok := finger.Attributes[attr].GetString()
+			require.True(ok, "missing attribute %s", attr)
+			require.ElementsMatch([]string{"host", "private"}, strings.Split(modes, ","))
+		}
+	case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
+		require.Fail("timeout receiving fingerprint")
+	}
+}
+
+func TestExecDriver_DriverCapabilities(t *testing.T) {
+	ci.Parallel(t)
+
+	d := NewExecDriver(context.Background(), testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	caps, err := harness.Capabilities()
+	require.NoError(t, err)
+	require.True(t, caps.SendSignals)
+	require.True(t, caps.Exec)
+	require.Equal(t, drivers.FSIsolationChroot, caps.FSIsolation)
+	require.ElementsMatch(t, []drivers.NetIsolationMode{
+		drivers.NetIsolationModeHost,
+		drivers.NetIsolationModeGroup,
+	}, caps.NetIsolationModes)
+	require.False(t, caps.MustInitiateNetwork)
+	require.Equal(t, drivers.MountConfigSupportAll, caps.MountConfigs)
+	require.False(t, caps.RemoteTasks)
+}
+
+func TestExecDriver_allowedCapabilities(t *testing.T) {
+	ci.Parallel(t)
+
+	t.Run("all", func(t *testing.T) {
+		d := &Driver{config: Config{AllowCaps: []string{"all"}}}
+		require.Equal(t, capabilities.Supported().Slice(false), d.allowedCapabilities())
+	})
+
+	t.Run("explicit", func(t *testing.T) {
+		d := &Driver{config: Config{AllowCaps: []string{"CAP_KILL", "chown", "kill"}}}
+		require.Equal(t, []string{"chown", "kill"}, d.allowedCapabilities())
+	})
+}
+
+func TestExecDriver_FingerprintCommands(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	var data []byte
+	require.NoError(basePlug.MsgPackEncode(&data, &Config{
+		DefaultModePID: executor.IsolationModePrivate,
+		DefaultModeIPC: executor.IsolationModePrivate,
+		AllowCaps:      []string{"all"},
+		FingerprintCommands: map[string][]string{
+			"version": {"/bin/echo", "  1.2.3  "},
+			"broken":  {"/bin/sh", "-c", "echo oops; exit 1"},
+		},
+	}))
+	require.NoError(harness.SetConfig(&basePlug.Config{PluginConfig: data}))
+
+	fingerCh, err := harness.Fingerprint(context.Background())
+	require.NoError(err)
+	select {
+	case finger := <-fingerCh:
+		require.Equal(drivers.HealthStateHealthy, finger.Health)
+		version, ok := finger.Attributes["driver.exec.custom.version"].GetString()
+		require.True(ok, "missing attribute driver.exec.custom.version")
+		require.Equal("1.2.3", version)
+
+		// a failing command doesn't set an attribute or fail the fingerprint
+		require.NotContains(finger.Attributes, "driver.exec.custom.broken")
+
+		// "all" is reported as the concrete capabilities it expands to
+		caps, ok := finger.Attributes["driver.exec.allow_caps"].GetString()
+		require.True(ok, "missing attribute driver.exec.allow_caps")
+		require.Equal(capabilities.Supported().Slice(false), strings.Split(caps, ","))
 	case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
 		require.Fail("timeout receiving fingerprint")
 	}
@@ -197,25 +292,26 @@ func TestExecDriver_StartWaitStopKill(t *testing.T) {
 	require.NoError(harness.DestroyTask(task.ID, true))
 }
 
-func TestExecDriver_StartWaitRecover(t *testing.T) {
+func TestExecDriver_StopTask_KillSignal(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
 	ctestutils.ExecCompatible(t)
 
-	dctx, dcancel := context.WithCancel(context.Background())
-	defer dcancel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
-	d := NewExecDriver(dctx, testlog.HCLogger(t))
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
 	harness := dtestutil.NewDriverHarness(t, d)
 	task := &drivers.TaskConfig{
-		ID:        uuid.Generate(),
-		Name:      "test",
-		Resources: testResources,
+		ID:         uuid.Generate(),
+		Name:       "test",
+		Resources:  testResources,
+		KillSignal: "SIGUSR1",
 	}
 
 	tc := &TaskConfig{
 		Command: "/bin/sleep",
-		Args:    []string{"5"},
+		Args:    []string{"600"},
 	}
 	require.NoError(task.EncodeConcreteDriverConfig(&tc))
 
@@ -224,57 +320,29 @@ func TestExecDriver_StartWaitRecover(t *testing.T) {
 
 	handle, _, err := harness.StartTask(task)
 	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
 
-	ctx, cancel := context.WithCancel(context.Background())
-
-	ch, err := harness.WaitTask(ctx, handle.Config.ID)
+	ch, err := harness.WaitTask(context.Background(), handle.Config.ID)
 	require.NoError(err)
 
-	var wg sync.WaitGroup
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		result := <-ch
-		require.Error(result.Err)
-	}()
-
 	require.NoError(harness.WaitUntilStarted(task.ID, 1*time.Second))
-	cancel()
 
-	waitCh := make(chan struct{})
+	// no signal given, so the task's kill signal should be used
 	go func() {
-		defer close(waitCh)
-		wg.Wait()
+		harness.StopTask(task.ID, 2*time.Second, "")
 	}()
 
 	select {
-	case <-waitCh:
-		status, err := harness.InspectTask(task.ID)
-		require.NoError(err)
-		require.Equal(drivers.TaskStateRunning, status.State)
-	case <-time.After(1 * time.Second):
-		require.Fail("timeout waiting for task wait to cancel")
+	case result := <-ch:
+		require.Equal(int(syscall.SIGUSR1), result.Signal)
+	case <-time.After(10 * time.Second):
+		require.Fail("timeout waiting for task to shutdown")
 	}
-
-	// Loose task
-	d.(*Driver).tasks.Delete(task.ID)
-	_, err = harness.InspectTask(task.ID)
-	require.Error(err)
-
-	require.NoError(harness.RecoverTask(handle))
-	status, err := harness.InspectTask(task.ID)
-	require.NoError(err)
-	require.Equal(drivers.TaskStateRunning, status.State)
-
-	require.NoError(harness.StopTask(task.ID, 0, ""))
-	require.NoError(harness.DestroyTask(task.ID, true))
 }
 
-// TestExecDriver_NoOrphans asserts that when the main
-// task dies, the orphans in the PID namespaces are killed by the kernel
-func TestExecDriver_NoOrphans(t *testing.T) {
+func TestExecDriver_StartWaitStopKill_StopSignal(t *testing.T) {
 	ci.Parallel(t)
-	r := require.New(t)
+	require := require.New(t)
 	ctestutils.ExecCompatible(t)
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -282,113 +350,71 @@ func TestExecDriver_NoOrphans(t *testing.T) {
 
 	d := NewExecDriver(ctx, testlog.HCLogger(t))
 	harness := dtestutil.NewDriverHarness(t, d)
-	defer harness.Kill()
-
-	config := &Config{
-		NoPivotRoot:    false,
-		DefaultModePID: executor.IsolationModePrivate,
-		DefaultModeIPC: executor.IsolationModePrivate,
+	task := &drivers.TaskConfig{
+		ID:         uuid.Generate(),
+		Name:       "test",
+		Resources:  testResources,
+		KillSignal: "SIGUSR1",
 	}
 
-	var data []byte
-	r.NoError(basePlug.MsgPackEncode(&data, config))
-	baseConfig := &basePlug.Config{PluginConfig: data}
-	r.NoError(harness.SetConfig(baseConfig))
-
-	task := &drivers.TaskConfig{
-		ID:   uuid.Generate(),
-		Name: "test",
+	tc := &TaskConfig{
+		Command:    "/bin/sleep",
+		Args:       []string{"600"},
+		StopSignal: "SIGUSR2",
 	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
 
-	cleanup := harness.MkAllocDir(task, true)
+	cleanup := harness.MkAllocDir(task, false)
 	defer cleanup()
 
-	taskConfig := map[string]interface{}{}
-	taskConfig["command"] = "/bin/sh"
-	// print the child PID in the task PID namespace, then sleep for 5 seconds to give us a chance to examine processes
-	taskConfig["args"] = []string{"-c", fmt.Sprintf(`sleep 3600 & sleep 20`)}
-	r.NoError(task.EncodeConcreteDriverConfig(&taskConfig))
-
 	handle, _, err := harness.StartTask(task)
-	r.NoError(err)
+	require.NoError(err)
 	defer harness.DestroyTask(task.ID, true)
 
-	waitCh, err := harness.WaitTask(context.Background(), handle.Config.ID)
-	r.NoError(err)
-
-	r.NoError(harness.WaitUntilStarted(task.ID, 1*time.Second))
+	ch, err := harness.WaitTask(context.Background(), handle.Config.ID)
+	require.NoError(err)
 
-	var childPids []int
-	taskState := TaskState{}
-	testutil.WaitForResult(func() (bool, error) {
-		r.NoError(handle.GetDriverState(&taskState))
-		if taskState.Pid == 0 {
-			return false, fmt.Errorf("task PID is zero")
-		}
+	require.NoError(harness.WaitUntilStarted(task.ID, 1*time.Second))
 
-		children, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/task/%d/children", taskState.Pid, taskState.Pid))
-		if err != nil {
-			return false, fmt.Errorf("error reading /proc for children: %v", err)
-		}
-		pids := strings.Fields(string(children))
-		if len(pids) < 2 {
-			return false, fmt.Errorf("error waiting for two children, currently %d", len(pids))
-		}
-		for _, cpid := range pids {
-			p, err := strconv.Atoi(cpid)
-			if err != nil {
-				return false, fmt.Errorf("error parsing child pids from /proc: %s", cpid)
-			}
-			childPids = append(childPids, p)
-		}
-		return true, nil
-	}, func(err error) {
-		r.NoError(err)
-	})
+	// no signal given, so the task's stop_signal should be used over its
+	// kill signal
+	go func() {
+		harness.StopTask(task.ID, 2*time.Second, "")
+	}()
 
 	select {
-	case result := <-waitCh:
-		r.True(result.Successful(), "command failed: %#v", result)
-	case <-time.After(30 * time.Second):
-		r.Fail("timeout waiting for task to shutdown")
+	case result := <-ch:
+		require.Equal(int(syscall.SIGUSR2), result.Signal)
+	case <-time.After(10 * time.Second):
+		require.Fail("timeout waiting for task to shutdown")
 	}
+}
 
-	// isProcessRunning returns an error if process is not running
-	isProcessRunning := func(pid int) error {
-		process, err := os.FindProcess(pid)
-		if err != nil {
-			return fmt.Errorf("failed to find process: %s", err)
-		}
-
-		err = process.Signal(syscall.Signal(0))
-		if err != nil {
-			return fmt.Errorf("failed to signal process: %s", err)
-		}
+func TestExecDriver_stopSignal(t *testing.T) {
+	ci.Parallel(t)
 
-		return nil
+	for _, tc := range []struct {
+		name       string
+		stopSignal string
+		killSignal string
+		expected   string
+	}{
+		{name: "default", expected: "SIGINT"},
+		{name: "kill_signal", killSignal: "SIGUSR1", expected: "SIGUSR1"},
+		{name: "stop_signal", stopSignal: "SIGUSR2", killSignal: "SIGUSR1", expected: "SIGUSR2"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			task := &drivers.TaskConfig{KillSignal: tc.killSignal}
+			require.NoError(t, task.EncodeConcreteDriverConfig(&TaskConfig{
+				Command:    "/bin/sleep",
+				StopSignal: tc.stopSignal,
+			}))
+			require.Equal(t, tc.expected, stopSignal(task))
+		})
 	}
-
-	// task should be dead
-	r.Error(isProcessRunning(taskState.Pid))
-
-	// all children should eventually be killed by OS
-	testutil.WaitForResult(func() (bool, error) {
-		for _, cpid := range childPids {
-			err := isProcessRunning(cpid)
-			if err == nil {
-				return false, fmt.Errorf("child process %d is still running", cpid)
-			}
-			if !strings.Contains(err.Error(), "failed to signal process") {
-				return false, fmt.Errorf("unexpected error: %v", err)
-			}
-		}
-		return true, nil
-	}, func(err error) {
-		r.NoError(err)
-	})
 }
 
-func TestExecDriver_Stats(t *testing.T) {
+func TestExecDriver_StopTask_KillSignalGrace(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
 	ctestutils.ExecCompatible(t)
@@ -398,95 +424,252 @@ func TestExecDriver_Stats(t *testing.T) {
 
 	d := NewExecDriver(dctx, testlog.HCLogger(t))
 	harness := dtestutil.NewDriverHarness(t, d)
+
+	var data []byte
+	require.NoError(basePlug.MsgPackEncode(&data, &Config{
+		DefaultModePID:  executor.IsolationModePrivate,
+		DefaultModeIPC:  executor.IsolationModePrivate,
+		KillSignalGrace: "1s",
+	}))
+	require.NoError(harness.SetConfig(&basePlug.Config{PluginConfig: data}))
+
 	task := &drivers.TaskConfig{
 		ID:        uuid.Generate(),
 		Name:      "test",
 		Resources: testResources,
 	}
 
+	// the task ignores SIGINT, so it has to be killed
 	tc := &TaskConfig{
-		Command: "/bin/sleep",
-		Args:    []string{"5"},
+		Command: "/bin/bash",
+		Args:    []string{"-c", "trap '' INT; touch /local/trapped; sleep 600"},
 	}
 	require.NoError(task.EncodeConcreteDriverConfig(&tc))
 
 	cleanup := harness.MkAllocDir(task, false)
 	defer cleanup()
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := harness.TaskEvents(ctx)
+	require.NoError(err)
+
 	handle, _, err := harness.StartTask(task)
 	require.NoError(err)
-	require.NotNil(handle)
+	defer harness.DestroyTask(task.ID, true)
 
-	require.NoError(harness.WaitUntilStarted(task.ID, 1*time.Second))
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-	statsCh, err := harness.TaskStats(ctx, task.ID, time.Second*10)
+	ch, err := harness.WaitTask(context.Background(), handle.Config.ID)
 	require.NoError(err)
-	select {
-	case stats := <-statsCh:
-		require.NotEmpty(stats.ResourceUsage.MemoryStats.Measured)
-		require.NotZero(stats.Timestamp)
-		require.WithinDuration(time.Now(), time.Unix(0, stats.Timestamp), time.Second)
-	case <-time.After(time.Second):
-		require.Fail("timeout receiving from channel")
+	require.NoError(harness.WaitUntilStarted(task.ID, 1*time.Second))
+
+	// wait for the trap, so that SIGINT doesn't arrive before it's ignored
+	testutil.WaitForResult(func() (bool, error) {
+		_, err := os.Stat(filepath.Join(task.TaskDir().LocalDir, "trapped"))
+		return err == nil, err
+	}, func(err error) {
+		require.NoError(err)
+	})
+
+	// the grace period takes the place of the much longer timeout
+	go func() {
+		harness.StopTask(task.ID, time.Minute, "SIGINT")
+	}()
+
+	var signalled []string
+	timeout := time.After(10 * time.Second)
+	for len(signalled) < 2 {
+		select {
+		case event := <-events:
+			require.Equal(task.ID, event.TaskID)
+			signalled = append(signalled, event.Annotations["signal"])
+		case <-timeout:
+			require.Fail("timeout waiting for task events", "got %v", signalled)
+		}
 	}
+	require.Equal([]string{"SIGINT", "SIGKILL"}, signalled)
 
-	require.NoError(harness.DestroyTask(task.ID, true))
+	select {
+	case result := <-ch:
+		// the executor may fail to collect the killed process's wait status,
+		// so only its failure is certain
+		require.False(result.Successful(), "result: %#v", result)
+	case <-time.After(10 * time.Second):
+		require.Fail("timeout waiting for task to be killed")
+	}
 }
 
-func TestExecDriver_Start_Wait_AllocDir(t *testing.T) {
+func TestExecDriver_SignalTasksOnShutdown(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
 	ctestutils.ExecCompatible(t)
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	dctx, dcancel := context.WithCancel(context.Background())
+	defer dcancel()
 
-	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	d := NewExecDriver(dctx, testlog.HCLogger(t))
 	harness := dtestutil.NewDriverHarness(t, d)
+
+	var data []byte
+	require.NoError(basePlug.MsgPackEncode(&data, &Config{
+		DefaultModePID:        executor.IsolationModePrivate,
+		DefaultModeIPC:        executor.IsolationModePrivate,
+		SignalTasksOnShutdown: true,
+	}))
+	require.NoError(harness.SetConfig(&basePlug.Config{PluginConfig: data}))
+
 	task := &drivers.TaskConfig{
-		ID:        uuid.Generate(),
-		Name:      "sleep",
-		Resources: testResources,
+		ID:         uuid.Generate(),
+		Name:       "test",
+		Resources:  testResources,
+		KillSignal: "SIGUSR1",
+	}
+
+	// the task records the signal it is sent before exiting
+	tc := &TaskConfig{
+		Command: "/bin/sh",
+		Args:    []string{"-c", "trap 'touch /local/signalled; exit 0' USR1; touch /local/trapped; sleep 600 & wait"},
 	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
 	cleanup := harness.MkAllocDir(task, false)
 	defer cleanup()
 
-	exp := []byte{'w', 'i', 'n'}
-	file := "output.txt"
+	_, _, err := harness.StartTask(task)
+	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+
+	testutil.WaitForResult(func() (bool, error) {
+		_, err := os.Stat(filepath.Join(task.TaskDir().LocalDir, "trapped"))
+		return err == nil, err
+	}, func(err error) {
+		require.NoError(err)
+	})
+
+	dcancel()
+
+	testutil.WaitForResult(func() (bool, error) {
+		_, err := os.Stat(filepath.Join(task.TaskDir().LocalDir, "signalled"))
+		return err == nil, err
+	}, func(err error) {
+		require.NoError(err, "task was not signalled on shutdown")
+	})
+}
+
+func TestExecDriver_InspectTaskContext(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	d := NewExecDriver(context.Background(), testlog.HCLogger(t)).(*Driver)
+	h := &taskHandle{
+		taskConfig: &drivers.TaskConfig{ID: uuid.Generate(), Name: "test"},
+		procState:  drivers.TaskStateRunning,
+		logger:     testlog.HCLogger(t),
+	}
+	d.tasks.Set(h.taskConfig.ID, h)
+
+	status, err := d.InspectTaskContext(context.Background(), h.taskConfig.ID)
+	require.NoError(err)
+	require.Equal(drivers.TaskStateRunning, status.State)
+
+	// simulate a stalled status read by holding the handle's state lock
+	h.stateLock.Lock()
+	defer h.stateLock.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = d.InspectTaskContext(ctx, h.taskConfig.ID)
+	require.ErrorIs(err, context.DeadlineExceeded)
+	require.Less(time.Since(start), time.Second)
+
+	_, err = d.InspectTaskContext(ctx, "missing")
+	require.ErrorIs(err, drivers.ErrTaskNotFound)
+}
+
+func TestExecDriver_StartWaitRecover(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	dctx, dcancel := context.WithCancel(context.Background())
+	defer dcancel()
+
+	d := NewExecDriver(dctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "test",
+		Resources: testResources,
+	}
+
 	tc := &TaskConfig{
-		Command: "/bin/bash",
-		Args: []string{
-			"-c",
-			fmt.Sprintf(`sleep 1; echo -n %s > /alloc/%s`, string(exp), file),
-		},
+		Command: "/bin/sleep",
+		Args:    []string{"5"},
 	}
 	require.NoError(task.EncodeConcreteDriverConfig(&tc))
 
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
 	handle, _, err := harness.StartTask(task)
 	require.NoError(err)
-	require.NotNil(handle)
 
-	// Task should terminate quickly
-	waitCh, err := harness.WaitTask(context.Background(), task.ID)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ch, err := harness.WaitTask(ctx, handle.Config.ID)
 	require.NoError(err)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		result := <-ch
+		require.Error(result.Err)
+	}()
+
+	require.NoError(harness.WaitUntilStarted(task.ID, 1*time.Second))
+	cancel()
+
+	waitCh := make(chan struct{})
+	go func() {
+		defer close(waitCh)
+		wg.Wait()
+	}()
+
 	select {
-	case res := <-waitCh:
-		require.True(res.Successful(), "task should have exited successfully: %v", res)
-	case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
-		require.Fail("timeout waiting for task")
+	case <-waitCh:
+		status, err := harness.InspectTask(task.ID)
+		require.NoError(err)
+		require.Equal(drivers.TaskStateRunning, status.State)
+	case <-time.After(1 * time.Second):
+		require.Fail("timeout waiting for task wait to cancel")
 	}
 
-	// Check that data was written to the shared alloc directory.
-	outputFile := filepath.Join(task.TaskDir().SharedAllocDir, file)
-	act, err := ioutil.ReadFile(outputFile)
+	status, err := harness.InspectTask(task.ID)
 	require.NoError(err)
-	require.Exactly(exp, act)
+	startedAt := status.StartedAt
+	require.False(startedAt.IsZero())
+
+	// Loose task
+	d.(*Driver).tasks.Delete(task.ID)
+	_, err = harness.InspectTask(task.ID)
+	require.Error(err)
+
+	require.NoError(harness.RecoverTask(handle))
+	status, err = harness.InspectTask(task.ID)
+	require.NoError(err)
+	require.Equal(drivers.TaskStateRunning, status.State)
+
+	// the start time is restored from the driver state, not reset
+	require.True(startedAt.Equal(status.StartedAt),
+		"expected start time %v, got %v", startedAt, status.StartedAt)
 
+	require.NoError(harness.StopTask(task.ID, 0, ""))
 	require.NoError(harness.DestroyTask(task.ID, true))
 }
 
-func TestExecDriver_User(t *testing.T) {
+func TestExecDriver_MaxRuntime(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
 	ctestutils.ExecCompatible(t)
@@ -498,32 +681,38 @@ func TestExecDriver_User(t *testing.T) {
 	harness := dtestutil.NewDriverHarness(t, d)
 	task := &drivers.TaskConfig{
 		ID:        uuid.Generate(),
-		Name:      "sleep",
-		User:      "alice",
+		Name:      "test",
 		Resources: testResources,
 	}
-	cleanup := harness.MkAllocDir(task, false)
-	defer cleanup()
 
 	tc := &TaskConfig{
-		Command: "/bin/sleep",
-		Args:    []string{"100"},
+		Command:    "/bin/sleep",
+		Args:       []string{"600"},
+		MaxRuntime: "1s",
 	}
 	require.NoError(task.EncodeConcreteDriverConfig(&tc))
 
-	handle, _, err := harness.StartTask(task)
-	require.Error(err)
-	require.Nil(handle)
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
 
-	msg := "user alice"
-	if !strings.Contains(err.Error(), msg) {
-		t.Fatalf("Expecting '%v' in '%v'", msg, err)
+	_, _, err := harness.StartTask(task)
+	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+
+	waitCh, err := harness.WaitTask(context.Background(), task.ID)
+	require.NoError(err)
+
+	select {
+	case res := <-waitCh:
+		require.False(res.Successful())
+		require.Equal(int(syscall.SIGINT), res.Signal)
+		require.EqualError(res.Err, "max runtime exceeded: task ran longer than 1s")
+	case <-time.After(time.Duration(testutil.TestMultiplier()*10) * time.Second):
+		require.Fail("timeout waiting for task to be stopped")
 	}
 }
 
-// TestExecDriver_HandlerExec ensures the exec driver's handle properly
-// executes commands inside the container.
-func TestExecDriver_HandlerExec(t *testing.T) {
+func TestExecDriver_MemorySwappiness(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
 	ctestutils.ExecCompatible(t)
@@ -535,215 +724,3629 @@ func TestExecDriver_HandlerExec(t *testing.T) {
 	harness := dtestutil.NewDriverHarness(t, d)
 	task := &drivers.TaskConfig{
 		ID:        uuid.Generate(),
-		Name:      "sleep",
+		Name:      "test",
 		Resources: testResources,
 	}
-	cleanup := harness.MkAllocDir(task, false)
-	defer cleanup()
 
 	tc := &TaskConfig{
-		Command: "/bin/sleep",
-		Args:    []string{"9000"},
+		Command:          "/bin/sleep",
+		Args:             []string{"600"},
+		MemorySwappiness: helper.IntToPtr(0),
 	}
 	require.NoError(task.EncodeConcreteDriverConfig(&tc))
 
-	handle, _, err := harness.StartTask(task)
-	require.NoError(err)
-	require.NotNil(handle)
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
 
-	// Exec a command that should work and dump the environment
-	// TODO: enable section when exec env is fully loaded
-	/*res, err := harness.ExecTask(task.ID, []string{"/bin/sh", "-c", "env | grep ^NOMAD"}, time.Second)
+	_, _, err := harness.StartTask(task)
 	require.NoError(err)
-	require.True(res.ExitResult.Successful())
-
-	// Assert exec'd commands are run in a task-like environment
-	scriptEnv := make(map[string]string)
-	for _, line := range strings.Split(string(res.Stdout), "\n") {
-		if line == "" {
-			continue
-		}
-		parts := strings.SplitN(string(line), "=", 2)
-		if len(parts) != 2 {
-			t.Fatalf("Invalid env var: %q", line)
-		}
-		scriptEnv[parts[0]] = parts[1]
-	}
-	if v, ok := scriptEnv["NOMAD_SECRETS_DIR"]; !ok || v != "/secrets" {
-		t.Errorf("Expected NOMAD_SECRETS_DIR=/secrets but found=%t value=%q", ok, v)
-	}*/
+	defer harness.DestroyTask(task.ID, true)
+	require.NoError(harness.WaitUntilStarted(task.ID, 1*time.Second))
 
-	// Assert cgroup membership
-	res, err := harness.ExecTask(task.ID, []string{"/bin/cat", "/proc/self/cgroup"}, time.Second)
-	require.NoError(err)
-	require.True(res.ExitResult.Successful())
-	found := false
-	for _, line := range strings.Split(string(res.Stdout), "\n") {
-		// Every cgroup entry should be /nomad/$ALLOC_ID
-		if line == "" {
-			continue
-		}
-		// Skip rdma subsystem; rdma was added in most recent kernels and libcontainer/docker
-		// don't isolate it by default.
-		if strings.Contains(line, ":rdma:") || strings.Contains(line, "::") {
-			continue
-		}
-		if !strings.Contains(line, ":/nomad/") {
-			t.Errorf("Not a member of the alloc's cgroup: expected=...:/nomad/... -- found=%q", line)
-			continue
-		}
-		found = true
+	dir, v1 := taskMemoryCgroup(t, taskPid(t, harness, task.ID))
+	if !v1 {
+		t.Skip("memory.swappiness is only available with cgroups v1")
 	}
-	require.True(found, "exec'd command isn't in the task's cgroup")
 
-	// Exec a command that should fail
-	res, err = harness.ExecTask(task.ID, []string{"/usr/bin/stat", "lkjhdsaflkjshowaisxmcvnlia"}, time.Second)
+	swappiness, err := ioutil.ReadFile(filepath.Join(dir, "memory.swappiness"))
 	require.NoError(err)
-	require.False(res.ExitResult.Successful())
-	if expected := "No such file or directory"; !bytes.Contains(res.Stdout, []byte(expected)) {
-		t.Fatalf("expected output to contain %q but found: %q", expected, res.Stdout)
-	}
-
-	require.NoError(harness.DestroyTask(task.ID, true))
+	require.Equal("0", strings.TrimSpace(string(swappiness)))
 }
 
-func TestExecDriver_DevicesAndMounts(t *testing.T) {
+func TestExecDriver_OOMKilled(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
 	ctestutils.ExecCompatible(t)
 
-	tmpDir, err := ioutil.TempDir("", "exec_binds_mounts")
-	require.NoError(err)
-	defer os.RemoveAll(tmpDir)
-
-	err = ioutil.WriteFile(filepath.Join(tmpDir, "testfile"), []byte("from-host"), 600)
-	require.NoError(err)
-
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	d := NewExecDriver(ctx, testlog.HCLogger(t))
 	harness := dtestutil.NewDriverHarness(t, d)
 	task := &drivers.TaskConfig{
-		ID:         uuid.Generate(),
-		Name:       "test",
-		User:       "root", // need permission to read mounts paths
-		Resources:  testResources,
-		StdoutPath: filepath.Join(tmpDir, "task-stdout"),
-		StderrPath: filepath.Join(tmpDir, "task-stderr"),
-		Devices: []*drivers.DeviceConfig{
-			{
-				TaskPath:    "/dev/inserted-random",
-				HostPath:    "/dev/random",
-				Permissions: "rw",
-			},
-		},
-		Mounts: []*drivers.MountConfig{
-			{
-				TaskPath: "/tmp/task-path-rw",
-				HostPath: tmpDir,
-				Readonly: false,
+		ID:      uuid.Generate(),
+		AllocID: uuid.Generate(),
+		Name:    "test",
+		Resources: &drivers.Resources{
+			NomadResources: &structs.AllocatedTaskResources{
+				Memory: structs.AllocatedMemoryResources{
+					MemoryMB: 16,
+				},
+				Cpu: structs.AllocatedCpuResources{
+					CpuShares: 100,
+				},
 			},
-			{
-				TaskPath: "/tmp/task-path-ro",
-				HostPath: tmpDir,
-				Readonly: true,
+			LinuxResources: &drivers.LinuxResources{
+				MemoryLimitBytes: 16 * 1024 * 1024,
+				CPUShares:        100,
 			},
 		},
 	}
 
-	require.NoError(ioutil.WriteFile(task.StdoutPath, []byte{}, 660))
-	require.NoError(ioutil.WriteFile(task.StderrPath, []byte{}, 660))
-
+	// doubles a string until the task runs out of memory
 	tc := &TaskConfig{
 		Command: "/bin/bash",
-		Args: []string{"-c", `
-export LANG=en.UTF-8
-echo "mounted device /inserted-random: $(stat -c '%t:%T' /dev/inserted-random)"
-echo "reading from ro path: $(cat /tmp/task-path-ro/testfile)"
-echo "reading from rw path: $(cat /tmp/task-path-rw/testfile)"
-touch /tmp/task-path-rw/testfile && echo 'overwriting file in rw succeeded'
-touch /tmp/task-path-rw/testfile-from-rw && echo from-exec >  /tmp/task-path-rw/testfile-from-rw && echo 'writing new file in rw succeeded'
-touch /tmp/task-path-ro/testfile && echo 'overwriting file in ro succeeded'
-touch /tmp/task-path-ro/testfile-from-ro && echo from-exec >  /tmp/task-path-ro/testfile-from-ro && echo 'writing new file in ro succeeded'
-exit 0
-`},
+		Args:    []string{"-c", `a=x; while true; do a="$a$a"; done`},
 	}
 	require.NoError(task.EncodeConcreteDriverConfig(&tc))
 
 	cleanup := harness.MkAllocDir(task, false)
 	defer cleanup()
 
-	handle, _, err := harness.StartTask(task)
+	events, err := harness.TaskEvents(ctx)
 	require.NoError(err)
 
-	ch, err := harness.WaitTask(context.Background(), handle.Config.ID)
+	_, _, err = harness.StartTask(task)
 	require.NoError(err)
-	result := <-ch
-	require.NoError(harness.DestroyTask(task.ID, true))
+	defer harness.DestroyTask(task.ID, true)
 
-	stdout, err := ioutil.ReadFile(task.StdoutPath)
+	waitCh, err := harness.WaitTask(context.Background(), task.ID)
 	require.NoError(err)
-	require.Equal(`mounted device /inserted-random: 1:8
-reading from ro path: from-host
-reading from rw path: from-host
-overwriting file in rw succeeded
-writing new file in rw succeeded`, strings.TrimSpace(string(stdout)))
+
+	select {
+	case res := <-waitCh:
+		require.False(res.Successful())
+		require.True(res.OOMKilled, "task was not OOM killed: %#v", res)
+	case <-time.After(time.Duration(testutil.TestMultiplier()*10) * time.Second):
+		require.Fail("timeout waiting for task to be OOM killed")
+	}
+
+	select {
+	case event := <-events:
+		require.Equal(task.ID, event.TaskID)
+		require.Equal("true", event.Annotations["oom_killed"])
+	case <-time.After(5 * time.Second):
+		require.Fail("timeout waiting for task event")
+	}
+
+	status, err := harness.InspectTask(task.ID)
+	require.NoError(err)
+	require.True(status.ExitResult.OOMKilled)
+}
+
+func TestExecDriver_OOMScoreAdj(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	var data []byte
+	require.NoError(basePlug.MsgPackEncode(&data, &Config{
+		DefaultModePID:     executor.IsolationModePrivate,
+		DefaultModeIPC:     executor.IsolationModePrivate,
+		DefaultOOMScoreAdj: 300,
+	}))
+	require.NoError(harness.SetConfig(&basePlug.Config{PluginConfig: data}))
+
+	for _, tc := range []struct {
+		adj *int
+		exp string
+	}{
+		{adj: nil, exp: "300"},
+		{adj: helper.IntToPtr(500), exp: "500"},
+	} {
+		task := &drivers.TaskConfig{
+			ID:        uuid.Generate(),
+			Name:      "test",
+			Resources: testResources,
+		}
+		taskConfig := &TaskConfig{
+			Command:     "/bin/sleep",
+			Args:        []string{"600"},
+			OOMScoreAdj: tc.adj,
+		}
+		require.NoError(task.EncodeConcreteDriverConfig(&taskConfig))
+
+		cleanup := harness.MkAllocDir(task, false)
+		defer cleanup()
+
+		_, _, err := harness.StartTask(task)
+		require.NoError(err)
+		defer harness.DestroyTask(task.ID, true)
+		require.NoError(harness.WaitUntilStarted(task.ID, 1*time.Second))
+
+		adj, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/oom_score_adj", taskPid(t, harness, task.ID)))
+		require.NoError(err)
+		require.Equal(tc.exp, strings.TrimSpace(string(adj)))
+	}
+}
+
+func TestExecDriver_NoNewPrivileges(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "test",
+		Resources: testResources,
+	}
+
+	tc := &TaskConfig{
+		Command:         "/bin/sleep",
+		Args:            []string{"600"},
+		NoNewPrivileges: helper.BoolToPtr(true),
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	_, _, err := harness.StartTask(task)
+	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+	require.NoError(harness.WaitUntilStarted(task.ID, 1*time.Second))
+
+	status, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/status", taskPid(t, harness, task.ID)))
+	require.NoError(err)
+	require.Regexp(`(?m)^NoNewPrivs:\s+1$`, string(status))
+}
+
+func TestExecDriver_UsernsMode(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	var data []byte
+	require.NoError(basePlug.MsgPackEncode(&data, &Config{
+		DefaultModePID: executor.IsolationModePrivate,
+		DefaultModeIPC: executor.IsolationModePrivate,
+		UsernsIDOffset: 200000,
+	}))
+	require.NoError(harness.SetConfig(&basePlug.Config{PluginConfig: data}))
+
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "test",
+		Resources: testResources,
+	}
+	tc := &TaskConfig{
+		Command:    "/bin/sleep",
+		Args:       []string{"600"},
+		ModeUserns: executor.IsolationModePrivate,
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	// root in the task's user namespace must be able to reach the task
+	// directory, which the harness creates in a private temp dir
+	require.NoError(os.Chmod(task.AllocDir, 0755))
+
+	_, _, err := harness.StartTask(task)
+	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+	require.NoError(harness.WaitUntilStarted(task.ID, 1*time.Second))
+
+	// root in the task's user namespace is the offset on the host
+	pid := taskPid(t, harness, task.ID)
+	for _, file := range []string{"uid_map", "gid_map"} {
+		idMap, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/%s", pid, file))
+		require.NoError(err)
+		require.Equal([]string{"0", "200000", "65536"}, strings.Fields(string(idMap)), file)
+	}
+}
+
+func TestExecDriver_HealthyQuietPeriod(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "test",
+		Resources: testResources,
+	}
+
+	// spin before recording when the task went idle
+	tc := &TaskConfig{
+		Command:             "/bin/sh",
+		Args:                []string{"-c", `i=0; while [ $i -lt 500000 ]; do i=$((i+1)); done; touch /local/idle; sleep 600`},
+		HealthyCpuThreshold: 50,
+		HealthyQuietPeriod:  "1s",
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	_, _, err := harness.StartTask(task)
+	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+
+	// the task isn't started until it has been idle for the quiet period
+	fi, err := os.Stat(filepath.Join(task.TaskDir().LocalDir, "idle"))
+	require.NoError(err, "task was started before it went idle")
+	require.GreaterOrEqual(time.Since(fi.ModTime()), time.Second)
+
+	status, err := harness.InspectTask(task.ID)
+	require.NoError(err)
+	require.Equal(drivers.TaskStateRunning, status.State)
+}
+
+func TestExecDriver_MemorySwapMB(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "test",
+		Resources: testResources,
+	}
+
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	// the limit can't be below the task's memory limit
+	tc := &TaskConfig{
+		Command:      "/bin/sleep",
+		Args:         []string{"600"},
+		MemorySwapMB: 64,
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+	_, _, err := harness.StartTask(task)
+	require.Error(err)
+	require.Contains(err.Error(), "memory_swap_mb must be at least the task's memory limit of 128 MB, got 64")
+
+	tc.MemorySwapMB = 192
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+	_, _, err = harness.StartTask(task)
+	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+	require.NoError(harness.WaitUntilStarted(task.ID, 1*time.Second))
+
+	// cgroups v2 limits swap alone, rather than memory and swap combined
+	file, expected := "memory.memsw.limit_in_bytes", "201326592"
+	dir, v1 := taskMemoryCgroup(t, taskPid(t, harness, task.ID))
+	if !v1 {
+		file, expected = "memory.swap.max", "67108864"
+	}
+
+	limit, err := ioutil.ReadFile(filepath.Join(dir, file))
+	if os.IsNotExist(err) {
+		t.Skipf("%s is not available, swap accounting may be disabled", file)
+	}
+	require.NoError(err)
+	require.Equal(expected, strings.TrimSpace(string(limit)))
+}
+
+func TestExecDriver_PidsLimit(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	tmpDir := t.TempDir()
+	task := &drivers.TaskConfig{
+		ID:         uuid.Generate(),
+		Name:       "test",
+		Resources:  testResources,
+		StdoutPath: filepath.Join(tmpDir, "task-stdout"),
+		StderrPath: filepath.Join(tmpDir, "task-stderr"),
+	}
+	require.NoError(ioutil.WriteFile(task.StdoutPath, []byte{}, 0644))
+	require.NoError(ioutil.WriteFile(task.StderrPath, []byte{}, 0644))
+
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	// a child shell forks until it can't, after which the task is still
+	// able to run and report the child's exit code
+	tc := &TaskConfig{
+		Command:   "/bin/sh",
+		Args:      []string{"-c", `sh -c 'i=0; while [ $i -lt 50 ]; do sleep 30 & i=$((i+1)); done'; echo "forks exited $?"`},
+		PidsLimit: 10,
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	_, _, err := harness.StartTask(task)
+	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+
+	waitCh, err := harness.WaitTask(context.Background(), task.ID)
+	require.NoError(err)
+	select {
+	case res := <-waitCh:
+		require.True(res.Successful(), "task failed: %v", res)
+	case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
+		require.Fail("timeout waiting for task to exit")
+	}
+
+	stdout, err := ioutil.ReadFile(task.StdoutPath)
+	require.NoError(err)
+	require.Equal("forks exited 2\n", string(stdout))
 
 	stderr, err := ioutil.ReadFile(task.StderrPath)
 	require.NoError(err)
-	require.Equal(`touch: cannot touch '/tmp/task-path-ro/testfile': Read-only file system
-touch: cannot touch '/tmp/task-path-ro/testfile-from-ro': Read-only file system`, strings.TrimSpace(string(stderr)))
+	require.Contains(string(stderr), "Cannot fork")
+}
 
-	// testing exit code last so we can inspect output first
-	require.Zero(result.ExitCode)
+func TestExecDriver_TailTaskLogs(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
 
-	fromRWContent, err := ioutil.ReadFile(filepath.Join(tmpDir, "testfile-from-rw"))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t)).(*Driver)
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	// the capability is advertised over the plugin RPC
+	caps, err := harness.Capabilities()
 	require.NoError(err)
-	require.Equal("from-exec", strings.TrimSpace(string(fromRWContent)))
+	require.True(caps.TailLogs)
+
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "test",
+		Resources: testResources,
+	}
+	tc := &TaskConfig{
+		Command: "/bin/sh",
+		Args:    []string{"-c", "for i in 1 2 3 4 5 6; do echo line $i; done; echo oops >&2"},
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	// output goes through fifos read by logmon, as it does in a client
+	cleanup := harness.MkAllocDir(task, true)
+	defer cleanup()
+	fi, err := os.Stat(task.StdoutPath)
+	require.NoError(err)
+	require.Equal(os.ModeNamedPipe, fi.Mode()&os.ModeNamedPipe)
+
+	_, _, err = harness.StartTask(task)
+	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+
+	waitCh, err := harness.WaitTask(context.Background(), task.ID)
+	require.NoError(err)
+	select {
+	case res := <-waitCh:
+		require.True(res.Successful(), "task failed: %v", res)
+	case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
+		require.Fail("timeout waiting for task to exit")
+	}
+
+	// logmon writes the output to the log files asynchronously
+	var lines []string
+	require.Eventually(func() bool {
+		lines, err = d.TailTaskLogs(task.ID, "stdout", 3)
+		return err == nil && len(lines) == 3 && lines[2] == "line 6"
+	}, 5*time.Second, 50*time.Millisecond, "unexpected stdout tail: %v, %v", lines, err)
+	require.Equal([]string{"line 4", "line 5", "line 6"}, lines)
+
+	require.Eventually(func() bool {
+		lines, err = d.TailTaskLogs(task.ID, "stderr", 10)
+		return err == nil && len(lines) == 1
+	}, 5*time.Second, 50*time.Millisecond, "unexpected stderr tail: %v, %v", lines, err)
+	require.Equal([]string{"oops"}, lines)
+
+	_, err = d.TailTaskLogs(task.ID, "stdin", 3)
+	require.EqualError(err, `stream must be "stdout" or "stderr", got "stdin"`)
+
+	_, err = d.TailTaskLogs(uuid.Generate(), "stdout", 3)
+	require.Equal(drivers.ErrTaskNotFound, err)
 }
 
-func TestConfig_ParseAllHCL(t *testing.T) {
+// TestExecDriver_tailLogFiles asserts that tailing continues from the newest
+// rotated log file into older ones, including lines split between them.
+func TestExecDriver_tailLogFiles(t *testing.T) {
 	ci.Parallel(t)
+	require := require.New(t)
 
-	cfgStr := `
-config {
-  command = "/bin/bash"
-  args = ["-c", "echo hello"]
-}`
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"test.stdout.0":      "line 1\nline 2\nli",
+		"test.stdout.1":      "ne 3\nline 4\n",
+		"test.stderr.2":      "oops\n",
+		".test.stdout.fifo":  "",
+		"test.stdout.backup": "other\n",
+	} {
+		require.NoError(ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
 
-	expected := &TaskConfig{
-		Command: "/bin/bash",
-		Args:    []string{"-c", "echo hello"},
+	lines, err := tailLogFiles(dir, "test.stdout", 1)
+	require.NoError(err)
+	require.Equal([]string{"line 4"}, lines)
+
+	lines, err = tailLogFiles(dir, "test.stdout", 3)
+	require.NoError(err)
+	require.Equal([]string{"line 2", "line 3", "line 4"}, lines)
+
+	lines, err = tailLogFiles(dir, "test.stdout", 10)
+	require.NoError(err)
+	require.Equal([]string{"line 1", "line 2", "line 3", "line 4"}, lines)
+
+	lines, err = tailLogFiles(dir, "other.stdout", 10)
+	require.NoError(err)
+	require.Empty(lines)
+}
+
+func TestExecDriver_ListManagedTasks(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t)).(*Driver)
+	harness := dtestutil.NewDriverHarness(t, d)
+	require.Empty(d.ListManagedTasks())
+
+	ids := make([]string, 2)
+	for i := range ids {
+		task := &drivers.TaskConfig{
+			ID:        uuid.Generate(),
+			Name:      fmt.Sprintf("sleep-%d", i),
+			Resources: testResources,
+		}
+		require.NoError(task.EncodeConcreteDriverConfig(&TaskConfig{
+			Command: "/bin/sleep",
+			Args:    []string{"600"},
+		}))
+		cleanup := harness.MkAllocDir(task, false)
+		defer cleanup()
+
+		_, _, err := harness.StartTask(task)
+		require.NoError(err)
+		defer harness.DestroyTask(task.ID, true)
+		ids[i] = task.ID
+	}
+
+	tasks := d.ListManagedTasks()
+	require.Len(tasks, 2)
+	for _, info := range tasks {
+		require.Contains(ids, info.TaskID)
+		require.NotZero(info.PID)
+		require.Equal(drivers.TaskStateRunning, info.State)
+		require.False(info.StartedAt.IsZero())
+	}
+	require.NotEqual(tasks[0].TaskID, tasks[1].TaskID)
+}
+
+// TestExecDriver_ExitMetric asserts that tasks killed by a signal are counted
+// apart from tasks exiting with the exit code the signal would produce.
+func TestExecDriver_ExitMetric(t *testing.T) {
+	// not parallel: replaces the global metrics sink
+	ctestutils.ExecCompatible(t)
+
+	inm := metrics.NewInmemSink(10*time.Second, time.Minute)
+	cfg := metrics.DefaultConfig("")
+	cfg.EnableHostname = false
+	cfg.EnableRuntimeMetrics = false
+	_, err := metrics.NewGlobal(cfg, inm)
+	require.NoError(t, err)
+
+	counted := func(key string) bool {
+		for _, interval := range inm.Data() {
+			if c, ok := interval.Counters[key]; ok && c.Count > 0 {
+				return true
+			}
+		}
+		return false
+	}
+
+	run := func(t *testing.T, tc *TaskConfig, signal string) {
+		require := require.New(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		d := NewExecDriver(ctx, testlog.HCLogger(t))
+		harness := dtestutil.NewDriverHarness(t, d)
+		task := &drivers.TaskConfig{
+			ID:        uuid.Generate(),
+			Name:      "test",
+			Resources: testResources,
+		}
+		require.NoError(task.EncodeConcreteDriverConfig(&tc))
+		cleanup := harness.MkAllocDir(task, false)
+		defer cleanup()
+
+		_, _, err := harness.StartTask(task)
+		require.NoError(err)
+		defer harness.DestroyTask(task.ID, true)
+
+		waitCh, err := harness.WaitTask(context.Background(), task.ID)
+		require.NoError(err)
+		if signal != "" {
+			require.NoError(harness.WaitUntilStarted(task.ID, time.Second))
+			require.NoError(harness.SignalTask(task.ID, signal))
+		}
+		select {
+		case <-waitCh:
+		case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
+			require.Fail("timeout waiting for task to exit")
+		}
+	}
+
+	t.Run("signal", func(t *testing.T) {
+		run(t, &TaskConfig{Command: "/bin/sleep", Args: []string{"600"}}, "SIGKILL")
+		require.Eventually(t, func() bool {
+			return counted("client.driver.exec.task_exits;exit_type=signal;signal=9")
+		}, 5*time.Second, 50*time.Millisecond)
+	})
+
+	t.Run("exit code", func(t *testing.T) {
+		run(t, &TaskConfig{Command: "/bin/sh", Args: []string{"-c", "exit 9"}}, "")
+		require.Eventually(t, func() bool {
+			return counted("client.driver.exec.task_exits;exit_type=exit_code;exit_code=9")
+		}, 5*time.Second, 50*time.Millisecond)
+	})
+
+	require.False(t, counted("client.driver.exec.task_exits;exit_type=exit_code;exit_code=137"),
+		"signalled task should not be counted by its exit code")
+}
+
+// TestExecDriver_RecoverTask_IncompatibleState asserts that handles the driver
+// can't decode are refused rather than reattached with bad state.
+func TestExecDriver_RecoverTask_IncompatibleState(t *testing.T) {
+	ci.Parallel(t)
+
+	d := NewExecDriver(context.Background(), testlog.HCLogger(t))
+	task := &drivers.TaskConfig{
+		ID:   uuid.Generate(),
+		Name: "test",
+	}
+
+	t.Run("newer version", func(t *testing.T) {
+		handle := drivers.NewTaskHandle(taskHandleVersion + 1)
+		handle.Config = task
+		require.NoError(t, handle.SetDriverState(&TaskState{TaskConfig: task}))
+
+		err := d.RecoverTask(handle)
+		require.EqualError(t, err, fmt.Sprintf(
+			"task handle version %d is not supported by this exec driver, which supports version %d",
+			taskHandleVersion+1, taskHandleVersion))
+		_, ok := d.(*Driver).tasks.Get(task.ID)
+		require.False(t, ok)
+	})
+
+	t.Run("missing reattach config", func(t *testing.T) {
+		handle := drivers.NewTaskHandle(taskHandleVersion)
+		handle.Config = task
+		require.NoError(t, handle.SetDriverState(&TaskState{TaskConfig: task}))
+
+		err := d.RecoverTask(handle)
+		require.EqualError(t, err, "task state from handle is incomplete: missing reattach config or task config")
+		_, ok := d.(*Driver).tasks.Get(task.ID)
+		require.False(t, ok)
+	})
+}
+
+// TestExecDriver_NoOrphans asserts that when the main
+// task dies, the orphans in the PID namespaces are killed by the kernel
+func TestExecDriver_NoOrphans(t *testing.T) {
+	ci.Parallel(t)
+	r := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	defer harness.Kill()
+
+	config := &Config{
+		NoPivotRoot:    false,
+		DefaultModePID: executor.IsolationModePrivate,
+		DefaultModeIPC: executor.IsolationModePrivate,
+	}
+
+	var data []byte
+	r.NoError(basePlug.MsgPackEncode(&data, config))
+	baseConfig := &basePlug.Config{PluginConfig: data}
+	r.NoError(harness.SetConfig(baseConfig))
+
+	task := &drivers.TaskConfig{
+		ID:   uuid.Generate(),
+		Name: "test",
+	}
+
+	cleanup := harness.MkAllocDir(task, true)
+	defer cleanup()
+
+	taskConfig := map[string]interface{}{}
+	taskConfig["command"] = "/bin/sh"
+	// print the child PID in the task PID namespace, then sleep for 5 seconds to give us a chance to examine processes
+	taskConfig["args"] = []string{"-c", fmt.Sprintf(`sleep 3600 & sleep 20`)}
+	r.NoError(task.EncodeConcreteDriverConfig(&taskConfig))
+
+	handle, _, err := harness.StartTask(task)
+	r.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+
+	waitCh, err := harness.WaitTask(context.Background(), handle.Config.ID)
+	r.NoError(err)
+
+	r.NoError(harness.WaitUntilStarted(task.ID, 1*time.Second))
+
+	var childPids []int
+	taskState := TaskState{}
+	testutil.WaitForResult(func() (bool, error) {
+		r.NoError(handle.GetDriverState(&taskState))
+		if taskState.Pid == 0 {
+			return false, fmt.Errorf("task PID is zero")
+		}
+
+		children, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/task/%d/children", taskState.Pid, taskState.Pid))
+		if err != nil {
+			return false, fmt.Errorf("error reading /proc for children: %v", err)
+		}
+		pids := strings.Fields(string(children))
+		if len(pids) < 2 {
+			return false, fmt.Errorf("error waiting for two children, currently %d", len(pids))
+		}
+		for _, cpid := range pids {
+			p, err := strconv.Atoi(cpid)
+			if err != nil {
+				return false, fmt.Errorf("error parsing child pids from /proc: %s", cpid)
+			}
+			childPids = append(childPids, p)
+		}
+		return true, nil
+	}, func(err error) {
+		r.NoError(err)
+	})
+
+	select {
+	case result := <-waitCh:
+		r.True(result.Successful(), "command failed: %#v", result)
+	case <-time.After(30 * time.Second):
+		r.Fail("timeout waiting for task to shutdown")
+	}
+
+	// isProcessRunning returns an error if process is not running
+	isProcessRunning := func(pid int) error {
+		process, err := os.FindProcess(pid)
+		if err != nil {
+			return fmt.Errorf("failed to find process: %s", err)
+		}
+
+		err = process.Signal(syscall.Signal(0))
+		if err != nil {
+			return fmt.Errorf("failed to signal process: %s", err)
+		}
+
+		return nil
+	}
+
+	// task should be dead
+	r.Error(isProcessRunning(taskState.Pid))
+
+	// all children should eventually be killed by OS
+	testutil.WaitForResult(func() (bool, error) {
+		for _, cpid := range childPids {
+			err := isProcessRunning(cpid)
+			if err == nil {
+				return false, fmt.Errorf("child process %d is still running", cpid)
+			}
+			if !strings.Contains(err.Error(), "failed to signal process") {
+				return false, fmt.Errorf("unexpected error: %v", err)
+			}
+		}
+		return true, nil
+	}, func(err error) {
+		r.NoError(err)
+	})
+}
+
+func TestExecDriver_Stats(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	dctx, dcancel := context.WithCancel(context.Background())
+	defer dcancel()
+
+	d := NewExecDriver(dctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "test",
+		Resources: testResources,
+	}
+
+	tc := &TaskConfig{
+		Command: "/bin/sleep",
+		Args:    []string{"5"},
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	handle, _, err := harness.StartTask(task)
+	require.NoError(err)
+	require.NotNil(handle)
+
+	require.NoError(harness.WaitUntilStarted(task.ID, 1*time.Second))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	statsCh, err := harness.TaskStats(ctx, task.ID, time.Second*10)
+	require.NoError(err)
+	select {
+	case stats := <-statsCh:
+		require.NotEmpty(stats.ResourceUsage.MemoryStats.Measured)
+		require.NotZero(stats.Timestamp)
+		require.WithinDuration(time.Now(), time.Unix(0, stats.Timestamp), time.Second)
+	case <-time.After(time.Second):
+		require.Fail("timeout receiving from channel")
+	}
+
+	require.NoError(harness.DestroyTask(task.ID, true))
+}
+
+// TestExecDriver_Stats_MaxUsage ensures that a task's stats report its peak
+// memory usage after its usage has dropped.
+func TestExecDriver_Stats_MaxUsage(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	dctx, dcancel := context.WithCancel(context.Background())
+	defer dcancel()
+
+	d := NewExecDriver(dctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "test",
+		Resources: testResources,
+	}
+
+	// writing a file charges the task's cgroup for its page cache, which is
+	// released when the file is truncated
+	const spike = 64 * 1024 * 1024
+	tc := &TaskConfig{
+		Command: "/bin/sh",
+		Args: []string{"-c", fmt.Sprintf(
+			"head -c %d /dev/zero > /local/spike && : > /local/spike && touch /local/idle && sleep 30", spike)},
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	_, _, err := harness.StartTask(task)
+	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+
+	idle := filepath.Join(task.TaskDir().LocalDir, "idle")
+	require.Eventually(func() bool {
+		_, err := os.Stat(idle)
+		return err == nil
+	}, 10*time.Second, 50*time.Millisecond, "task did not finish its memory spike")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	statsCh, err := harness.TaskStats(ctx, task.ID, time.Second)
+	require.NoError(err)
+	select {
+	case stats := <-statsCh:
+		ms := stats.ResourceUsage.MemoryStats
+		if !helper.SliceStringContains(ms.Measured, "Max Usage") {
+			t.Skip("peak memory usage is not reported on this node")
+		}
+		require.GreaterOrEqual(ms.MaxUsage, uint64(spike))
+		require.Less(ms.Usage, uint64(spike))
+	case <-time.After(5 * time.Second):
+		require.Fail("timeout receiving from channel")
+	}
+}
+
+func TestExecDriver_Stats_FirstSampleMeasured(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	dctx, dcancel := context.WithCancel(context.Background())
+	defer dcancel()
+
+	d := NewExecDriver(dctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "test",
+		Resources: testResources,
+	}
+
+	tc := &TaskConfig{
+		Command: "/bin/sleep",
+		Args:    []string{"1"},
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	_, _, err := harness.StartTask(task)
+	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+
+	// ask for stats as soon as the task starts, before its cgroup is
+	// likely to have been charged for any memory
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	statsCh, err := harness.TaskStats(ctx, task.ID, 10*time.Second)
+	require.NoError(err)
+	select {
+	case stats := <-statsCh:
+		require.NotNil(stats)
+		ms := stats.ResourceUsage.MemoryStats
+		require.NotEmpty(ms.Measured)
+		require.True(ms.Usage > 0 || ms.RSS > 0, "first sample has no memory usage: %#v", ms)
+	case <-time.After(5 * time.Second):
+		require.Fail("timeout receiving from channel")
+	}
+}
+
+func TestExecDriver_Stats_SubSecondInterval(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	dctx, dcancel := context.WithCancel(context.Background())
+	defer dcancel()
+
+	d := NewExecDriver(dctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "test",
+		Resources: testResources,
+	}
+
+	tc := &TaskConfig{
+		Command: "/bin/sleep",
+		Args:    []string{"600"},
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	_, _, err := harness.StartTask(task)
+	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+	require.NoError(harness.WaitUntilStarted(task.ID, 1*time.Second))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	statsCh, err := harness.TaskStats(ctx, task.ID, 200*time.Millisecond)
+	require.NoError(err)
+
+	// wait for the first sample, then expect more within a second
+	select {
+	case <-statsCh:
+	case <-time.After(5 * time.Second):
+		require.Fail("timeout receiving from channel")
+	}
+	samples := 0
+	timeout := time.After(time.Second)
+	for samples < 3 {
+		select {
+		case <-statsCh:
+			samples++
+		case <-timeout:
+			require.Fail("too few samples", "got %d within a second", samples)
+		}
+	}
+}
+
+func TestExecDriver_Stats_MinInterval(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	dctx, dcancel := context.WithCancel(context.Background())
+	defer dcancel()
+
+	d := NewExecDriver(dctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "test",
+		Resources: testResources,
+	}
+
+	tc := &TaskConfig{
+		Command: "/bin/sleep",
+		Args:    []string{"600"},
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	_, _, err := harness.StartTask(task)
+	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+	require.NoError(harness.WaitUntilStarted(task.ID, 1*time.Second))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	statsCh, err := harness.TaskStats(ctx, task.ID, time.Millisecond)
+	require.NoError(err)
+
+	// samples are spaced at least the floor apart, not a millisecond
+	var last int64
+	for i := 0; i < 5; i++ {
+		select {
+		case stats := <-statsCh:
+			if last != 0 {
+				require.GreaterOrEqual(stats.Timestamp-last, int64(minStatsInterval/2))
+			}
+			last = stats.Timestamp
+		case <-time.After(5 * time.Second):
+			require.Fail("timeout receiving from channel")
+		}
+	}
+}
+
+func TestExecDriver_Stats_CancelledOnShutdown(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	dctx, dcancel := context.WithCancel(context.Background())
+	defer dcancel()
+
+	d := NewExecDriver(dctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "test",
+		Resources: testResources,
+	}
+
+	tc := &TaskConfig{
+		Command: "/bin/sleep",
+		Args:    []string{"600"},
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	_, _, err := harness.StartTask(task)
+	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+	require.NoError(harness.WaitUntilStarted(task.ID, 1*time.Second))
+
+	// open streams that are never cancelled by their callers
+	var streams []<-chan *drivers.TaskResourceUsage
+	for i := 0; i < 3; i++ {
+		statsCh, err := d.TaskStats(context.Background(), task.ID, 100*time.Millisecond)
+		require.NoError(err)
+		streams = append(streams, statsCh)
+	}
+	for _, statsCh := range streams {
+		select {
+		case <-statsCh:
+		case <-time.After(5 * time.Second):
+			require.Fail("timeout receiving from channel")
+		}
+	}
+
+	// shutting down the driver ends every stream
+	dcancel()
+	for _, statsCh := range streams {
+		timeout := time.After(5 * time.Second)
+	DRAIN:
+		for {
+			select {
+			case _, ok := <-statsCh:
+				if !ok {
+					break DRAIN
+				}
+			case <-timeout:
+				require.Fail("stats stream was not closed on driver shutdown")
+			}
+		}
+	}
+}
+
+func TestExecDriver_DisableCgroups(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	dctx, dcancel := context.WithCancel(context.Background())
+	defer dcancel()
+
+	d := NewExecDriver(dctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	var data []byte
+	require.NoError(basePlug.MsgPackEncode(&data, &Config{
+		DefaultModePID: executor.IsolationModePrivate,
+		DefaultModeIPC: executor.IsolationModePrivate,
+		DisableCgroups: true,
+	}))
+	require.NoError(harness.SetConfig(&basePlug.Config{PluginConfig: data}))
+
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "test",
+		Resources: testResources,
+	}
+	tc := &TaskConfig{
+		Command: "/bin/sleep",
+		Args:    []string{"600"},
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	_, _, err := harness.StartTask(task)
+	require.NoError(err)
+	require.NoError(harness.WaitUntilStarted(task.ID, 1*time.Second))
+
+	// the task isn't placed in a cgroup created by the executor
+	pid := taskPid(t, harness, task.ID)
+	cgroups, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/cgroup", pid))
+	require.NoError(err)
+	require.NotContains(string(cgroups), "/nomad/")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	statsCh, err := harness.TaskStats(ctx, task.ID, time.Second*10)
+	require.NoError(err)
+	select {
+	case stats := <-statsCh:
+		require.Empty(stats.ResourceUsage.MemoryStats.Measured)
+		require.Empty(stats.ResourceUsage.CpuStats.Measured)
+		require.NotZero(stats.Timestamp)
+	case <-time.After(time.Second):
+		require.Fail("timeout receiving from channel")
+	}
+
+	// the task is still killed without a cgroup to find its processes
+	require.NoError(harness.DestroyTask(task.ID, true))
+	require.Eventually(func() bool {
+		return syscall.Kill(pid, 0) != nil
+	}, 5*time.Second, 100*time.Millisecond, "task process still running")
+}
+
+// TestExecDriver_FallbackToHostIsolation asserts that tasks requesting private
+// IPC isolation are run in the host IPC namespace, with a task event, when
+// private isolation isn't available and the driver allows falling back.
+func TestExecDriver_FallbackToHostIsolation(t *testing.T) {
+	// not parallel: overrides namespaceSupported for the package
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	orig := namespaceSupported
+	namespaceSupported = func(ns string) bool { return ns != "ipc" }
+	defer func() { namespaceSupported = orig }()
+
+	dctx, dcancel := context.WithCancel(context.Background())
+	defer dcancel()
+
+	d := NewExecDriver(dctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	var data []byte
+	require.NoError(basePlug.MsgPackEncode(&data, &Config{
+		DefaultModePID:          executor.IsolationModePrivate,
+		DefaultModeIPC:          executor.IsolationModePrivate,
+		FallbackToHostIsolation: true,
+	}))
+	require.NoError(harness.SetConfig(&basePlug.Config{PluginConfig: data}))
+
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		AllocID:   uuid.Generate(),
+		Name:      "test",
+		Resources: testResources,
+	}
+	tc := &TaskConfig{
+		Command: "/bin/sleep",
+		Args:    []string{"600"},
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := harness.TaskEvents(ctx)
+	require.NoError(err)
+
+	_, _, err = harness.StartTask(task)
+	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+	require.NoError(harness.WaitUntilStarted(task.ID, 1*time.Second))
+
+	select {
+	case event := <-events:
+		require.Equal(task.ID, event.TaskID)
+		require.Equal(task.AllocID, event.AllocID)
+		require.Equal("Private IPC isolation is not available, running task with host isolation", event.Message)
+		require.Equal(executor.IsolationModeHost, event.Annotations["ipc_mode"])
+	case <-time.After(5 * time.Second):
+		require.Fail("timeout waiting for task event")
+	}
+
+	pid := taskPid(t, harness, task.ID)
+	taskNS, err := os.Readlink(fmt.Sprintf("/proc/%d/ns/ipc", pid))
+	require.NoError(err)
+	hostNS, err := os.Readlink("/proc/self/ns/ipc")
+	require.NoError(err)
+	require.Equal(hostNS, taskNS)
+}
+
+// TestExecDriver_PartialCgroupControllers asserts that the limits of the
+// available cgroup controllers are still applied, with a task event listing
+// the limits that aren't, when only some controllers are available.
+func TestExecDriver_PartialCgroupControllers(t *testing.T) {
+	// not parallel: overrides availableCgroupControllers for the package
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	orig := availableCgroupControllers
+	availableCgroupControllers = func() ([]string, error) {
+		return []string{"devices", "freezer", "memory", "pids"}, nil
+	}
+	defer func() { availableCgroupControllers = orig }()
+
+	dctx, dcancel := context.WithCancel(context.Background())
+	defer dcancel()
+
+	d := NewExecDriver(dctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		AllocID:   uuid.Generate(),
+		Name:      "test",
+		Resources: testResources,
+	}
+	tc := &TaskConfig{
+		Command: "/bin/sleep",
+		Args:    []string{"600"},
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := harness.TaskEvents(ctx)
+	require.NoError(err)
+
+	_, _, err = harness.StartTask(task)
+	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+	require.NoError(harness.WaitUntilStarted(task.ID, 1*time.Second))
+
+	select {
+	case event := <-events:
+		require.Equal(task.ID, event.TaskID)
+		require.Equal("Resource limits not applied because their cgroup controller is unavailable: cpu", event.Message)
+		require.Equal("cpu", event.Annotations["unapplied_limits"])
+	case <-time.After(5 * time.Second):
+		require.Fail("timeout waiting for task event")
+	}
+
+	// the memory limit is still applied
+	dir, v1 := taskMemoryCgroup(t, taskPid(t, harness, task.ID))
+	file := "memory.max"
+	if v1 {
+		file = "memory.limit_in_bytes"
+	}
+	limit, err := ioutil.ReadFile(filepath.Join(dir, file))
+	require.NoError(err)
+	require.Equal("134217728", strings.TrimSpace(string(limit)))
+}
+
+func TestExecDriver_Start_Wait_AllocDir(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "sleep",
+		Resources: testResources,
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	exp := []byte{'w', 'i', 'n'}
+	file := "output.txt"
+	tc := &TaskConfig{
+		Command: "/bin/bash",
+		Args: []string{
+			"-c",
+			fmt.Sprintf(`sleep 1; echo -n %s > /alloc/%s`, string(exp), file),
+		},
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	handle, _, err := harness.StartTask(task)
+	require.NoError(err)
+	require.NotNil(handle)
+
+	// Task should terminate quickly
+	waitCh, err := harness.WaitTask(context.Background(), task.ID)
+	require.NoError(err)
+	select {
+	case res := <-waitCh:
+		require.True(res.Successful(), "task should have exited successfully: %v", res)
+	case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
+		require.Fail("timeout waiting for task")
+	}
+
+	// Check that data was written to the shared alloc directory.
+	outputFile := filepath.Join(task.TaskDir().SharedAllocDir, file)
+	act, err := ioutil.ReadFile(outputFile)
+	require.NoError(err)
+	require.Exactly(exp, act)
+
+	require.NoError(harness.DestroyTask(task.ID, true))
+}
+
+func TestExecDriver_PropagateTimezone(t *testing.T) {
+	// not parallel as the test overrides the host localtime path
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	zoneinfo := "/usr/share/zoneinfo/Asia/Tokyo"
+	if _, err := os.Stat(zoneinfo); err != nil {
+		t.Skipf("timezone data not available: %v", err)
+	}
+	orig := hostLocaltimePath
+	hostLocaltimePath = zoneinfo
+	defer func() { hostLocaltimePath = orig }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "tz",
+		Resources: testResources,
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	// use the printf builtin as the test chroot does not contain date
+	tc := &TaskConfig{
+		Command:           "/bin/bash",
+		Args:              []string{"-c", `printf '%(%Z)T' -1 > /alloc/tz.txt`},
+		PropagateTimezone: true,
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	_, _, err := harness.StartTask(task)
+	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+
+	waitCh, err := harness.WaitTask(context.Background(), task.ID)
+	require.NoError(err)
+	select {
+	case res := <-waitCh:
+		require.True(res.Successful(), "task should have exited successfully: %v", res)
+	case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
+		require.Fail("timeout waiting for task")
+	}
+
+	act, err := ioutil.ReadFile(filepath.Join(task.TaskDir().SharedAllocDir, "tz.txt"))
+	require.NoError(err)
+	require.Equal("JST", string(act))
+}
+
+func TestExecDriver_DefaultUser(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	u, err := user.Lookup("daemon")
+	if err != nil {
+		t.Skipf("daemon user not available: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	var data []byte
+	require.NoError(basePlug.MsgPackEncode(&data, &Config{
+		DefaultModePID: executor.IsolationModePrivate,
+		DefaultModeIPC: executor.IsolationModePrivate,
+		DefaultUser:    "daemon",
+	}))
+	require.NoError(harness.SetConfig(&basePlug.Config{PluginConfig: data}))
+
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "sleep",
+		Resources: testResources,
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	tc := &TaskConfig{
+		Command: "/bin/sleep",
+		Args:    []string{"100"},
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	_, _, err = harness.StartTask(task)
+	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+
+	status, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/status", taskPid(t, harness, task.ID)))
+	require.NoError(err)
+	require.Contains(string(status), fmt.Sprintf("Uid:\t%[1]s\t%[1]s\t%[1]s\t%[1]s\n", u.Uid))
+}
+
+func TestExecDriver_EnvFile(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "env",
+		Env:       map[string]string{"FOO": "from-task", "KEEP": "kept"},
+		Resources: testResources,
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	envFile := filepath.Join(task.TaskDir().SecretsDir, "app.env")
+	require.NoError(ioutil.WriteFile(envFile, []byte("FOO=from-file\nBAR=bar\n"), 0644))
+
+	tc := &TaskConfig{
+		Command: "/bin/bash",
+		Args:    []string{"-c", `echo -n "$FOO $BAR $KEEP" > /alloc/env.txt`},
+		EnvFile: "secrets/app.env",
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	_, _, err := harness.StartTask(task)
+	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+
+	waitCh, err := harness.WaitTask(context.Background(), task.ID)
+	require.NoError(err)
+	select {
+	case res := <-waitCh:
+		require.True(res.Successful(), "task should have exited successfully: %v", res)
+	case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
+		require.Fail("timeout waiting for task")
+	}
+
+	act, err := ioutil.ReadFile(filepath.Join(task.TaskDir().SharedAllocDir, "env.txt"))
+	require.NoError(err)
+	require.Equal("from-file bar kept", string(act))
+}
+
+func TestExecDriver_readEnvFile(t *testing.T) {
+	ci.Parallel(t)
+
+	taskDir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(taskDir, "local"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(taskDir, "local", "ok.env"), []byte("A=1\n# comment\nB=\"two words\"\n"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(taskDir, "local", "bad.env"), []byte("not a pair\n"), 0644))
+
+	vars, err := readEnvFile(taskDir, "local/ok.env")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"A": "1", "B": "two words"}, vars)
+
+	_, err = readEnvFile(taskDir, "local/bad.env")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `failed to parse env_file "local/bad.env"`)
+
+	_, err = readEnvFile(taskDir, "../escape.env")
+	require.EqualError(t, err, `env_file "../escape.env" escapes the task directory`)
+
+	_, err = readEnvFile(taskDir, "local/missing.env")
+	require.Error(t, err)
+}
+
+func TestExecDriver_StartTask_RedactsSecrets(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	var data []byte
+	require.NoError(basePlug.MsgPackEncode(&data, &Config{
+		DefaultModePID: executor.IsolationModePrivate,
+		DefaultModeIPC: executor.IsolationModePrivate,
+		SecretPatterns: []string{`password=\S+`},
+	}))
+	require.NoError(harness.SetConfig(&basePlug.Config{PluginConfig: data}))
+
+	vaultToken := uuid.Generate()
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "secret",
+		Env:       map[string]string{"VAULT_TOKEN": vaultToken},
+		Resources: testResources,
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	// the command does not exist, so the error includes it
+	tc := &TaskConfig{
+		Command: "/bin/missing-" + vaultToken + "-password=hunter2",
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	_, _, err := harness.StartTask(task)
+	require.Error(err)
+	require.Contains(err.Error(), "/bin/missing-<redacted>-<redacted>")
+	require.NotContains(err.Error(), vaultToken)
+	require.NotContains(err.Error(), "hunter2")
+}
+
+func TestExecDriver_MountNamespaceOnly(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "mountns",
+		Resources: testResources,
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	// without the chroot the task reaches the alloc dir through the host path
+	allocDir := filepath.Dir(task.TaskDir().SharedAllocDir)
+	require.NoError(os.Chmod(allocDir, 0755))
+
+	// a file outside of the task directory is only visible without the chroot
+	hostFile := filepath.Join(allocDir, "host.txt")
+	require.NoError(ioutil.WriteFile(hostFile, []byte("from the host"), 0644))
+
+	tc := &TaskConfig{
+		Command:            "/bin/sh",
+		Args:               []string{"-c", fmt.Sprintf("cat %s > ${NOMAD_ALLOC_DIR}/out && sleep 600", hostFile)},
+		MountNamespaceOnly: true,
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	_, _, err := harness.StartTask(task)
+	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+
+	outFile := filepath.Join(task.TaskDir().SharedAllocDir, "out")
+	testutil.WaitForResult(func() (bool, error) {
+		out, err := ioutil.ReadFile(outFile)
+		if err != nil {
+			return false, err
+		}
+		return string(out) == "from the host", fmt.Errorf("unexpected output: %q", out)
+	}, func(err error) {
+		require.NoError(err)
+	})
+
+	// the task still runs in its own mount namespace
+	pid := taskPid(t, harness, task.ID)
+	taskNS, err := os.Readlink(fmt.Sprintf("/proc/%d/ns/mnt", pid))
+	require.NoError(err)
+	hostNS, err := os.Readlink("/proc/self/ns/mnt")
+	require.NoError(err)
+	require.NotEqual(hostNS, taskNS)
+}
+
+func TestExecDriver_User(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "sleep",
+		User:      "alice",
+		Resources: testResources,
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	tc := &TaskConfig{
+		Command: "/bin/sleep",
+		Args:    []string{"100"},
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	handle, _, err := harness.StartTask(task)
+	require.Error(err)
+	require.Nil(handle)
+
+	msg := "user alice"
+	if !strings.Contains(err.Error(), msg) {
+		t.Fatalf("Expecting '%v' in '%v'", msg, err)
+	}
+}
+
+func TestExecDriver_Groups(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	tmpDir := t.TempDir()
+	task := &drivers.TaskConfig{
+		ID:         uuid.Generate(),
+		Name:       "test",
+		Resources:  testResources,
+		StdoutPath: filepath.Join(tmpDir, "task-stdout"),
+		StderrPath: filepath.Join(tmpDir, "task-stderr"),
+	}
+	require.NoError(ioutil.WriteFile(task.StdoutPath, []byte{}, 0644))
+	require.NoError(ioutil.WriteFile(task.StderrPath, []byte{}, 0644))
+
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	// groups must exist on the host
+	tc := &TaskConfig{
+		Command: "/usr/bin/id",
+		Args:    []string{"-G"},
+		Groups:  []string{"daemon", "not-a-group"},
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+	_, _, err := harness.StartTask(task)
+	require.Error(err)
+	require.Contains(err.Error(), `group "not-a-group" does not exist`)
+
+	daemon, err := user.LookupGroup("daemon")
+	require.NoError(err)
+	nogroup, err := user.LookupGroup("nogroup")
+	require.NoError(err)
+
+	tc.Groups = []string{"daemon", "nogroup"}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+	_, _, err = harness.StartTask(task)
+	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+
+	waitCh, err := harness.WaitTask(context.Background(), task.ID)
+	require.NoError(err)
+	select {
+	case res := <-waitCh:
+		require.True(res.Successful(), "task failed: %v", res)
+	case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
+		require.Fail("timeout waiting for task to exit")
+	}
+
+	stdout, err := ioutil.ReadFile(task.StdoutPath)
+	require.NoError(err)
+	gids := strings.Fields(string(stdout))
+	require.Contains(gids, daemon.Gid)
+	require.Contains(gids, nogroup.Gid)
+}
+
+func TestExecDriver_WorkDir(t *testing.T) {
+	ci.Parallel(t)
+	ctestutils.ExecCompatible(t)
+
+	for _, tc := range []struct {
+		workDir string
+		exp     string
+	}{
+		{workDir: "", exp: "/local"},
+		{workDir: "/alloc", exp: "/alloc"},
+	} {
+		t.Run(tc.exp, func(t *testing.T) {
+			require := require.New(t)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			d := NewExecDriver(ctx, testlog.HCLogger(t))
+			harness := dtestutil.NewDriverHarness(t, d)
+			tmpDir := t.TempDir()
+			task := &drivers.TaskConfig{
+				ID:         uuid.Generate(),
+				Name:       "test",
+				Resources:  testResources,
+				StdoutPath: filepath.Join(tmpDir, "task-stdout"),
+				StderrPath: filepath.Join(tmpDir, "task-stderr"),
+			}
+			require.NoError(ioutil.WriteFile(task.StdoutPath, []byte{}, 0644))
+			require.NoError(ioutil.WriteFile(task.StderrPath, []byte{}, 0644))
+
+			cleanup := harness.MkAllocDir(task, false)
+			defer cleanup()
+
+			taskConfig := &TaskConfig{
+				Command: "/bin/sh",
+				Args:    []string{"-c", "pwd"},
+				WorkDir: tc.workDir,
+			}
+			require.NoError(task.EncodeConcreteDriverConfig(&taskConfig))
+
+			_, _, err := harness.StartTask(task)
+			require.NoError(err)
+			defer harness.DestroyTask(task.ID, true)
+
+			waitCh, err := harness.WaitTask(context.Background(), task.ID)
+			require.NoError(err)
+			select {
+			case res := <-waitCh:
+				require.True(res.Successful(), "task failed: %v", res)
+			case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
+				require.Fail("timeout waiting for task to exit")
+			}
+
+			stdout, err := ioutil.ReadFile(task.StdoutPath)
+			require.NoError(err)
+			require.Equal(tc.exp+"\n", string(stdout))
+		})
+	}
+}
+
+func TestExecDriver_taskWorkDir(t *testing.T) {
+	ci.Parallel(t)
+
+	allocDir := t.TempDir()
+	mountSource := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(allocDir, "web", "local"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(mountSource, "data"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(allocDir, "web", "file"), nil, 0644))
+
+	cfg := &drivers.TaskConfig{
+		Name:     "web",
+		AllocDir: allocDir,
+		Mounts: []*drivers.MountConfig{
+			{TaskPath: "/srv", HostPath: mountSource},
+		},
+	}
+
+	for _, tc := range []struct {
+		name   string
+		tc     *TaskConfig
+		exp    string
+		expErr string
+	}{
+		{name: "default", tc: &TaskConfig{}, exp: "/local"},
+		{name: "default without chroot", tc: &TaskConfig{MountNamespaceOnly: true}, exp: filepath.Join(allocDir, "web", "local")},
+		{name: "task dir", tc: &TaskConfig{WorkDir: "/local/"}, exp: "/local"},
+		{name: "under mount", tc: &TaskConfig{WorkDir: "/srv/data"}, exp: "/srv/data"},
+		{name: "without chroot", tc: &TaskConfig{WorkDir: mountSource, MountNamespaceOnly: true}, exp: mountSource},
+		{name: "missing", tc: &TaskConfig{WorkDir: "/missing"}, expErr: `work_dir "/missing" does not exist in the task`},
+		{name: "missing under mount", tc: &TaskConfig{WorkDir: "/srv/missing"}, expErr: `work_dir "/srv/missing" does not exist in the task`},
+		{name: "file", tc: &TaskConfig{WorkDir: "/file"}, expErr: `work_dir "/file" is not a directory`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir, err := taskWorkDir(cfg, tc.tc)
+			if tc.expErr != "" {
+				require.EqualError(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.exp, dir)
+		})
+	}
+}
+
+func TestExecDriver_ChownTaskDir(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "test",
+		User:      "nobody",
+		Resources: testResources,
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	// leave the task's local dir writable only by root, along with a file
+	// already rendered into it
+	localDir := task.TaskDir().LocalDir
+	require.NoError(os.Chown(localDir, 0, 0))
+	require.NoError(os.Chmod(localDir, 0755))
+	require.NoError(ioutil.WriteFile(filepath.Join(localDir, "rendered"), []byte("x"), 0644))
+
+	tc := &TaskConfig{
+		Command:      "/bin/sh",
+		Args:         []string{"-c", `echo hello > "${NOMAD_TASK_DIR}/out" && echo more >> "${NOMAD_TASK_DIR}/rendered"`},
+		ChownTaskDir: true,
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	_, _, err := harness.StartTask(task)
+	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+
+	waitCh, err := harness.WaitTask(context.Background(), task.ID)
+	require.NoError(err)
+	select {
+	case res := <-waitCh:
+		require.True(res.Successful(), "task failed: %v", res)
+	case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
+		require.Fail("timeout waiting for task to exit")
+	}
+
+	out, err := ioutil.ReadFile(filepath.Join(localDir, "out"))
+	require.NoError(err)
+	require.Equal("hello\n", string(out))
+
+	nobody, err := user.Lookup("nobody")
+	require.NoError(err)
+	fi, err := os.Stat(filepath.Join(localDir, "rendered"))
+	require.NoError(err)
+	require.Equal(nobody.Uid, strconv.Itoa(int(fi.Sys().(*syscall.Stat_t).Uid)))
+}
+
+// TestExecDriver_HandlerExec ensures the exec driver's handle properly
+// executes commands inside the container.
+func TestExecDriver_HandlerExec(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "sleep",
+		Resources: testResources,
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	tc := &TaskConfig{
+		Command: "/bin/sleep",
+		Args:    []string{"9000"},
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	handle, _, err := harness.StartTask(task)
+	require.NoError(err)
+	require.NotNil(handle)
+
+	// Exec a command that should work and dump the environment
+	res, err := harness.ExecTask(task.ID, []string{"/bin/cat", "/proc/self/environ"}, time.Second)
+	require.NoError(err)
+	require.True(res.ExitResult.Successful())
+
+	// Assert exec'd commands are run in a task-like environment
+	scriptEnv := make(map[string]string)
+	for _, line := range strings.Split(string(res.Stdout), "\x00") {
+		if line == "" {
+			continue
+		}
+		parts := strings.SplitN(string(line), "=", 2)
+		if len(parts) != 2 {
+			t.Fatalf("Invalid env var: %q", line)
+		}
+		scriptEnv[parts[0]] = parts[1]
+	}
+	require.Equal("/secrets", scriptEnv["NOMAD_SECRETS_DIR"])
+	require.Equal("/alloc", scriptEnv["NOMAD_ALLOC_DIR"])
+	require.Equal("/local", scriptEnv["NOMAD_TASK_DIR"])
+	require.Equal(task.Name, scriptEnv["NOMAD_TASK_NAME"])
+
+	// Assert cgroup membership
+	res, err = harness.ExecTask(task.ID, []string{"/bin/cat", "/proc/self/cgroup"}, time.Second)
+	require.NoError(err)
+	require.True(res.ExitResult.Successful())
+	found := false
+	for _, line := range strings.Split(string(res.Stdout), "\n") {
+		// Every cgroup entry should be /nomad/$ALLOC_ID
+		if line == "" {
+			continue
+		}
+		// Skip rdma subsystem; rdma was added in most recent kernels and libcontainer/docker
+		// don't isolate it by default.
+		if strings.Contains(line, ":rdma:") || strings.Contains(line, "::") {
+			continue
+		}
+		if !strings.Contains(line, ":/nomad/") {
+			t.Errorf("Not a member of the alloc's cgroup: expected=...:/nomad/... -- found=%q", line)
+			continue
+		}
+		found = true
+	}
+	require.True(found, "exec'd command isn't in the task's cgroup")
+
+	// Exec a command that should fail
+	res, err = harness.ExecTask(task.ID, []string{"/usr/bin/stat", "lkjhdsaflkjshowaisxmcvnlia"}, time.Second)
+	require.NoError(err)
+	require.False(res.ExitResult.Successful())
+	if expected := "No such file or directory"; !bytes.Contains(res.Stdout, []byte(expected)) {
+		t.Fatalf("expected output to contain %q but found: %q", expected, res.Stdout)
+	}
+
+	require.NoError(harness.DestroyTask(task.ID, true))
+}
+
+func TestExecDriver_ExecTaskWithInput(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "sleep",
+		Resources: testResources,
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	tc := &TaskConfig{
+		Command: "/bin/sleep",
+		Args:    []string{"9000"},
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	_, _, err := harness.StartTask(task)
+	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+
+	// Pipe input through cat and expect it echoed back once stdin closes
+	input := "hello from stdin\nsecond line\n"
+	res, err := d.(*Driver).ExecTaskWithInput(task.ID, []string{"/bin/cat"}, strings.NewReader(input), 5*time.Second)
+	require.NoError(err)
+	require.True(res.ExitResult.Successful())
+	require.Equal(input, string(res.Stdout))
+
+	// Unknown tasks are rejected
+	_, err = d.(*Driver).ExecTaskWithInput(uuid.Generate(), []string{"/bin/cat"}, strings.NewReader(input), 5*time.Second)
+	require.ErrorIs(err, drivers.ErrTaskNotFound)
+}
+
+func TestExecDriver_ExecTask_DefaultTimeout(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	var data []byte
+	require.NoError(basePlug.MsgPackEncode(&data, &Config{
+		DefaultModePID:     executor.IsolationModePrivate,
+		DefaultModeIPC:     executor.IsolationModePrivate,
+		DefaultExecTimeout: "1s",
+	}))
+	require.NoError(harness.SetConfig(&basePlug.Config{PluginConfig: data}))
+
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "sleep",
+		Resources: testResources,
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	tc := &TaskConfig{
+		Command: "/bin/sleep",
+		Args:    []string{"9000"},
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	_, _, err := harness.StartTask(task)
+	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+
+	// A zero timeout runs the command for the default_exec_timeout
+	start := time.Now()
+	_, err = harness.ExecTask(task.ID, []string{"/bin/sleep", "30"}, 0)
+	elapsed := time.Since(start)
+	require.Error(err)
+	require.Contains(err.Error(), "context deadline exceeded")
+	require.GreaterOrEqual(elapsed, time.Second)
+	require.Less(elapsed, 10*time.Second)
+
+	// Quick commands still complete normally
+	res, err := harness.ExecTask(task.ID, []string{"/bin/sh", "-c", "echo hello"}, 0)
+	require.NoError(err)
+	require.True(res.ExitResult.Successful())
+	require.Equal("hello\n", string(res.Stdout))
+}
+
+func TestExecDriver_Hostname(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "sleep",
+		Resources: testResources,
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	tc := &TaskConfig{
+		Command:  "/bin/sleep",
+		Args:     []string{"9000"},
+		Hostname: "web-1.example.com",
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	_, _, err := harness.StartTask(task)
+	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+
+	// the test chroot has no hostname binary, so read the kernel's view
+	res, err := harness.ExecTask(task.ID, []string{"/bin/cat", "/proc/sys/kernel/hostname"}, time.Second)
+	require.NoError(err)
+	require.True(res.ExitResult.Successful(), string(res.Stdout))
+	require.Equal("web-1.example.com", strings.TrimSpace(string(res.Stdout)))
+
+	// the host's hostname is left alone
+	hostname, err := os.Hostname()
+	require.NoError(err)
+	require.NotEqual("web-1.example.com", hostname)
+}
+
+func TestExecDriver_utsMode(t *testing.T) {
+	ci.Parallel(t)
+
+	cfg := &drivers.TaskConfig{
+		Name: "cache",
+		Env:  map[string]string{"NOMAD_ALLOC_NAME": "example.cache[0]"},
+	}
+	for _, tc := range []struct {
+		name         string
+		driverConfig TaskConfig
+		mode         string
+		hostname     string
+	}{
+		{name: "default", mode: "host", hostname: ""},
+		{name: "host", driverConfig: TaskConfig{ModeUTS: "host"}, mode: "host", hostname: ""},
+		{name: "hostname", driverConfig: TaskConfig{Hostname: "web-1"}, mode: "private", hostname: "web-1"},
+		{name: "alloc name", driverConfig: TaskConfig{ModeUTS: "private"}, mode: "private", hostname: "example.cache-0"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mode, hostname := utsMode(cfg, &tc.driverConfig)
+			require.Equal(t, tc.mode, mode)
+			require.Equal(t, tc.hostname, hostname)
+		})
+	}
+
+	// falls back to the task name without an allocation name
+	_, hostname := utsMode(&drivers.TaskConfig{Name: "cache"}, &TaskConfig{ModeUTS: "private"})
+	require.Equal(t, "cache", hostname)
+}
+
+func TestExecDriver_SeccompProfile(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	profile := []byte(`{
+  "defaultAction": "SCMP_ACT_ALLOW",
+  "syscalls": [{"names": ["unshare"], "action": "SCMP_ACT_ERRNO"}]
+}`)
+	if err := executor.ValidateSeccompProfile(profile); err != nil {
+		t.Skipf("seccomp profiles are not usable: %v", err)
+	}
+	unshare, err := ioutil.ReadFile("/usr/bin/unshare")
+	if err != nil {
+		t.Skipf("test requires unshare: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "test",
+		Resources: testResources,
+	}
+	cleanup := harness.MkAllocDir(task, true)
+	defer cleanup()
+
+	localDir := task.TaskDir().LocalDir
+	require.NoError(ioutil.WriteFile(filepath.Join(localDir, "seccomp.json"), profile, 0644))
+	require.NoError(ioutil.WriteFile(filepath.Join(localDir, "unshare"), unshare, 0755))
+
+	tc := &TaskConfig{
+		Command:        "/bin/sh",
+		Args:           []string{"-c", "/local/unshare -U /bin/sh -c 'echo unshared'"},
+		SeccompProfile: "local/seccomp.json",
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	handle, _, err := harness.StartTask(task)
+	require.NoError(err)
+
+	ch, err := harness.WaitTask(context.Background(), handle.Config.ID)
+	require.NoError(err)
+	result := <-ch
+	require.NoError(harness.DestroyTask(task.ID, true))
+	require.False(result.Successful())
+
+	stdout, err := ioutil.ReadFile(filepath.Join(task.TaskDir().LogDir, "test.stdout.0"))
+	require.NoError(err)
+	require.NotContains(string(stdout), "unshared")
+
+	stderr, err := ioutil.ReadFile(filepath.Join(task.TaskDir().LogDir, "test.stderr.0"))
+	require.NoError(err)
+	require.Contains(string(stderr), "Operation not permitted")
+}
+
+func TestExecDriver_seccompProfile(t *testing.T) {
+	ci.Parallel(t)
+
+	taskDir := t.TempDir()
+	hostDir := t.TempDir()
+	valid := `{"defaultAction": "SCMP_ACT_ALLOW", "syscalls": [{"names": ["unshare"], "action": "SCMP_ACT_ERRNO"}]}`
+	require.NoError(t, ioutil.WriteFile(filepath.Join(taskDir, "invalid.json"), []byte(`{"defaultAction": `), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(taskDir, "bad-action.json"), []byte(`{"defaultAction": "SCMP_ACT_MAYBE"}`), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(hostDir, "default.json"), []byte(valid), 0644))
+
+	d := NewExecDriver(context.Background(), testlog.HCLogger(t)).(*Driver)
+
+	// no profile at all
+	profile, err := d.seccompProfile(taskDir, &TaskConfig{})
+	require.NoError(t, err)
+	require.Nil(t, profile)
+
+	for _, tc := range []struct {
+		name string
+		path string
+		err  string
+	}{
+		{name: "missing", path: "missing.json", err: "failed to read seccomp_profile"},
+		{name: "escapes", path: "../default.json", err: `seccomp_profile "../default.json" escapes the task directory`},
+		{name: "invalid json", path: "invalid.json", err: "failed to parse seccomp profile"},
+		{name: "invalid action", path: "bad-action.json", err: "invalid seccomp profile"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := d.seccompProfile(taskDir, &TaskConfig{SeccompProfile: tc.path})
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+
+	// the task's profile takes the place of the driver's default
+	d.config.DefaultSeccompProfile = filepath.Join(hostDir, "default.json")
+	_, err = d.seccompProfile(taskDir, &TaskConfig{SeccompProfile: "invalid.json"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to parse seccomp profile")
+
+	profile, err = d.seccompProfile(taskDir, &TaskConfig{})
+	if err != nil {
+		require.Contains(t, err.Error(), "seccomp is not supported")
+	} else {
+		require.Equal(t, valid, string(profile))
+	}
+}
+
+func TestExecDriver_Rlimits(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "sleep",
+		Resources: testResources,
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	tc := &TaskConfig{
+		Command: "/bin/sleep",
+		Args:    []string{"9000"},
+		Rlimits: map[string]Rlimit{
+			"nofile": {Soft: 1024, Hard: 2048},
+		},
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	_, _, err := harness.StartTask(task)
+	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+
+	res, err := harness.ExecTask(task.ID, []string{"/bin/sh", "-c", "ulimit -n; ulimit -Hn"}, time.Second)
+	require.NoError(err)
+	require.True(res.ExitResult.Successful(), string(res.Stdout))
+	require.Equal("1024\n2048\n", string(res.Stdout))
+}
+
+func TestExecDriver_RlimitNofile(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "sleep",
+		Resources: testResources,
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	tc := &TaskConfig{
+		Command: "/bin/sleep",
+		Args:    []string{"9000"},
+		Rlimits: map[string]Rlimit{
+			"nofile": {Soft: 1024, Hard: 2048},
+		},
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	_, _, err := harness.StartTask(task)
+	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+
+	// the task can't raise the hard limit, for itself or its children
+	res, err := harness.ExecTask(task.ID, []string{"/bin/sh", "-c", `ulimit -Hn 4096 2>/dev/null || echo denied; sh -c "ulimit -Hn"`}, time.Second)
+	require.NoError(err)
+	require.Equal("denied\n2048\n", string(res.Stdout))
+}
+
+// TestExecDriver_RlimitNofile_SysResource ensures that a task whose nofile
+// rlimit can't be enforced is rejected before its executor is launched.
+func TestExecDriver_RlimitNofile_SysResource(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var created int32
+	d := NewExecDriver(ctx, testlog.HCLogger(t)).(*Driver)
+	d.createExecutor = func(hclog.Logger, *basePlug.ClientDriverConfig, *executor.ExecutorConfig) (executor.Executor, *plugin.Client, error) {
+		atomic.AddInt32(&created, 1)
+		return nil, nil, fmt.Errorf("executor should not have been created")
+	}
+	d.config.AllowCaps = append(capabilities.NomadDefaults().Slice(false), "sys_resource")
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "sleep",
+		Resources: testResources,
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	tc := &TaskConfig{
+		Command: "/bin/sleep",
+		Args:    []string{"9000"},
+		CapAdd:  []string{"sys_resource"},
+		Rlimits: map[string]Rlimit{
+			"nofile": {Soft: 1024, Hard: 2048},
+		},
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	_, _, err := d.StartTask(task)
+	require.EqualError(err, `failed driver config validation: rlimit "nofile" can't be enforced for tasks with the sys_resource capability`)
+	require.Zero(atomic.LoadInt32(&created))
+}
+
+func TestExecDriver_validateNofileRlimit(t *testing.T) {
+	ci.Parallel(t)
+
+	nrOpen := filepath.Join(t.TempDir(), "nr_open")
+	require.NoError(t, ioutil.WriteFile(nrOpen, []byte("4096\n"), 0644))
+
+	nofile := func(hard uint64) map[string]Rlimit {
+		return map[string]Rlimit{"nofile": {Soft: hard, Hard: hard}}
+	}
+	caps := capabilities.NomadDefaults().Slice(true)
+
+	require.NoError(t, validateNofileRlimit(nil, caps, nrOpen))
+	require.NoError(t, validateNofileRlimit(map[string]Rlimit{"nproc": {Soft: 8192, Hard: 8192}}, caps, nrOpen))
+	require.NoError(t, validateNofileRlimit(nofile(4096), caps, nrOpen))
+	require.EqualError(t, validateNofileRlimit(nofile(8192), caps, nrOpen),
+		`rlimit "nofile" hard limit 8192 exceeds this node's fs.nr_open of 4096`)
+	require.EqualError(t, validateNofileRlimit(nofile(1024), append(caps, "CAP_SYS_RESOURCE"), nrOpen),
+		`rlimit "nofile" can't be enforced for tasks with the sys_resource capability`)
+}
+
+func TestExecDriver_DevicesAndMounts(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	tmpDir, err := ioutil.TempDir("", "exec_binds_mounts")
+	require.NoError(err)
+	defer os.RemoveAll(tmpDir)
+
+	err = ioutil.WriteFile(filepath.Join(tmpDir, "testfile"), []byte("from-host"), 600)
+	require.NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:         uuid.Generate(),
+		Name:       "test",
+		User:       "root", // need permission to read mounts paths
+		Resources:  testResources,
+		StdoutPath: filepath.Join(tmpDir, "task-stdout"),
+		StderrPath: filepath.Join(tmpDir, "task-stderr"),
+		Devices: []*drivers.DeviceConfig{
+			{
+				TaskPath:    "/dev/inserted-random",
+				HostPath:    "/dev/random",
+				Permissions: "rw",
+			},
+		},
+		Mounts: []*drivers.MountConfig{
+			{
+				TaskPath: "/tmp/task-path-rw",
+				HostPath: tmpDir,
+				Readonly: false,
+			},
+			{
+				TaskPath: "/tmp/task-path-ro",
+				HostPath: tmpDir,
+				Readonly: true,
+			},
+		},
+	}
+
+	require.NoError(ioutil.WriteFile(task.StdoutPath, []byte{}, 660))
+	require.NoError(ioutil.WriteFile(task.StderrPath, []byte{}, 660))
+
+	tc := &TaskConfig{
+		Command: "/bin/bash",
+		Args: []string{"-c", `
+export LANG=en.UTF-8
+echo "mounted device /inserted-random: $(stat -c '%t:%T' /dev/inserted-random)"
+echo "reading from ro path: $(cat /tmp/task-path-ro/testfile)"
+echo "reading from rw path: $(cat /tmp/task-path-rw/testfile)"
+touch /tmp/task-path-rw/testfile && echo 'overwriting file in rw succeeded'
+touch /tmp/task-path-rw/testfile-from-rw && echo from-exec >  /tmp/task-path-rw/testfile-from-rw && echo 'writing new file in rw succeeded'
+touch /tmp/task-path-ro/testfile && echo 'overwriting file in ro succeeded'
+touch /tmp/task-path-ro/testfile-from-ro && echo from-exec >  /tmp/task-path-ro/testfile-from-ro && echo 'writing new file in ro succeeded'
+exit 0
+`},
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	handle, _, err := harness.StartTask(task)
+	require.NoError(err)
+
+	ch, err := harness.WaitTask(context.Background(), handle.Config.ID)
+	require.NoError(err)
+	result := <-ch
+	require.NoError(harness.DestroyTask(task.ID, true))
+
+	stdout, err := ioutil.ReadFile(task.StdoutPath)
+	require.NoError(err)
+	require.Equal(`mounted device /inserted-random: 1:8
+reading from ro path: from-host
+reading from rw path: from-host
+overwriting file in rw succeeded
+writing new file in rw succeeded`, strings.TrimSpace(string(stdout)))
+
+	stderr, err := ioutil.ReadFile(task.StderrPath)
+	require.NoError(err)
+	require.Equal(`touch: cannot touch '/tmp/task-path-ro/testfile': Read-only file system
+touch: cannot touch '/tmp/task-path-ro/testfile-from-ro': Read-only file system`, strings.TrimSpace(string(stderr)))
+
+	// testing exit code last so we can inspect output first
+	require.Zero(result.ExitCode)
+
+	fromRWContent, err := ioutil.ReadFile(filepath.Join(tmpDir, "testfile-from-rw"))
+	require.NoError(err)
+	require.Equal("from-exec", strings.TrimSpace(string(fromRWContent)))
+}
+
+func TestExecDriver_DevicePermissions(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	for _, path := range []string{"/dev/loop0", "/dev/loop1", "/dev/loop2"} {
+		if _, err := os.Stat(path); err != nil {
+			t.Skipf("test requires loop devices: %v", err)
+		}
+	}
+
+	tmpDir := t.TempDir()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	var data []byte
+	require.NoError(basePlug.MsgPackEncode(&data, &Config{
+		DefaultModePID:     executor.IsolationModePrivate,
+		DefaultModeIPC:     executor.IsolationModePrivate,
+		AllowedDeviceGlobs: []string{"/dev/loop[01]"},
+	}))
+	require.NoError(harness.SetConfig(&basePlug.Config{PluginConfig: data}))
+
+	task := &drivers.TaskConfig{
+		ID:         uuid.Generate(),
+		Name:       "test",
+		User:       "root", // need permission to open devices
+		Resources:  testResources,
+		StdoutPath: filepath.Join(tmpDir, "task-stdout"),
+		StderrPath: filepath.Join(tmpDir, "task-stderr"),
+		Devices: []*drivers.DeviceConfig{
+			{
+				TaskPath:    "/dev/inserted-loop",
+				HostPath:    "/dev/loop2",
+				Permissions: "r",
+			},
+		},
+	}
+
+	require.NoError(ioutil.WriteFile(task.StdoutPath, []byte{}, 660))
+	require.NoError(ioutil.WriteFile(task.StderrPath, []byte{}, 660))
+
+	tc := &TaskConfig{
+		Command: "/bin/bash",
+		Args: []string{"-c", `
+: < /dev/loop0 && echo 'reading glob-allowed device succeeded'
+: > /dev/loop1 && echo 'writing glob-allowed device succeeded'
+: < /dev/inserted-loop && echo 'reading read-only device succeeded'
+: > /dev/inserted-loop && echo 'writing read-only device succeeded'
+: < /dev/loop2 && echo 'reading unlisted device succeeded'
+exit 0
+`},
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	handle, _, err := harness.StartTask(task)
+	require.NoError(err)
+
+	ch, err := harness.WaitTask(context.Background(), handle.Config.ID)
+	require.NoError(err)
+	result := <-ch
+	require.NoError(harness.DestroyTask(task.ID, true))
+
+	stdout, err := ioutil.ReadFile(task.StdoutPath)
+	require.NoError(err)
+	require.Equal(`reading glob-allowed device succeeded
+writing glob-allowed device succeeded
+reading read-only device succeeded`, strings.TrimSpace(string(stdout)))
+
+	stderr, err := ioutil.ReadFile(task.StderrPath)
+	require.NoError(err)
+	require.Equal(`/bin/bash: line 5: /dev/inserted-loop: Operation not permitted
+/bin/bash: line 6: /dev/loop2: No such file or directory`, strings.TrimSpace(string(stderr)))
+
+	require.Zero(result.ExitCode)
+}
+
+func TestExecDriver_taskDevices(t *testing.T) {
+	ci.Parallel(t)
+
+	devices := []*drivers.DeviceConfig{
+		{TaskPath: "/dev/inserted-null", HostPath: "/dev/null", Permissions: "rw"},
+		{TaskPath: "/dev/zero", HostPath: "/dev/zero", Permissions: "r"},
+	}
+
+	// globs only match devices, and don't replace requested ones
+	result, err := taskDevices(devices, []string{"/dev/nul?", "/dev/zer?", "/etc/host*"})
+	require.NoError(t, err)
+	require.Equal(t, []*drivers.DeviceConfig{
+		devices[0],
+		devices[1],
+		{TaskPath: "/dev/null", HostPath: "/dev/null", Permissions: "rwm"},
+	}, result)
+	require.Len(t, devices, 2)
+
+	invalid := []*drivers.DeviceConfig{
+		{TaskPath: "/dev/null", HostPath: "/dev/null", Permissions: "rwx"},
+	}
+	_, err = taskDevices(invalid, nil)
+	require.EqualError(t, err, `device "/dev/null" has invalid permissions "rwx": must only contain r, w and m`)
+}
+
+func TestExecDriver_Tmpfs(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	tmpDir := t.TempDir()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:         uuid.Generate(),
+		Name:       "test",
+		Resources:  testResources,
+		StdoutPath: filepath.Join(tmpDir, "task-stdout"),
+		StderrPath: filepath.Join(tmpDir, "task-stderr"),
+	}
+
+	require.NoError(ioutil.WriteFile(task.StdoutPath, []byte{}, 660))
+	require.NoError(ioutil.WriteFile(task.StderrPath, []byte{}, 660))
+
+	tc := &TaskConfig{
+		Command: "/bin/bash",
+		Args: []string{"-c", `
+echo from-task > /scratch/testfile && echo "reading from tmpfs: $(cat /scratch/testfile)"
+while read -r _ path type opts _; do
+  [ "$path" = /scratch ] && echo "$type $opts"
+done < /proc/mounts
+exit 0
+`},
+		Tmpfs: []TmpfsMount{{Path: "/scratch", SizeMB: 8, Mode: "1777"}},
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	handle, _, err := harness.StartTask(task)
+	require.NoError(err)
+
+	ch, err := harness.WaitTask(context.Background(), handle.Config.ID)
+	require.NoError(err)
+	result := <-ch
+	require.NoError(harness.DestroyTask(task.ID, true))
+
+	stdout, err := ioutil.ReadFile(task.StdoutPath)
+	require.NoError(err)
+	lines := strings.Split(strings.TrimSpace(string(stdout)), "\n")
+	require.Len(lines, 2, string(stdout))
+	require.Equal("reading from tmpfs: from-task", lines[0])
+	require.Regexp(`^tmpfs .*size=8192k`, lines[1])
+	require.Zero(result.ExitCode)
+
+	// the file was written to memory, not the task directory on the host
+	_, err = os.Stat(filepath.Join(task.TaskDir().Dir, "scratch", "testfile"))
+	require.True(os.IsNotExist(err), "file written to tmpfs is visible on the host: %v", err)
+}
+
+func TestExecDriver_Tmpfs_MemoryLimit(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	d := NewExecDriver(context.Background(), testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "test",
+		Resources: testResources,
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&TaskConfig{
+		Command: "/bin/true",
+		Tmpfs:   []TmpfsMount{{Path: "/scratch", SizeMB: 256, Mode: "1777"}},
+	}))
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	_, _, err := harness.StartTask(task)
+	require.Error(err)
+	require.Contains(err.Error(), `tmpfs at "/scratch" of 256 MB exceeds the task's memory limit of 128 MB`)
+}
+
+func TestExecDriver_DevicesAndMounts_TaskPathCollision(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	// the devices and mounts of TestExecDriver_DevicesAndMounts
+	devices := []*drivers.DeviceConfig{
+		{TaskPath: "/dev/inserted-random", HostPath: "/dev/random", Permissions: "rw"},
+	}
+	mounts := []*drivers.MountConfig{
+		{TaskPath: "/tmp/task-path-rw", HostPath: "/tmp/host", Readonly: false},
+		{TaskPath: "/tmp/task-path-ro", HostPath: "/tmp/host", Readonly: true},
+	}
+	require.NoError(validateTaskPaths(mounts, devices, nil))
+
+	collidingDevices := append(devices, &drivers.DeviceConfig{
+		TaskPath: "/tmp/task-path-ro/", HostPath: "/dev/null", Permissions: "r",
+	})
+	require.EqualError(validateTaskPaths(mounts, collidingDevices, nil),
+		`task path "/tmp/task-path-ro" is used by both a mount and a device`)
+
+	collidingMounts := append(mounts, &drivers.MountConfig{
+		TaskPath: "/tmp/task-path-rw", HostPath: "/tmp/other",
+	})
+	require.EqualError(validateTaskPaths(collidingMounts, devices, nil),
+		`task path "/tmp/task-path-rw" is used by more than one mount`)
+
+	collidingTmpfs := []TmpfsMount{{Path: "/tmp/task-path-rw", SizeMB: 1, Mode: "1777"}}
+	require.EqualError(validateTaskPaths(mounts, devices, collidingTmpfs),
+		`task path "/tmp/task-path-rw" is used by both a mount and a tmpfs mount`)
+
+	// the driver refuses to start tasks with colliding paths
+	d := NewExecDriver(context.Background(), testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "test",
+		Resources: testResources,
+		Mounts:    mounts,
+		Devices:   collidingDevices,
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&TaskConfig{Command: "/bin/true"}))
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	_, _, err := harness.StartTask(task)
+	require.Error(err)
+	require.Contains(err.Error(), `task path "/tmp/task-path-ro" is used by both a mount and a device`)
+}
+
+func TestExecDriver_DevicesAndMounts_MountSources(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	// mounts like those of TestExecDriver_DevicesAndMounts
+	hostPath := t.TempDir()
+	missingPath := filepath.Join(hostPath, "missing")
+	mounts := []*drivers.MountConfig{
+		{TaskPath: "/tmp/task-path-rw", HostPath: hostPath, Readonly: false},
+		{TaskPath: "/tmp/task-path-ro", HostPath: hostPath, Readonly: true},
+	}
+	require.NoError(validateMountSources(mounts))
+
+	missingMounts := append(mounts, &drivers.MountConfig{
+		TaskPath: "/tmp/task-path-missing", HostPath: missingPath,
+	})
+	expErr := fmt.Sprintf("mount source %q for task path %q does not exist", missingPath, "/tmp/task-path-missing")
+	require.EqualError(validateMountSources(missingMounts), expErr)
+
+	// the driver refuses to start tasks with missing mount sources when
+	// configured to check them
+	d := NewExecDriver(context.Background(), testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	var data []byte
+	require.NoError(basePlug.MsgPackEncode(&data, &Config{
+		DefaultModePID:       executor.IsolationModePrivate,
+		DefaultModeIPC:       executor.IsolationModePrivate,
+		ValidateMountSources: true,
+	}))
+	require.NoError(harness.SetConfig(&basePlug.Config{PluginConfig: data}))
+
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "test",
+		Resources: testResources,
+		Mounts:    missingMounts,
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&TaskConfig{Command: "/bin/true"}))
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	_, _, err := harness.StartTask(task)
+	require.Error(err)
+	require.Contains(err.Error(), expErr)
+}
+
+func TestConfig_ParseAllHCL(t *testing.T) {
+	ci.Parallel(t)
+
+	cfgStr := `
+config {
+  command = "/bin/bash"
+  args = ["-c", "echo hello"]
+}`
+
+	expected := &TaskConfig{
+		Command: "/bin/bash",
+		Args:    []string{"-c", "echo hello"},
+		Tmpfs:   []TmpfsMount{},
+		Rlimits: map[string]Rlimit{},
+	}
+
+	var tc *TaskConfig
+	hclutils.NewConfigParser(taskConfigSpec).ParseHCL(t, cfgStr, &tc)
+
+	require.EqualValues(t, expected, tc)
+}
+
+func TestConfig_ParseRlimits(t *testing.T) {
+	ci.Parallel(t)
+
+	cfgStr := `
+config {
+  command = "/bin/my-app"
+
+  rlimits "nofile" {
+    soft = 1024
+    hard = 4096
+  }
+
+  rlimits "core" {
+    soft = 0
+    hard = 0
+  }
+}`
+
+	var tc *TaskConfig
+	hclutils.NewConfigParser(taskConfigSpec).ParseHCL(t, cfgStr, &tc)
+
+	require.Equal(t, map[string]Rlimit{
+		"nofile": {Soft: 1024, Hard: 4096},
+		"core":   {Soft: 0, Hard: 0},
+	}, tc.Rlimits)
+}
+
+func TestExecDriver_NoPivotRoot(t *testing.T) {
+	ci.Parallel(t)
+	r := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	config := &Config{
+		NoPivotRoot:    true,
+		DefaultModePID: executor.IsolationModePrivate,
+		DefaultModeIPC: executor.IsolationModePrivate,
+	}
+
+	var data []byte
+	r.NoError(basePlug.MsgPackEncode(&data, config))
+	bconfig := &basePlug.Config{PluginConfig: data}
+	r.NoError(harness.SetConfig(bconfig))
+
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "sleep",
+		Resources: testResources,
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	tc := &TaskConfig{
+		Command: "/bin/sleep",
+		Args:    []string{"100"},
+	}
+	r.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	handle, _, err := harness.StartTask(task)
+	r.NoError(err)
+	r.NotNil(handle)
+}
+
+func TestExecDriver_NiceAndIOClass(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "sleep",
+		Resources: testResources,
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	tc := &TaskConfig{
+		Command:    "/bin/sleep",
+		Args:       []string{"100"},
+		Nice:       10,
+		IOClass:    executor.IOClassBestEffort,
+		IOPriority: 6,
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	handle, _, err := harness.StartTask(task)
+	require.NoError(err)
+	require.NotNil(handle)
+	defer harness.DestroyTask(task.ID, true)
+
+	pid := taskPid(t, harness, task.ID)
+
+	// nice is the 19th field of /proc/<pid>/stat; skip past the command name
+	// as it may contain spaces
+	stat, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	require.NoError(err)
+	fields := strings.Fields(string(stat[bytes.LastIndexByte(stat, ')')+1:]))
+	require.Equal("10", fields[16])
+
+	// ioprio is (class << 13) | priority, with best-effort being class 2
+	ioprio, _, errno := syscall.Syscall(syscall.SYS_IOPRIO_GET, 1, uintptr(pid), 0)
+	require.Zero(errno)
+	require.Equal(uintptr(2<<13|6), ioprio)
+}
+
+func TestExecDriver_CpusetCpus(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "sleep",
+		Resources: testResources,
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	tc := &TaskConfig{
+		Command:    "/bin/sleep",
+		Args:       []string{"100"},
+		CpusetCpus: "0",
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	handle, _, err := harness.StartTask(task)
+	require.NoError(err)
+	require.NotNil(handle)
+	defer harness.DestroyTask(task.ID, true)
+
+	status, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/status", taskPid(t, harness, task.ID)))
+	require.NoError(err)
+	require.Contains(string(status), "Cpus_allowed_list:\t0\n")
+}
+
+// TestExecDriver_CpusetCpus_ReservedCores asserts that a task may pin itself
+// to the cores reserved for its allocation, as seen by the task itself.
+func TestExecDriver_CpusetCpus_ReservedCores(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	tmpDir := t.TempDir()
+	task := &drivers.TaskConfig{
+		ID:         uuid.Generate(),
+		Name:       "test",
+		StdoutPath: filepath.Join(tmpDir, "task-stdout"),
+		StderrPath: filepath.Join(tmpDir, "task-stderr"),
+		Resources: &drivers.Resources{
+			NomadResources: &structs.AllocatedTaskResources{
+				Memory: testResources.NomadResources.Memory,
+				Cpu: structs.AllocatedCpuResources{
+					CpuShares:     100,
+					ReservedCores: []uint16{0},
+				},
+			},
+			LinuxResources: testResources.LinuxResources,
+		},
+	}
+	require.NoError(ioutil.WriteFile(task.StdoutPath, []byte{}, 0644))
+	require.NoError(ioutil.WriteFile(task.StderrPath, []byte{}, 0644))
+
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	tc := &TaskConfig{
+		Command:    "/bin/cat",
+		Args:       []string{"/proc/self/status"},
+		CpusetCpus: "0",
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	handle, _, err := harness.StartTask(task)
+	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+
+	ch, err := harness.WaitTask(context.Background(), handle.Config.ID)
+	require.NoError(err)
+	result := <-ch
+	require.True(result.Successful(), "task failed: %#v", result)
+
+	stdout, err := ioutil.ReadFile(task.StdoutPath)
+	require.NoError(err)
+	require.Contains(string(stdout), "Cpus_allowed_list:\t0\n")
+}
+
+func TestExecDriver_CpusetMems(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "sleep",
+		Resources: testResources,
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	tc := &TaskConfig{
+		Command:    "/bin/sleep",
+		Args:       []string{"100"},
+		CpusetMems: "0",
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	handle, _, err := harness.StartTask(task)
+	require.NoError(err)
+	require.NotNil(handle)
+	defer harness.DestroyTask(task.ID, true)
+
+	status, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/status", taskPid(t, harness, task.ID)))
+	require.NoError(err)
+	require.Contains(string(status), "Mems_allowed_list:\t0\n")
+}
+
+func TestExecDriver_InspectMountsAndDevices(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "sleep",
+		Resources: testResources,
+		Devices: []*drivers.DeviceConfig{
+			{
+				TaskPath:    "/dev/inserted-null",
+				HostPath:    "/dev/null",
+				Permissions: "rw",
+			},
+		},
+		Mounts: []*drivers.MountConfig{
+			{
+				TaskPath: "/tmp/task-path-ro",
+				HostPath: t.TempDir(),
+				Readonly: true,
+			},
+		},
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	tc := &TaskConfig{
+		Command: "/bin/sleep",
+		Args:    []string{"100"},
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	_, _, err := harness.StartTask(task)
+	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+
+	status, err := harness.InspectTask(task.ID)
+	require.NoError(err)
+	require.Contains(strings.Split(status.DriverAttributes["mounts"], ","), "/tmp/task-path-ro:ro")
+	require.Equal("/dev/inserted-null:rw", status.DriverAttributes["devices"])
+}
+
+func TestExecDriver_validateCpusetAvailable(t *testing.T) {
+	ci.Parallel(t)
+
+	online := filepath.Join(t.TempDir(), "online")
+	require.NoError(t, ioutil.WriteFile(online, []byte("0-3\n"), 0644))
+
+	require.NoError(t, validateCpusetAvailable("cpuset_cpus", "", online))
+	require.NoError(t, validateCpusetAvailable("cpuset_cpus", "0-1,3", online))
+	require.EqualError(t, validateCpusetAvailable("cpuset_cpus", "2-5", online),
+		"cpuset_cpus configured with values not available on this node: 4-5")
+}
+
+func TestExecDriver_validateCpusetReserved(t *testing.T) {
+	ci.Parallel(t)
+
+	taskConfig := func(reserved ...uint16) *drivers.TaskConfig {
+		return &drivers.TaskConfig{
+			Resources: &drivers.Resources{
+				NomadResources: &structs.AllocatedTaskResources{
+					Cpu: structs.AllocatedCpuResources{ReservedCores: reserved},
+				},
+			},
+		}
+	}
+
+	// without reserved cores the task may use any of the node's cores
+	require.NoError(t, validateCpusetReserved("0-3", taskConfig()))
+	require.NoError(t, validateCpusetReserved("0-3", &drivers.TaskConfig{}))
+
+	require.NoError(t, validateCpusetReserved("", taskConfig(2, 3)))
+	require.NoError(t, validateCpusetReserved("2", taskConfig(2, 3)))
+	require.NoError(t, validateCpusetReserved("2-3", taskConfig(2, 3)))
+	require.EqualError(t, validateCpusetReserved("1-3,5", taskConfig(2, 3)),
+		"cpuset_cpus configured with cores not reserved for the allocation: 1,5")
+}
+
+func TestExecDriver_validateApparmorProfile(t *testing.T) {
+	ci.Parallel(t)
+
+	profiles := filepath.Join(t.TempDir(), "profiles")
+	require.NoError(t, ioutil.WriteFile(profiles, []byte("nomad-task (enforce)\n/usr/bin/man (complain)\n"), 0644))
+
+	require.NoError(t, validateApparmorProfile("", profiles))
+	require.NoError(t, validateApparmorProfile("nomad-task", profiles))
+	require.NoError(t, validateApparmorProfile("/usr/bin/man", profiles))
+	require.EqualError(t, validateApparmorProfile("nomad", profiles),
+		`apparmor_profile "nomad" is not loaded on this node`)
+	require.EqualError(t, validateApparmorProfile("nomad-task", filepath.Join(t.TempDir(), "missing")),
+		"apparmor_profile requires AppArmor, which is not enabled on this node")
+}
+
+func TestExecDriver_ApparmorProfile(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	if _, err := os.Stat(apparmorProfilesPath); err != nil {
+		t.Skip("AppArmor is not enabled")
+	}
+	parser, err := osexec.LookPath("apparmor_parser")
+	if err != nil {
+		t.Skip("apparmor_parser not found")
+	}
+
+	// load a profile which allows everything but writing files
+	name := "nomad-test-" + uuid.Generate()[:8]
+	profile := filepath.Join(t.TempDir(), name)
+	require.NoError(ioutil.WriteFile(profile, []byte(fmt.Sprintf(`profile %s flags=(attach_disconnected,mediate_deleted) {
+  file,
+  capability,
+  network,
+  signal,
+  deny /** w,
+}
+`, name)), 0644))
+	out, err := osexec.Command(parser, "-r", profile).CombinedOutput()
+	require.NoError(err, "failed to load profile: %s", out)
+	defer osexec.Command(parser, "-R", profile).Run()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "test",
+		Resources: testResources,
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	tc := &TaskConfig{
+		Command:         "/bin/touch",
+		Args:            []string{"/local/denied"},
+		ApparmorProfile: name,
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	_, _, err = harness.StartTask(task)
+	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+
+	waitCh, err := harness.WaitTask(context.Background(), task.ID)
+	require.NoError(err)
+	select {
+	case res := <-waitCh:
+		require.False(res.Successful(), "task was able to write a file")
+	case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
+		require.Fail("timeout waiting for task to exit")
+	}
+	_, err = os.Stat(filepath.Join(task.TaskDir().LocalDir, "denied"))
+	require.True(os.IsNotExist(err))
+}
+
+func TestExecDriver_SELinuxRelabel(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	if !selinuxEnabled() {
+		t.Skip("SELinux is not enabled")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	mountSource := t.TempDir()
+	require.NoError(ioutil.WriteFile(filepath.Join(mountSource, "data"), []byte("relabeled"), 0644))
+
+	tmpDir := t.TempDir()
+	task := &drivers.TaskConfig{
+		ID:         uuid.Generate(),
+		Name:       "test",
+		Resources:  testResources,
+		StdoutPath: filepath.Join(tmpDir, "task-stdout"),
+		StderrPath: filepath.Join(tmpDir, "task-stderr"),
+		Mounts: []*drivers.MountConfig{
+			{TaskPath: "/srv", HostPath: mountSource, Readonly: true},
+		},
+	}
+	require.NoError(ioutil.WriteFile(task.StdoutPath, []byte{}, 0644))
+	require.NoError(ioutil.WriteFile(task.StderrPath, []byte{}, 0644))
+
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	tc := &TaskConfig{
+		Command:        "/bin/cat",
+		Args:           []string{"/srv/data"},
+		SELinuxRelabel: true,
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	_, _, err := harness.StartTask(task)
+	require.NoError(err)
+	defer harness.DestroyTask(task.ID, true)
+
+	waitCh, err := harness.WaitTask(context.Background(), task.ID)
+	require.NoError(err)
+	select {
+	case res := <-waitCh:
+		require.True(res.Successful(), "task failed: %v", res)
+	case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
+		require.Fail("timeout waiting for task to exit")
+	}
+
+	stdout, err := ioutil.ReadFile(task.StdoutPath)
+	require.NoError(err)
+	require.Equal("relabeled", string(stdout))
+
+	label, err := selinux.FileLabel(filepath.Join(mountSource, "data"))
+	require.NoError(err)
+	require.Contains(label, ":container_file_t:")
+}
+
+func TestExecDriver_SELinuxRelabel_Disabled(t *testing.T) {
+	// not parallel: overrides selinuxEnabled for the package
+	require := require.New(t)
+
+	orig := selinuxEnabled
+	selinuxEnabled = func() bool { return false }
+	defer func() { selinuxEnabled = orig }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "test",
+		Resources: testResources,
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	tc := &TaskConfig{
+		Command:        "/bin/sleep",
+		Args:           []string{"600"},
+		SELinuxRelabel: true,
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	_, _, err := harness.StartTask(task)
+	require.Error(err)
+	require.Contains(err.Error(), "selinux_relabel requires SELinux, which is not enabled on this node")
+}
+
+func TestExecDriver_unavailableCgroupLimits(t *testing.T) {
+	ci.Parallel(t)
+
+	cfg := &drivers.TaskConfig{Resources: testResources}
+	driverConfig := &TaskConfig{
+		CpusetCpus:       "0",
+		MemorySwapMB:     256,
+		MemorySwappiness: helper.IntToPtr(10),
+	}
+
+	cases := []struct {
+		name        string
+		controllers []string
+		exp         []string
+	}{
+		{name: "unknown", controllers: nil, exp: nil},
+		{name: "all", controllers: []string{"cpu", "cpuset", "memory", "pids"}, exp: nil},
+		{name: "no memory", controllers: []string{"cpu", "cpuset", "pids"}, exp: []string{"memory", "memory_swap_mb", "memory_swappiness"}},
+		{name: "memory only", controllers: []string{"memory"}, exp: []string{"cpu", "cpuset_cpus", "pids_limit"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.exp, unavailableCgroupLimits(cfg, driverConfig, 100, tc.controllers))
+		})
+	}
+}
+
+// taskPid returns the host PID of the main process of the task.
+func taskPid(t *testing.T, harness *dtestutil.DriverHarness, taskID string) int {
+	status, err := harness.InspectTask(taskID)
+	require.NoError(t, err)
+	pid, err := strconv.Atoi(status.DriverAttributes["pid"])
+	require.NoError(t, err)
+	return pid
+}
+
+// taskMemoryCgroup returns the directory of the memory cgroup the process is
+// in, and whether it is a cgroups v1 hierarchy.
+func taskMemoryCgroup(t *testing.T, pid int) (string, bool) {
+	b, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/cgroup", pid))
+	require.NoError(t, err)
+
+	var unified string
+	for _, line := range strings.Split(string(b), "\n") {
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		switch parts[1] {
+		case "memory":
+			return filepath.Join("/sys/fs/cgroup/memory", parts[2]), true
+		case "":
+			unified = parts[2]
+		}
+	}
+	require.NotEmpty(t, unified, "process is not in a memory cgroup")
+	return filepath.Join("/sys/fs/cgroup", unified), false
+}
+
+func TestExecDriver_SystemReservedMemory(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t)).(*Driver)
+	d.config.SystemReservedMemoryMB = 400
+	d.availableMemory = func() (uint64, error) {
+		return 512 * 1024 * 1024, nil
+	}
+	harness := dtestutil.NewDriverHarness(t, d)
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "sleep",
+		Resources: testResources,
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	tc := &TaskConfig{
+		Command: "/bin/sleep",
+		Args:    []string{"100"},
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	// 512 MB available less the task's 128 MB cuts into the reservation
+	_, _, err := harness.StartTask(task)
+	require.Error(err)
+	require.Contains(err.Error(), "insufficient memory to start task: requires 128 MB but 512 MB is available and 400 MB is reserved for the system")
+
+	d.config.SystemReservedMemoryMB = 384
+	require.NoError(d.checkMemoryReservation(task))
+}
+
+// startCountingExecutor is an executor whose Launch records how many launches
+// are in flight at once.
+type startCountingExecutor struct {
+	executor.Executor
+
+	active, maxActive *int32
+	exitCh            chan struct{}
+}
+
+func (e *startCountingExecutor) Launch(*executor.ExecCommand) (*executor.ProcessState, error) {
+	n := atomic.AddInt32(e.active, 1)
+	defer atomic.AddInt32(e.active, -1)
+	for {
+		max := atomic.LoadInt32(e.maxActive)
+		if n <= max || atomic.CompareAndSwapInt32(e.maxActive, max, n) {
+			break
+		}
+	}
+	time.Sleep(200 * time.Millisecond)
+	return &executor.ProcessState{Pid: 1}, nil
+}
+
+func (e *startCountingExecutor) Wait(ctx context.Context) (*executor.ProcessState, error) {
+	select {
+	case <-e.exitCh:
+	case <-ctx.Done():
+	}
+	return &executor.ProcessState{}, nil
+}
+
+func (e *startCountingExecutor) Shutdown(string, time.Duration) error {
+	close(e.exitCh)
+	return nil
+}
+
+func TestExecDriver_MaxConcurrentStarts(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var active, maxActive int32
+	d := NewExecDriver(ctx, testlog.HCLogger(t)).(*Driver)
+	d.createExecutor = func(hclog.Logger, *basePlug.ClientDriverConfig, *executor.ExecutorConfig) (executor.Executor, *plugin.Client, error) {
+		exec := &startCountingExecutor{
+			active:    &active,
+			maxActive: &maxActive,
+			exitCh:    make(chan struct{}),
+		}
+		return exec, &plugin.Client{}, nil
+	}
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	var data []byte
+	require.NoError(basePlug.MsgPackEncode(&data, &Config{
+		DefaultModePID:      executor.IsolationModePrivate,
+		DefaultModeIPC:      executor.IsolationModePrivate,
+		MaxConcurrentStarts: 2,
+	}))
+	require.NoError(d.SetConfig(&basePlug.Config{PluginConfig: data}))
+
+	const numTasks = 6
+	tasks := make([]*drivers.TaskConfig, numTasks)
+	for i := range tasks {
+		task := &drivers.TaskConfig{
+			ID:        uuid.Generate(),
+			Name:      fmt.Sprintf("sleep-%d", i),
+			Resources: testResources,
+		}
+		cleanup := harness.MkAllocDir(task, false)
+		defer cleanup()
+
+		tc := &TaskConfig{
+			Command: "/bin/sleep",
+			Args:    []string{"100"},
+		}
+		require.NoError(task.EncodeConcreteDriverConfig(&tc))
+		tasks[i] = task
+	}
+
+	// start all tasks at once
+	var wg sync.WaitGroup
+	errCh := make(chan error, numTasks)
+	for _, task := range tasks {
+		wg.Add(1)
+		go func(task *drivers.TaskConfig) {
+			defer wg.Done()
+			_, _, err := d.StartTask(task)
+			errCh <- err
+		}(task)
+		defer d.DestroyTask(task.ID, true)
+	}
+	wg.Wait()
+	close(errCh)
+
+	for err := range errCh {
+		require.NoError(err)
+	}
+	require.Len(d.tasks.store, numTasks)
+	require.EqualValues(2, atomic.LoadInt32(&maxActive))
+}
+
+// busyExecutor is an executor whose task never stops using CPU.
+type busyExecutor struct {
+	executor.Executor
+
+	exitCh chan struct{}
+}
+
+func (e *busyExecutor) Launch(*executor.ExecCommand) (*executor.ProcessState, error) {
+	return &executor.ProcessState{Pid: 1}, nil
+}
+
+func (e *busyExecutor) Wait(ctx context.Context) (*executor.ProcessState, error) {
+	select {
+	case <-e.exitCh:
+	case <-ctx.Done():
+	}
+	return &executor.ProcessState{}, nil
+}
+
+func (e *busyExecutor) Shutdown(string, time.Duration) error {
+	close(e.exitCh)
+	return nil
+}
+
+func (e *busyExecutor) Stats(ctx context.Context, interval time.Duration) (<-chan *cstructs.TaskResourceUsage, error) {
+	ch := make(chan *cstructs.TaskResourceUsage)
+	go func() {
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			usage := &cstructs.TaskResourceUsage{
+				ResourceUsage: &cstructs.ResourceUsage{
+					CpuStats: &cstructs.CpuStats{Percent: 100},
+				},
+			}
+			select {
+			case ch <- usage:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// TestExecDriver_HealthyQuietPeriod_NeverQuiet ensures that starting a task
+// whose CPU usage never settles gives up waiting for it, and doesn't hold up
+// other tasks' starts in the meantime.
+func TestExecDriver_HealthyQuietPeriod_NeverQuiet(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t)).(*Driver)
+	d.createExecutor = func(hclog.Logger, *basePlug.ClientDriverConfig, *executor.ExecutorConfig) (executor.Executor, *plugin.Client, error) {
+		return &busyExecutor{exitCh: make(chan struct{})}, &plugin.Client{}, nil
+	}
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	var data []byte
+	require.NoError(basePlug.MsgPackEncode(&data, &Config{
+		DefaultModePID:      executor.IsolationModePrivate,
+		DefaultModeIPC:      executor.IsolationModePrivate,
+		MaxConcurrentStarts: 1,
+	}))
+	require.NoError(d.SetConfig(&basePlug.Config{PluginConfig: data}))
+
+	newTask := func(name string, tc *TaskConfig) *drivers.TaskConfig {
+		task := &drivers.TaskConfig{
+			ID:        uuid.Generate(),
+			Name:      name,
+			Resources: testResources,
+		}
+		require.NoError(task.EncodeConcreteDriverConfig(tc))
+		return task
+	}
+	busy := newTask("busy", &TaskConfig{
+		Command:             "/bin/sleep",
+		Args:                []string{"100"},
+		HealthyCpuThreshold: 10,
+		HealthyQuietPeriod:  "200ms",
+	})
+	other := newTask("other", &TaskConfig{
+		Command: "/bin/sleep",
+		Args:    []string{"100"},
+	})
+	for _, task := range []*drivers.TaskConfig{busy, other} {
+		cleanup := harness.MkAllocDir(task, false)
+		defer cleanup()
+	}
+
+	start := time.Now()
+	busyErrCh := make(chan error, 1)
+	go func() {
+		_, _, err := d.StartTask(busy)
+		busyErrCh <- err
+	}()
+	defer d.DestroyTask(busy.ID, true)
+
+	require.Eventually(func() bool {
+		_, ok := d.tasks.Get(busy.ID)
+		return ok
+	}, 5*time.Second, 10*time.Millisecond, "busy task was not launched")
+
+	// the busy task doesn't hold the only start slot while it settles
+	_, _, err := d.StartTask(other)
+	require.NoError(err)
+	defer d.DestroyTask(other.ID, true)
+	select {
+	case <-busyErrCh:
+		t.Fatal("busy task was started before waiting for it to settle")
+	default:
+	}
+
+	select {
+	case err := <-busyErrCh:
+		require.NoError(err)
+		require.GreaterOrEqual(time.Since(start), maxQuietPeriods*200*time.Millisecond)
+	case <-time.After(10 * time.Second):
+		t.Fatal("starting busy task never gave up waiting for it to settle")
 	}
+}
 
-	var tc *TaskConfig
-	hclutils.NewConfigParser(taskConfigSpec).ParseHCL(t, cfgStr, &tc)
+// slowLaunchExecutor is an executor whose Launch blocks until release is
+// closed, simulating isolation setup that hangs.
+type slowLaunchExecutor struct {
+	executor.Executor
 
-	require.EqualValues(t, expected, tc)
+	release  chan struct{}
+	shutdown chan struct{}
 }
 
-func TestExecDriver_NoPivotRoot(t *testing.T) {
+func (e *slowLaunchExecutor) Launch(*executor.ExecCommand) (*executor.ProcessState, error) {
+	<-e.release
+	return &executor.ProcessState{Pid: 1}, nil
+}
+
+func (e *slowLaunchExecutor) Shutdown(string, time.Duration) error {
+	close(e.shutdown)
+	return nil
+}
+
+func TestExecDriver_StartTimeout(t *testing.T) {
 	ci.Parallel(t)
-	r := require.New(t)
-	ctestutils.ExecCompatible(t)
+	require := require.New(t)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	exec := &slowLaunchExecutor{
+		release:  make(chan struct{}),
+		shutdown: make(chan struct{}),
+	}
+	d := NewExecDriver(ctx, testlog.HCLogger(t)).(*Driver)
+	d.createExecutor = func(hclog.Logger, *basePlug.ClientDriverConfig, *executor.ExecutorConfig) (executor.Executor, *plugin.Client, error) {
+		return exec, &plugin.Client{}, nil
+	}
 	harness := dtestutil.NewDriverHarness(t, d)
 
-	config := &Config{
-		NoPivotRoot:    true,
+	var data []byte
+	require.NoError(basePlug.MsgPackEncode(&data, &Config{
 		DefaultModePID: executor.IsolationModePrivate,
 		DefaultModeIPC: executor.IsolationModePrivate,
-	}
-
-	var data []byte
-	r.NoError(basePlug.MsgPackEncode(&data, config))
-	bconfig := &basePlug.Config{PluginConfig: data}
-	r.NoError(harness.SetConfig(bconfig))
+		StartTimeout:   "100ms",
+	}))
+	require.NoError(d.SetConfig(&basePlug.Config{PluginConfig: data}))
 
 	task := &drivers.TaskConfig{
 		ID:        uuid.Generate(),
@@ -752,20 +4355,108 @@ func TestExecDriver_NoPivotRoot(t *testing.T) {
 	}
 	cleanup := harness.MkAllocDir(task, false)
 	defer cleanup()
-
-	tc := &TaskConfig{
+	require.NoError(task.EncodeConcreteDriverConfig(&TaskConfig{
 		Command: "/bin/sleep",
 		Args:    []string{"100"},
+	}))
+
+	start := time.Now()
+	_, _, err := d.StartTask(task)
+	require.EqualError(err, "task did not start within the start_timeout of 100ms")
+	require.Less(time.Since(start), 5*time.Second)
+	_, ok := d.tasks.Get(task.ID)
+	require.False(ok, "timed out task should not be tracked")
+
+	// a launch that completes after the timeout is stopped rather than leaked
+	close(exec.release)
+	select {
+	case <-exec.shutdown:
+	case <-time.After(5 * time.Second):
+		require.Fail("task launched after the timeout was not shut down")
 	}
-	r.NoError(task.EncodeConcreteDriverConfig(&tc))
+}
 
-	handle, _, err := harness.StartTask(task)
-	r.NoError(err)
-	r.NotNil(handle)
+func TestExecDriver_AllowedUsers(t *testing.T) {
+	ci.Parallel(t)
+
+	for _, tc := range []struct {
+		name    string
+		allowed []string
+		user    string
+		expErr  string
+	}{
+		{name: "allowed", allowed: []string{"nobody", "alice"}, user: "alice"},
+		{name: "allowed default user", allowed: []string{"nobody"}, user: ""},
+		{name: "denied", allowed: []string{"nobody"}, user: "alice", expErr: "user alice is not in the allowed_users list"},
+		{name: "empty allowlist", allowed: nil, user: "alice"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var active, maxActive int32
+			d := NewExecDriver(ctx, testlog.HCLogger(t)).(*Driver)
+			d.createExecutor = func(hclog.Logger, *basePlug.ClientDriverConfig, *executor.ExecutorConfig) (executor.Executor, *plugin.Client, error) {
+				return &startCountingExecutor{
+					active:    &active,
+					maxActive: &maxActive,
+					exitCh:    make(chan struct{}),
+				}, &plugin.Client{}, nil
+			}
+			d.config.AllowedUsers = tc.allowed
+			harness := dtestutil.NewDriverHarness(t, d)
+
+			task := &drivers.TaskConfig{
+				ID:        uuid.Generate(),
+				Name:      "sleep",
+				User:      tc.user,
+				Resources: testResources,
+			}
+			cleanup := harness.MkAllocDir(task, false)
+			defer cleanup()
+
+			taskConfig := &TaskConfig{
+				Command: "/bin/sleep",
+				Args:    []string{"100"},
+			}
+			require.NoError(task.EncodeConcreteDriverConfig(&taskConfig))
+
+			_, _, err := d.StartTask(task)
+			if tc.expErr != "" {
+				require.Error(err)
+				require.Contains(err.Error(), tc.expErr)
+				require.Zero(atomic.LoadInt32(&maxActive), "executor should not have launched")
+				return
+			}
+			require.NoError(err)
+			require.NoError(d.DestroyTask(task.ID, true))
+		})
+	}
 }
 
 func TestDriver_Config_validate(t *testing.T) {
 	ci.Parallel(t)
+	t.Run("multiple problems", func(t *testing.T) {
+		err := (&Config{
+			DefaultModePID:         "private",
+			DefaultModeIPC:         "other",
+			AllowCaps:              []string{"chown", "not_a_cap"},
+			SystemReservedMemoryMB: -1,
+			StartTimeout:           "later",
+		}).validate()
+		require.Error(t, err)
+
+		var mErr *multierror.Error
+		require.True(t, errors.As(err, &mErr))
+		require.Len(t, mErr.Errors, 4)
+		require.EqualError(t, mErr.Errors[0], `default_ipc_mode must be "private" or "host", got "other"`)
+		require.EqualError(t, mErr.Errors[1], "allow_caps configured with capabilities not supported by system: not_a_cap")
+		require.EqualError(t, mErr.Errors[2], "system_reserved_memory_mb must not be negative, got -1")
+		require.EqualError(t, mErr.Errors[3], `start_timeout must be a positive duration, got "later"`)
+	})
+
 	t.Run("pid/ipc", func(t *testing.T) {
 		for _, tc := range []struct {
 			pidMode, ipcMode string
@@ -803,10 +4494,251 @@ func TestDriver_Config_validate(t *testing.T) {
 			}).validate())
 		}
 	})
+
+	t.Run("system_reserved_memory_mb", func(t *testing.T) {
+		for _, tc := range []struct {
+			mb  int
+			exp error
+		}{
+			{mb: 0, exp: nil},
+			{mb: 512, exp: nil},
+			{mb: -1, exp: errors.New("system_reserved_memory_mb must not be negative, got -1")},
+		} {
+			require.Equal(t, tc.exp, (&Config{
+				DefaultModePID:         "private",
+				DefaultModeIPC:         "private",
+				SystemReservedMemoryMB: tc.mb,
+			}).validate())
+		}
+	})
+
+	t.Run("default_user", func(t *testing.T) {
+		for _, tc := range []struct {
+			user string
+			exp  error
+		}{
+			{user: "", exp: nil},
+			{user: "root", exp: nil},
+			{user: "not-a-user", exp: errors.New(`default_user "not-a-user" not found on host: user: unknown user not-a-user`)},
+		} {
+			require.Equal(t, tc.exp, (&Config{
+				DefaultModePID: "private",
+				DefaultModeIPC: "private",
+				DefaultUser:    tc.user,
+			}).validate())
+		}
+	})
+
+	t.Run("secret_patterns", func(t *testing.T) {
+		for _, tc := range []struct {
+			patterns []string
+			exp      error
+		}{
+			{patterns: nil, exp: nil},
+			{patterns: []string{`token=\w+`}, exp: nil},
+			{patterns: []string{`(`}, exp: errors.New("secret_patterns contains invalid pattern \"(\": error parsing regexp: missing closing ): `(`")},
+		} {
+			require.Equal(t, tc.exp, (&Config{
+				DefaultModePID: "private",
+				DefaultModeIPC: "private",
+				SecretPatterns: tc.patterns,
+			}).validate())
+		}
+	})
+
+	t.Run("max_concurrent_starts", func(t *testing.T) {
+		for _, tc := range []struct {
+			max int
+			exp error
+		}{
+			{max: 0, exp: nil},
+			{max: 4, exp: nil},
+			{max: -1, exp: errors.New("max_concurrent_starts must not be negative, got -1")},
+		} {
+			require.Equal(t, tc.exp, (&Config{
+				DefaultModePID:      "private",
+				DefaultModeIPC:      "private",
+				MaxConcurrentStarts: tc.max,
+			}).validate())
+		}
+	})
+
+	t.Run("start_timeout", func(t *testing.T) {
+		for _, tc := range []struct {
+			timeout string
+			exp     error
+		}{
+			{timeout: "", exp: nil},
+			{timeout: "30s", exp: nil},
+			{timeout: "0s", exp: errors.New(`start_timeout must be a positive duration, got "0s"`)},
+			{timeout: "later", exp: errors.New(`start_timeout must be a positive duration, got "later"`)},
+		} {
+			require.Equal(t, tc.exp, (&Config{
+				DefaultModePID: "private",
+				DefaultModeIPC: "private",
+				StartTimeout:   tc.timeout,
+			}).validate())
+		}
+	})
+
+	t.Run("kill_signal_grace", func(t *testing.T) {
+		for _, tc := range []struct {
+			grace string
+			exp   error
+		}{
+			{grace: "", exp: nil},
+			{grace: "10s", exp: nil},
+			{grace: "0s", exp: errors.New(`kill_signal_grace must be a positive duration, got "0s"`)},
+			{grace: "soon", exp: errors.New(`kill_signal_grace must be a positive duration, got "soon"`)},
+		} {
+			require.Equal(t, tc.exp, (&Config{
+				DefaultModePID:  "private",
+				DefaultModeIPC:  "private",
+				KillSignalGrace: tc.grace,
+			}).validate())
+		}
+	})
+
+	t.Run("default_exec_timeout", func(t *testing.T) {
+		for _, tc := range []struct {
+			timeout string
+			exp     error
+		}{
+			{timeout: "", exp: nil},
+			{timeout: "30s", exp: nil},
+			{timeout: "-1s", exp: errors.New(`default_exec_timeout must be a positive duration, got "-1s"`)},
+			{timeout: "never", exp: errors.New(`default_exec_timeout must be a positive duration, got "never"`)},
+		} {
+			require.Equal(t, tc.exp, (&Config{
+				DefaultModePID:     "private",
+				DefaultModeIPC:     "private",
+				DefaultExecTimeout: tc.timeout,
+			}).validate())
+		}
+	})
+
+	t.Run("allowed_device_globs", func(t *testing.T) {
+		for _, tc := range []struct {
+			globs []string
+			exp   error
+		}{
+			{globs: nil, exp: nil},
+			{globs: []string{"/dev/nvidia*", "/dev/fuse"}, exp: nil},
+			{globs: []string{"dev/nvidia*"}, exp: errors.New(`allowed_device_globs must contain absolute paths, got "dev/nvidia*"`)},
+			{globs: []string{"/dev/nvidia["}, exp: errors.New(`allowed_device_globs contains invalid pattern "/dev/nvidia[": syntax error in pattern`)},
+		} {
+			require.Equal(t, tc.exp, (&Config{
+				DefaultModePID:     "private",
+				DefaultModeIPC:     "private",
+				AllowedDeviceGlobs: tc.globs,
+			}).validate())
+		}
+	})
+
+	t.Run("default_seccomp_profile", func(t *testing.T) {
+		require.NoError(t, (&Config{
+			DefaultModePID:        "private",
+			DefaultModeIPC:        "private",
+			DefaultSeccompProfile: "/etc/nomad/seccomp.json",
+		}).validate())
+		require.EqualError(t, (&Config{
+			DefaultModePID:        "private",
+			DefaultModeIPC:        "private",
+			DefaultSeccompProfile: "seccomp.json",
+		}).validate(), `default_seccomp_profile must be an absolute path, got "seccomp.json"`)
+	})
+
+	t.Run("fingerprint_commands", func(t *testing.T) {
+		for _, tc := range []struct {
+			commands map[string][]string
+			exp      error
+		}{
+			{commands: nil, exp: nil},
+			{commands: map[string][]string{"gpu": {"nvidia-smi", "--version"}}, exp: nil},
+			{commands: map[string][]string{"": {"true"}}, exp: errors.New("fingerprint_commands must not contain an empty name")},
+			{commands: map[string][]string{"gpu": {}}, exp: errors.New(`fingerprint_commands "gpu" must not be empty`)},
+			{commands: map[string][]string{"gpu": {""}}, exp: errors.New(`fingerprint_commands "gpu" must not be empty`)},
+		} {
+			require.Equal(t, tc.exp, (&Config{
+				DefaultModePID:      "private",
+				DefaultModeIPC:      "private",
+				FingerprintCommands: tc.commands,
+			}).validate())
+		}
+	})
+
+	t.Run("userns_id_offset", func(t *testing.T) {
+		for _, tc := range []struct {
+			offset int64
+			exp    error
+		}{
+			{offset: 0, exp: nil},
+			{offset: 100000, exp: nil},
+			{offset: 4294901760, exp: nil},
+			{offset: -1, exp: errors.New("userns_id_offset must be between 0 and 4294901760, got -1")},
+			{offset: 4294901761, exp: errors.New("userns_id_offset must be between 0 and 4294901760, got 4294901761")},
+		} {
+			require.Equal(t, tc.exp, (&Config{
+				DefaultModePID: "private",
+				DefaultModeIPC: "private",
+				UsernsIDOffset: tc.offset,
+			}).validate())
+		}
+	})
+
+	t.Run("default_oom_score_adj", func(t *testing.T) {
+		for _, tc := range []struct {
+			adj int
+			exp error
+		}{
+			{adj: 0, exp: nil},
+			{adj: -1000, exp: nil},
+			{adj: 1000, exp: nil},
+			{adj: -1001, exp: errors.New("default_oom_score_adj must be between -1000 and 1000, got -1001")},
+			{adj: 1001, exp: errors.New("default_oom_score_adj must be between -1000 and 1000, got 1001")},
+		} {
+			require.Equal(t, tc.exp, (&Config{
+				DefaultModePID:     "private",
+				DefaultModeIPC:     "private",
+				DefaultOOMScoreAdj: tc.adj,
+			}).validate())
+		}
+	})
+
+	t.Run("default_pids_limit", func(t *testing.T) {
+		require.NoError(t, (&Config{
+			DefaultModePID:   "private",
+			DefaultModeIPC:   "private",
+			DefaultPidsLimit: 100,
+		}).validate())
+		require.EqualError(t, (&Config{
+			DefaultModePID:   "private",
+			DefaultModeIPC:   "private",
+			DefaultPidsLimit: -1,
+		}).validate(), "default_pids_limit must not be negative, got -1")
+	})
 }
 
 func TestDriver_TaskConfig_validate(t *testing.T) {
 	ci.Parallel(t)
+	t.Run("multiple problems", func(t *testing.T) {
+		err := (&TaskConfig{
+			ModePID: "other",
+			ModeIPC: "host",
+			CapAdd:  []string{"not_a_cap"},
+			Nice:    20,
+		}).validate()
+		require.Error(t, err)
+
+		var mErr *multierror.Error
+		require.True(t, errors.As(err, &mErr))
+		require.Len(t, mErr.Errors, 3)
+		require.EqualError(t, mErr.Errors[0], `pid_mode must be "private" or "host", got "other"`)
+		require.EqualError(t, mErr.Errors[1], "cap_add configured with capabilities not supported by system: not_a_cap")
+		require.EqualError(t, mErr.Errors[2], "nice must be between -20 and 19, got 20")
+		require.Contains(t, err.Error(), "3 errors occurred")
+	})
+
 	t.Run("pid/ipc", func(t *testing.T) {
 		for _, tc := range []struct {
 			pidMode, ipcMode string
@@ -862,4 +4794,354 @@ func TestDriver_TaskConfig_validate(t *testing.T) {
 			}).validate())
 		}
 	})
+	t.Run("nice/io", func(t *testing.T) {
+		for _, tc := range []struct {
+			nice, ioPriority int
+			ioClass          string
+			exp              error
+		}{
+			{nice: 0, ioClass: "", ioPriority: 0, exp: nil},
+			{nice: -20, ioClass: "realtime", ioPriority: 0, exp: nil},
+			{nice: 19, ioClass: "best-effort", ioPriority: 7, exp: nil},
+			{nice: 5, ioClass: "idle", ioPriority: 0, exp: nil},
+			{nice: -21, exp: errors.New("nice must be between -20 and 19, got -21")},
+			{nice: 20, exp: errors.New("nice must be between -20 and 19, got 20")},
+			{ioClass: "other", exp: errors.New(`io_class must be "realtime", "best-effort" or "idle", got "other"`)},
+			{ioClass: "best-effort", ioPriority: 8, exp: errors.New("io_priority must be between 0 and 7, got 8")},
+			{ioClass: "idle", ioPriority: 2, exp: errors.New(`io_priority requires io_class to be "realtime" or "best-effort"`)},
+			{ioClass: "", ioPriority: 2, exp: errors.New(`io_priority requires io_class to be "realtime" or "best-effort"`)},
+		} {
+			require.Equal(t, tc.exp, (&TaskConfig{
+				Nice:       tc.nice,
+				IOClass:    tc.ioClass,
+				IOPriority: tc.ioPriority,
+			}).validate())
+		}
+	})
+	t.Run("cpuset_mems", func(t *testing.T) {
+		for _, tc := range []struct {
+			mems string
+			exp  error
+		}{
+			{mems: "", exp: nil},
+			{mems: "0-1", exp: nil},
+			{mems: "x", exp: errors.New(`cpuset_mems "x" is not a valid cpuset: strconv.Atoi: parsing "x": invalid syntax`)},
+		} {
+			require.Equal(t, tc.exp, (&TaskConfig{
+				CpusetMems: tc.mems,
+			}).validate())
+		}
+	})
+
+	t.Run("cpuset_cpus", func(t *testing.T) {
+		for _, tc := range []struct {
+			cpus string
+			exp  error
+		}{
+			{cpus: "", exp: nil},
+			{cpus: "0", exp: nil},
+			{cpus: "0-3,8", exp: nil},
+			{cpus: "0-a", exp: errors.New(`cpuset_cpus "0-a" is not a valid cpuset: strconv.Atoi: parsing "a": invalid syntax`)},
+		} {
+			require.Equal(t, tc.exp, (&TaskConfig{
+				CpusetCpus: tc.cpus,
+			}).validate())
+		}
+	})
+
+	t.Run("memory_swappiness", func(t *testing.T) {
+		for _, tc := range []struct {
+			swappiness *int
+			exp        error
+		}{
+			{swappiness: nil, exp: nil},
+			{swappiness: helper.IntToPtr(-1), exp: nil},
+			{swappiness: helper.IntToPtr(0), exp: nil},
+			{swappiness: helper.IntToPtr(100), exp: nil},
+			{swappiness: helper.IntToPtr(-2), exp: errors.New("memory_swappiness must be between 0 and 100, or -1 to inherit, got -2")},
+			{swappiness: helper.IntToPtr(101), exp: errors.New("memory_swappiness must be between 0 and 100, or -1 to inherit, got 101")},
+		} {
+			require.Equal(t, tc.exp, (&TaskConfig{
+				MemorySwappiness: tc.swappiness,
+			}).validate())
+		}
+	})
+
+	t.Run("memory_swap_mb", func(t *testing.T) {
+		require.NoError(t, (&TaskConfig{MemorySwapMB: 0}).validate())
+		require.NoError(t, (&TaskConfig{MemorySwapMB: 512}).validate())
+		require.EqualError(t, (&TaskConfig{MemorySwapMB: -1}).validate(),
+			"memory_swap_mb must not be negative, got -1")
+	})
+
+	t.Run("groups", func(t *testing.T) {
+		require.NoError(t, (&TaskConfig{Groups: []string{"daemon"}}).validate())
+		require.EqualError(t, (&TaskConfig{Groups: []string{"daemon", ""}}).validate(),
+			"groups must not contain an empty name")
+	})
+
+	t.Run("work_dir", func(t *testing.T) {
+		require.NoError(t, (&TaskConfig{WorkDir: "/alloc"}).validate())
+		require.EqualError(t, (&TaskConfig{WorkDir: "alloc"}).validate(),
+			`work_dir must be an absolute path, got "alloc"`)
+	})
+
+	t.Run("pids_limit", func(t *testing.T) {
+		require.NoError(t, (&TaskConfig{PidsLimit: 0}).validate())
+		require.NoError(t, (&TaskConfig{PidsLimit: 100}).validate())
+		require.EqualError(t, (&TaskConfig{PidsLimit: -1}).validate(),
+			"pids_limit must not be negative, got -1")
+	})
+
+	t.Run("tmpfs", func(t *testing.T) {
+		require.NoError(t, (&TaskConfig{Tmpfs: []TmpfsMount{{Path: "/scratch", SizeMB: 64, Mode: "0700"}}}).validate())
+		require.EqualError(t, (&TaskConfig{Tmpfs: []TmpfsMount{{Path: "scratch", SizeMB: 64, Mode: "1777"}}}).validate(),
+			`tmpfs path must be absolute, got "scratch"`)
+		require.EqualError(t, (&TaskConfig{Tmpfs: []TmpfsMount{{Path: "/scratch", Mode: "1777"}}}).validate(),
+			`tmpfs size_mb for "/scratch" must be positive, got 0`)
+		require.EqualError(t, (&TaskConfig{Tmpfs: []TmpfsMount{{Path: "/scratch", SizeMB: 64, Mode: "rwx"}}}).validate(),
+			`tmpfs mode for "/scratch" must be octal permissions such as "1777", got "rwx"`)
+	})
+
+	t.Run("stop_signal", func(t *testing.T) {
+		require.NoError(t, (&TaskConfig{StopSignal: "SIGQUIT"}).validate())
+		require.EqualError(t, (&TaskConfig{StopSignal: "SIGBOGUS"}).validate(),
+			`stop_signal "SIGBOGUS" is not a known signal`)
+	})
+
+	t.Run("userns_mode", func(t *testing.T) {
+		require.NoError(t, (&TaskConfig{ModeUserns: "private"}).validate())
+		require.NoError(t, (&TaskConfig{ModeUserns: "host", ModePID: "host"}).validate())
+		require.EqualError(t, (&TaskConfig{ModeUserns: "shared"}).validate(),
+			`userns_mode must be "private" or "host", got "shared"`)
+		require.EqualError(t, (&TaskConfig{ModeUserns: "private", ModePID: "host"}).validate(),
+			`userns_mode "private" cannot be used with pid_mode "host"`)
+	})
+
+	t.Run("hostname", func(t *testing.T) {
+		require.NoError(t, (&TaskConfig{Hostname: "web-1.example.com"}).validate())
+		require.NoError(t, (&TaskConfig{Hostname: "web-1", ModeUTS: "private"}).validate())
+		require.NoError(t, (&TaskConfig{ModeUTS: "host"}).validate())
+		require.EqualError(t, (&TaskConfig{ModeUTS: "shared"}).validate(),
+			`uts_mode must be "private" or "host", got "shared"`)
+		require.EqualError(t, (&TaskConfig{Hostname: "web-1", ModeUTS: "host"}).validate(),
+			`hostname cannot be set with uts_mode "host"`)
+		require.EqualError(t, (&TaskConfig{Hostname: "web_1"}).validate(),
+			`hostname must be a valid hostname of at most 64 characters, got "web_1"`)
+		require.EqualError(t, (&TaskConfig{Hostname: "-web"}).validate(),
+			`hostname must be a valid hostname of at most 64 characters, got "-web"`)
+		require.Error(t, (&TaskConfig{Hostname: strings.Repeat("a", 65)}).validate())
+	})
+
+	t.Run("rlimits", func(t *testing.T) {
+		require.NoError(t, (&TaskConfig{Rlimits: map[string]Rlimit{
+			"nofile": {Soft: 1024, Hard: 4096},
+			"core":   {Soft: 0, Hard: 0},
+		}}).validate())
+		require.EqualError(t, (&TaskConfig{Rlimits: map[string]Rlimit{
+			"nofile": {Soft: 4096, Hard: 1024},
+		}}).validate(), `rlimit "nofile" soft limit 4096 exceeds its hard limit 1024`)
+
+		err := (&TaskConfig{Rlimits: map[string]Rlimit{
+			"files": {Soft: 1, Hard: 1},
+		}}).validate()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `rlimits contains unknown rlimit "files"`)
+	})
+
+	t.Run("oom_score_adj", func(t *testing.T) {
+		require.NoError(t, (&TaskConfig{}).validate())
+		require.NoError(t, (&TaskConfig{OOMScoreAdj: helper.IntToPtr(-1000)}).validate())
+		require.NoError(t, (&TaskConfig{OOMScoreAdj: helper.IntToPtr(1000)}).validate())
+		require.EqualError(t, (&TaskConfig{OOMScoreAdj: helper.IntToPtr(-1001)}).validate(),
+			"oom_score_adj must be between -1000 and 1000, got -1001")
+		require.EqualError(t, (&TaskConfig{OOMScoreAdj: helper.IntToPtr(1001)}).validate(),
+			"oom_score_adj must be between -1000 and 1000, got 1001")
+	})
+
+	t.Run("healthy_quiet_period", func(t *testing.T) {
+		require.NoError(t, (&TaskConfig{}).validate())
+		require.NoError(t, (&TaskConfig{HealthyCpuThreshold: 5, HealthyQuietPeriod: "10s"}).validate())
+		require.EqualError(t, (&TaskConfig{HealthyCpuThreshold: -5, HealthyQuietPeriod: "10s"}).validate(),
+			"healthy_cpu_threshold must not be negative, got -5")
+		require.EqualError(t, (&TaskConfig{HealthyCpuThreshold: 5, HealthyQuietPeriod: "0s"}).validate(),
+			`healthy_quiet_period must be a positive duration, got "0s"`)
+		require.EqualError(t, (&TaskConfig{HealthyCpuThreshold: 5}).validate(),
+			"healthy_cpu_threshold and healthy_quiet_period must be set together")
+		require.EqualError(t, (&TaskConfig{HealthyQuietPeriod: "10s"}).validate(),
+			"healthy_cpu_threshold and healthy_quiet_period must be set together")
+	})
+
+	t.Run("max_runtime", func(t *testing.T) {
+		for _, tc := range []struct {
+			runtime string
+			exp     error
+		}{
+			{runtime: "", exp: nil},
+			{runtime: "90s", exp: nil},
+			{runtime: "1h30m", exp: nil},
+			{runtime: "0s", exp: errors.New(`max_runtime must be a positive duration, got "0s"`)},
+			{runtime: "-1m", exp: errors.New(`max_runtime must be a positive duration, got "-1m"`)},
+			{runtime: "soon", exp: errors.New(`max_runtime must be a positive duration, got "soon"`)},
+		} {
+			require.Equal(t, tc.exp, (&TaskConfig{
+				MaxRuntime: tc.runtime,
+			}).validate())
+		}
+	})
+}
+
+func TestDriver_TaskConfig_pidsLimit(t *testing.T) {
+	ci.Parallel(t)
+
+	for _, tc := range []struct {
+		name         string
+		defaultLimit int64
+		taskLimit    int64
+		exp          int64
+	}{
+		{name: "unlimited", defaultLimit: 0, taskLimit: 0, exp: 0},
+		{name: "unset uses default", defaultLimit: 100, taskLimit: 0, exp: 100},
+		{name: "task without default", defaultLimit: 0, taskLimit: 50, exp: 50},
+		{name: "task below default", defaultLimit: 100, taskLimit: 50, exp: 50},
+		{name: "task capped at default", defaultLimit: 100, taskLimit: 500, exp: 100},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.exp, (&TaskConfig{PidsLimit: tc.taskLimit}).pidsLimit(tc.defaultLimit))
+		})
+	}
+}
+
+func TestDriver_TaskConfig_noNewPrivileges(t *testing.T) {
+	ci.Parallel(t)
+
+	for _, tc := range []struct {
+		name       string
+		defaultNNP bool
+		taskNNP    *bool
+		exp        bool
+		expErr     string
+	}{
+		{name: "unset uses disabled default", defaultNNP: false, taskNNP: nil, exp: false},
+		{name: "unset uses enabled default", defaultNNP: true, taskNNP: nil, exp: true},
+		{name: "task enables", defaultNNP: false, taskNNP: helper.BoolToPtr(true), exp: true},
+		{name: "task disables", defaultNNP: false, taskNNP: helper.BoolToPtr(false), exp: false},
+		{name: "task keeps default", defaultNNP: true, taskNNP: helper.BoolToPtr(true), exp: true},
+		{
+			name:       "task cannot disable default",
+			defaultNNP: true,
+			taskNNP:    helper.BoolToPtr(false),
+			expErr:     "no_new_privileges cannot be disabled, as default_no_new_privileges is enabled in the exec driver",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nnp, err := (&TaskConfig{NoNewPrivileges: tc.taskNNP}).noNewPrivileges(tc.defaultNNP)
+			if tc.expErr != "" {
+				require.EqualError(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.exp, nnp)
+		})
+	}
+}
+
+func TestExecDriver_TaskConfigChanges(t *testing.T) {
+	ci.Parallel(t)
+
+	taskConfig := func(update func(*drivers.TaskConfig, *TaskConfig)) *drivers.TaskConfig {
+		task := &drivers.TaskConfig{
+			Env:     map[string]string{"FOO": "bar"},
+			Mounts:  []*drivers.MountConfig{{TaskPath: "/data", HostPath: "/srv/data"}},
+			Devices: []*drivers.DeviceConfig{{TaskPath: "/dev/fuse", HostPath: "/dev/fuse", Permissions: "rw"}},
+		}
+		tc := &TaskConfig{
+			Command: "/bin/sleep",
+			Args:    []string{"9000"},
+			CapAdd:  []string{"net_raw"},
+		}
+		if update != nil {
+			update(task, tc)
+		}
+		require.NoError(t, task.EncodeConcreteDriverConfig(tc))
+		return task
+	}
+
+	cases := []struct {
+		name     string
+		update   func(*drivers.TaskConfig, *TaskConfig)
+		expected []TaskConfigChange
+	}{
+		{
+			name: "unchanged",
+		},
+		{
+			name:     "command",
+			update:   func(_ *drivers.TaskConfig, tc *TaskConfig) { tc.Command = "/bin/true" },
+			expected: []TaskConfigChange{TaskConfigChangeCommand},
+		},
+		{
+			name:     "args",
+			update:   func(_ *drivers.TaskConfig, tc *TaskConfig) { tc.Args = []string{"60"} },
+			expected: []TaskConfigChange{TaskConfigChangeArgs},
+		},
+		{
+			name:     "env",
+			update:   func(task *drivers.TaskConfig, _ *TaskConfig) { task.Env["FOO"] = "baz" },
+			expected: []TaskConfigChange{TaskConfigChangeEnv},
+		},
+		{
+			name:     "env file",
+			update:   func(_ *drivers.TaskConfig, tc *TaskConfig) { tc.EnvFile = "local/app.env" },
+			expected: []TaskConfigChange{TaskConfigChangeEnv},
+		},
+		{
+			name:     "mount host path",
+			update:   func(task *drivers.TaskConfig, _ *TaskConfig) { task.Mounts[0].HostPath = "/srv/other" },
+			expected: []TaskConfigChange{TaskConfigChangeMounts},
+		},
+		{
+			name: "tmpfs",
+			update: func(_ *drivers.TaskConfig, tc *TaskConfig) {
+				tc.Tmpfs = []TmpfsMount{{Path: "/scratch", SizeMB: 64, Mode: "1777"}}
+			},
+			expected: []TaskConfigChange{TaskConfigChangeMounts},
+		},
+		{
+			name:     "devices",
+			update:   func(task *drivers.TaskConfig, _ *TaskConfig) { task.Devices = nil },
+			expected: []TaskConfigChange{TaskConfigChangeDevices},
+		},
+		{
+			name:     "caps",
+			update:   func(_ *drivers.TaskConfig, tc *TaskConfig) { tc.CapDrop = []string{"chown"} },
+			expected: []TaskConfigChange{TaskConfigChangeCaps},
+		},
+		{
+			name:   "caps spelling",
+			update: func(_ *drivers.TaskConfig, tc *TaskConfig) { tc.CapAdd = []string{"CAP_NET_RAW"} },
+		},
+		{
+			name:     "other",
+			update:   func(_ *drivers.TaskConfig, tc *TaskConfig) { tc.Nice = 10 },
+			expected: []TaskConfigChange{TaskConfigChangeOther},
+		},
+		{
+			name: "several",
+			update: func(task *drivers.TaskConfig, tc *TaskConfig) {
+				tc.Nice = 10
+				tc.Command = "/bin/true"
+				task.Mounts = nil
+			},
+			expected: []TaskConfigChange{TaskConfigChangeCommand, TaskConfigChangeMounts, TaskConfigChangeOther},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			changes, err := TaskConfigChanges(taskConfig(nil), taskConfig(c.update))
+			require.NoError(t, err)
+			require.Equal(t, c.expected, changes)
+		})
+	}
 }
@@ -119,6 +119,44 @@ func TestExec_ExecTaskStreaming(t *testing.T) {
 
 }
 
+func TestExec_ExecTaskStreaming_InteractiveShell(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	defer harness.Kill()
+
+	task := &drivers.TaskConfig{
+		ID:   uuid.Generate(),
+		Name: "sleep",
+	}
+
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	tc := &TaskConfig{
+		Command: "/bin/sleep",
+		Args:    []string{"9000"},
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	_, _, err := harness.StartTask(task)
+	require.NoError(err)
+	defer d.DestroyTask(task.ID, true)
+
+	// an interactive shell reads commands from stdin on the tty, and runs
+	// them in the task's cgroup
+	exitCode, stdout, _ := dtestutil.ExecTask(t, harness, task.ID, "exec /bin/sh -i", true,
+		"cg=$(cat /proc/self/cgroup); [ \"${cg#*nomad}\" != \"$cg\" ] && echo in cgroup $((6*7))\nexit 7\n")
+	require.Equal(7, exitCode)
+	require.Contains(stdout, "in cgroup 42")
+}
+
 // Tests that a given DNSConfig properly configures dns
 func TestExec_dnsConfig(t *testing.T) {
 	ci.Parallel(t)
@@ -1,11 +1,20 @@
 package exec
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	metrics "github.com/armon/go-metrics"
+	"github.com/hashicorp/consul-template/signals"
 	hclog "github.com/hashicorp/go-hclog"
 	plugin "github.com/hashicorp/go-plugin"
 	"github.com/hashicorp/nomad/drivers/shared/executor"
@@ -18,6 +27,9 @@ type taskHandle struct {
 	pluginClient *plugin.Client
 	logger       hclog.Logger
 
+	// emitEvent emits a task event, such as when the task is OOM killed
+	emitEvent func(*drivers.TaskEvent) error
+
 	// stateLock syncs access to all fields below
 	stateLock sync.RWMutex
 
@@ -26,6 +38,11 @@ type taskHandle struct {
 	startedAt   time.Time
 	completedAt time.Time
 	exitResult  *drivers.ExitResult
+
+	// maxRuntime is how long the task may run before it is stopped, or zero
+	// if it may run indefinitely
+	maxRuntime         time.Duration
+	maxRuntimeExceeded bool
 }
 
 func (h *taskHandle) TaskStatus() *drivers.TaskStatus {
@@ -40,17 +57,322 @@ func (h *taskHandle) TaskStatus() *drivers.TaskStatus {
 		CompletedAt: h.completedAt,
 		ExitResult:  h.exitResult,
 		DriverAttributes: map[string]string{
-			"pid": strconv.Itoa(h.pid),
+			"pid":     strconv.Itoa(h.pid),
+			"mounts":  formatMounts(h.taskConfig.Mounts),
+			"devices": formatDevices(h.taskConfig.Devices),
 		},
 	}
 }
 
+// formatMounts returns a comma separated list of the task paths of mounts
+// along with their access mode, e.g. "/etc/resolv.conf:ro,/data:rw".
+func formatMounts(mounts []*drivers.MountConfig) string {
+	parts := make([]string, 0, len(mounts))
+	for _, m := range mounts {
+		mode := "rw"
+		if m.Readonly {
+			mode = "ro"
+		}
+		parts = append(parts, m.TaskPath+":"+mode)
+	}
+	return strings.Join(parts, ",")
+}
+
+// formatDevices returns a comma separated list of the task paths of devices
+// along with their cgroup permissions, e.g. "/dev/fuse:rwm".
+func formatDevices(devices []*drivers.DeviceConfig) string {
+	parts := make([]string, 0, len(devices))
+	for _, d := range devices {
+		parts = append(parts, d.TaskPath+":"+d.Permissions)
+	}
+	return strings.Join(parts, ",")
+}
+
+// ManagedTaskInfo returns a summary of the task for listing the tasks the
+// driver manages.
+func (h *taskHandle) ManagedTaskInfo() ManagedTaskInfo {
+	h.stateLock.RLock()
+	defer h.stateLock.RUnlock()
+
+	return ManagedTaskInfo{
+		TaskID:    h.taskConfig.ID,
+		PID:       h.pid,
+		State:     h.procState,
+		StartedAt: h.startedAt,
+	}
+}
+
 func (h *taskHandle) IsRunning() bool {
 	h.stateLock.RLock()
 	defer h.stateLock.RUnlock()
 	return h.procState == drivers.TaskStateRunning
 }
 
+// shutdown stops the task in the background by sending it signal, escalating
+// to SIGKILL if it hasn't exited once grace has passed, and emitting a task
+// event for each step. The returned channel receives the result once the
+// task is stopped, or once ctx is cancelled without killing it.
+func (h *taskHandle) shutdown(ctx context.Context, signal string, grace time.Duration, emit func(*drivers.TaskEvent) error) <-chan error {
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- h.escalateShutdown(ctx, signal, grace, emit)
+	}()
+	return errCh
+}
+
+func (h *taskHandle) escalateShutdown(ctx context.Context, signal string, grace time.Duration, emit func(*drivers.TaskEvent) error) error {
+	if grace <= 0 {
+		return h.exec.Shutdown("", 0)
+	}
+
+	sig, ok := signals.SignalLookup[signal]
+	if !ok {
+		return fmt.Errorf("unknown signal given for shutdown: %s", signal)
+	}
+
+	waitCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		h.exec.Wait(waitCtx)
+	}()
+
+	h.emitShutdownEvent(emit, fmt.Sprintf("Sent %s to task", signal), signal)
+	if err := h.exec.Signal(sig); err != nil {
+		h.logger.Debug("failed to signal task", "task_id", h.taskConfig.ID, "signal", signal, "error", err)
+	}
+
+	timer := time.NewTimer(grace)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-exited:
+	case <-timer.C:
+		h.emitShutdownEvent(emit, fmt.Sprintf("Task did not exit within %s of %s, sent SIGKILL", grace, signal), "SIGKILL")
+	}
+
+	// kills whatever is still running and cleans up the task's isolation
+	return h.exec.Shutdown("", 0)
+}
+
+func (h *taskHandle) emitShutdownEvent(emit func(*drivers.TaskEvent) error, msg, signal string) {
+	emit(&drivers.TaskEvent{
+		TaskID:    h.taskConfig.ID,
+		AllocID:   h.taskConfig.AllocID,
+		TaskName:  h.taskConfig.Name,
+		Timestamp: time.Now(),
+		Message:   msg,
+		Annotations: map[string]string{
+			"signal": signal,
+		},
+	})
+}
+
+// enforceMaxRuntime stops the task once it has been running for longer than
+// its max runtime, killing it if it doesn't exit in time.
+func (h *taskHandle) enforceMaxRuntime(exited <-chan struct{}) {
+	timer := time.NewTimer(time.Until(h.startedAt.Add(h.maxRuntime)))
+	defer timer.Stop()
+
+	select {
+	case <-exited:
+		return
+	case <-timer.C:
+	}
+
+	h.stateLock.Lock()
+	h.maxRuntimeExceeded = true
+	h.stateLock.Unlock()
+
+	h.logger.Info("task exceeded its max runtime, stopping", "task_id", h.taskConfig.ID, "max_runtime", h.maxRuntime)
+	if err := h.exec.Shutdown(stopSignal(h.taskConfig), maxRuntimeKillTimeout); err != nil {
+		h.logger.Error("failed to stop task after exceeding its max runtime", "task_id", h.taskConfig.ID, "error", err)
+	}
+}
+
+// emitExitMetric counts the task's exit, labelled to tell tasks killed by a
+// signal apart from tasks that exited with a code, since a task killed by
+// SIGKILL also reports exit code 137.
+func (h *taskHandle) emitExitMetric() {
+	labels := []metrics.Label{
+		{Name: "exit_type", Value: "exit_code"},
+		{Name: "exit_code", Value: strconv.Itoa(h.exitResult.ExitCode)},
+	}
+	if h.exitResult.Signal != 0 {
+		labels = []metrics.Label{
+			{Name: "exit_type", Value: "signal"},
+			{Name: "signal", Value: strconv.Itoa(h.exitResult.Signal)},
+		}
+	}
+	metrics.IncrCounterWithLabels([]string{"client", "driver", "exec", "task_exits"}, 1, labels)
+}
+
+// quietStatsInterval is how often the task's CPU usage is sampled while
+// waiting for it to settle.
+const quietStatsInterval = time.Second
+
+// waitForQuiet blocks until the task's CPU usage, as a percentage of one
+// core, has stayed below threshold for the quiet period. It returns early if
+// the task exits, as its usage can no longer be measured.
+func (h *taskHandle) waitForQuiet(ctx context.Context, threshold float64, period time.Duration) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	statsCh, err := h.exec.Stats(ctx, quietStatsInterval)
+	if err != nil {
+		return err
+	}
+
+	// The first sample has no earlier one to measure usage against, so it
+	// always reports the task as idle.
+	first := true
+
+	var quietSince time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case usage, ok := <-statsCh:
+			if !ok {
+				return nil
+			}
+			if first {
+				first = false
+				continue
+			}
+			if usage.ResourceUsage == nil || usage.ResourceUsage.CpuStats == nil ||
+				usage.ResourceUsage.CpuStats.Percent >= threshold {
+				quietSince = time.Time{}
+				continue
+			}
+			if quietSince.IsZero() {
+				quietSince = time.Now()
+			}
+			if time.Since(quietSince) >= period {
+				return nil
+			}
+		}
+	}
+}
+
+// maxRuntimeErr returns the error to report for the task's exit if it was
+// stopped for exceeding its max runtime, or nil otherwise.
+func (h *taskHandle) maxRuntimeErr() error {
+	h.stateLock.RLock()
+	defer h.stateLock.RUnlock()
+	return h.maxRuntimeErrLocked()
+}
+
+func (h *taskHandle) maxRuntimeErrLocked() error {
+	if !h.maxRuntimeExceeded {
+		return nil
+	}
+	return fmt.Errorf("max runtime exceeded: task ran longer than %s", h.maxRuntime)
+}
+
+// TailLogs returns up to the last lines lines written by the task to the
+// given stream, which must be "stdout" or "stderr". The task writes its
+// output to a fifo read by logmon, so they're read from the log files logmon
+// rotates it into.
+func (h *taskHandle) TailLogs(stream string, lines int) ([]string, error) {
+	if lines <= 0 {
+		return nil, fmt.Errorf("lines must be positive, got %d", lines)
+	}
+	if stream != "stdout" && stream != "stderr" {
+		return nil, fmt.Errorf("stream must be %q or %q, got %q", "stdout", "stderr", stream)
+	}
+
+	logDir := h.taskConfig.TaskDir().LogDir
+	return tailLogFiles(logDir, fmt.Sprintf("%s.%s", h.taskConfig.Name, stream), lines)
+}
+
+// tailLogFiles returns up to the last n lines of the log files in dir named
+// base.0, base.1 and so on by logmon. The newest file is read backwards, so
+// that only the end of a large file is read, continuing into older files
+// until there are enough lines.
+func tailLogFiles(dir, base string, n int) ([]string, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := base + "."
+	var indexes []int
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), prefix) {
+			continue
+		}
+		idx, err := strconv.Atoi(strings.TrimPrefix(entry.Name(), prefix))
+		if err != nil {
+			continue
+		}
+		indexes = append(indexes, idx)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(indexes)))
+
+	var buf []byte
+	for _, idx := range indexes {
+		if bytes.Count(buf, []byte("\n")) > n {
+			break
+		}
+		buf, err = prependFileTail(filepath.Join(dir, fmt.Sprintf("%s.%d", base, idx)), buf, n)
+		if os.IsNotExist(err) {
+			// logmon removed the file, and any older ones, since listing them
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	text := strings.TrimSuffix(string(buf), "\n")
+	if text == "" {
+		return []string{}, nil
+	}
+	result := strings.Split(text, "\n")
+	if len(result) > n {
+		result = result[len(result)-n:]
+	}
+	return result, nil
+}
+
+// prependFileTail reads the file at path backwards onto the front of buf,
+// until buf has the last n lines or the whole file has been read. A line
+// may continue from the end of an older file, so completing the last n
+// lines needs one more newline than lines.
+func prependFileTail(path string, buf []byte, n int) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return buf, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return buf, err
+	}
+
+	const chunkSize = 4096
+	offset := fi.Size()
+	for offset > 0 && bytes.Count(buf, []byte("\n")) <= n {
+		size := int64(chunkSize)
+		if offset < size {
+			size = offset
+		}
+		offset -= size
+
+		chunk := make([]byte, size)
+		if _, err := f.ReadAt(chunk, offset); err != nil {
+			return buf, err
+		}
+		buf = append(chunk, buf...)
+	}
+	return buf, nil
+}
+
 func (h *taskHandle) run() {
 	h.stateLock.Lock()
 	if h.exitResult == nil {
@@ -58,22 +380,52 @@ func (h *taskHandle) run() {
 	}
 	h.stateLock.Unlock()
 
+	exited := make(chan struct{})
+	defer close(exited)
+	if h.maxRuntime > 0 {
+		go h.enforceMaxRuntime(exited)
+	}
+
 	// Block until process exits
 	ps, err := h.exec.Wait(context.Background())
 
 	h.stateLock.Lock()
-	defer h.stateLock.Unlock()
-
 	if err != nil {
 		h.exitResult.Err = err
 		h.procState = drivers.TaskStateUnknown
 		h.completedAt = time.Now()
+		h.stateLock.Unlock()
 		return
 	}
 	h.procState = drivers.TaskStateExited
 	h.exitResult.ExitCode = ps.ExitCode
 	h.exitResult.Signal = ps.Signal
+	h.exitResult.OOMKilled = ps.OOMKilled
 	h.completedAt = ps.Time
+	h.exitResult.Err = h.maxRuntimeErrLocked()
+	h.emitExitMetric()
+	h.stateLock.Unlock()
 
-	// TODO: detect if the task OOMed
+	if ps.OOMKilled {
+		h.emitOOMEvent()
+	}
+}
+
+// emitOOMEvent emits a task event telling an OOM kill apart from the task
+// exiting on its own.
+func (h *taskHandle) emitOOMEvent() {
+	h.logger.Info("task was OOM killed", "task_id", h.taskConfig.ID, "task_name", h.taskConfig.Name)
+	if h.emitEvent == nil {
+		return
+	}
+	h.emitEvent(&drivers.TaskEvent{
+		TaskID:    h.taskConfig.ID,
+		AllocID:   h.taskConfig.AllocID,
+		TaskName:  h.taskConfig.Name,
+		Timestamp: time.Now(),
+		Message:   "Task was killed by the OOM killer after exceeding its memory limit",
+		Annotations: map[string]string{
+			"oom_killed": "true",
+		},
+	})
 }
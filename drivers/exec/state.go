@@ -26,6 +26,16 @@ func (ts *taskStore) Get(id string) (*taskHandle, bool) {
 	return t, ok
 }
 
+func (ts *taskStore) List() []*taskHandle {
+	ts.lock.RLock()
+	defer ts.lock.RUnlock()
+	handles := make([]*taskHandle, 0, len(ts.store))
+	for _, h := range ts.store {
+		handles = append(handles, h)
+	}
+	return handles
+}
+
 func (ts *taskStore) Delete(id string) {
 	ts.lock.Lock()
 	defer ts.lock.Unlock()
@@ -50,6 +50,30 @@ func (c *grpcExecutorClient) Launch(cmd *ExecCommand) (*ProcessState, error) {
 		DefaultPidMode:     cmd.ModePID,
 		DefaultIpcMode:     cmd.ModeIPC,
 		Capabilities:       cmd.Capabilities,
+		Nice:               int32(cmd.Nice),
+		IoClass:            cmd.IOClass,
+		IoPriority:         int32(cmd.IOPriority),
+		CpusetCpus:         cmd.CpusetCpus,
+		CpusetMems:         cmd.CpusetMems,
+		MountNamespaceOnly: cmd.MountNamespaceOnly,
+		DisableCgroups:     cmd.DisableCgroups,
+		MemorySwappiness:   wrapInt64(cmd.MemorySwappiness),
+		MemorySwapMb:       cmd.MemorySwapMB,
+		ApparmorProfile:    cmd.ApparmorProfile,
+		OomScoreAdj:        int32(cmd.OOMScoreAdj),
+		NoNewPrivileges:    cmd.NoNewPrivileges,
+		UsernsMode:         cmd.ModeUserns,
+		UsernsIdOffset:     cmd.UsernsIDOffset,
+		TmpfsMounts:        tmpfsMountsToProto(cmd.TmpfsMounts),
+		UtsMode:            cmd.ModeUTS,
+		Hostname:           cmd.Hostname,
+		SeccompProfile:     cmd.SeccompProfile,
+		Rlimits:            rlimitsToProto(cmd.Rlimits),
+		CgroupControllers:  cmd.CgroupControllers,
+		PidsLimit:          cmd.PidsLimit,
+		AdditionalGroups:   cmd.AdditionalGroups,
+		WorkDir:            cmd.WorkDir,
+		SelinuxRelabel:     cmd.SELinuxRelabel,
 	}
 	resp, err := c.client.Launch(ctx, req)
 	if err != nil {
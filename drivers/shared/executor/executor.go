@@ -41,8 +41,28 @@ const (
 
 	// IsolationModeHost represents the host isolation mode for a namespace
 	IsolationModeHost = "host"
+
+	// IOClassRealtime represents the realtime I/O scheduling class
+	IOClassRealtime = "realtime"
+
+	// IOClassBestEffort represents the best-effort I/O scheduling class
+	IOClassBestEffort = "best-effort"
+
+	// IOClassIdle represents the idle I/O scheduling class
+	IOClassIdle = "idle"
+
+	// UsernsIDCount is the number of user and group IDs mapped into a task's
+	// private user namespace
+	UsernsIDCount = 65536
 )
 
+// RlimitNames are the resources whose limits may be set for tasks, as named by
+// setrlimit(2) without the RLIMIT_ prefix.
+var RlimitNames = []string{
+	"as", "core", "cpu", "data", "fsize", "locks", "memlock", "msgqueue",
+	"nice", "nofile", "nproc", "rss", "rtprio", "rttime", "sigpending", "stack",
+}
+
 var (
 	// The statistics the basic executor exposes
 	ExecutorBasicMeasuredMemStats = []string{"RSS", "Swap"}
@@ -154,6 +174,121 @@ type ExecCommand struct {
 
 	// Capabilities are the linux capabilities to be enabled by the task driver.
 	Capabilities []string
+
+	// Nice is the scheduling priority (nice value) of the task process.
+	Nice int
+
+	// IOClass is the I/O scheduling class of the task process. If empty the
+	// kernel default is used.
+	IOClass string
+
+	// IOPriority is the I/O scheduling priority within IOClass.
+	IOPriority int
+
+	// CpusetCpus is the set of CPUs the task is pinned to, in the Linux
+	// cpuset list format (e.g. "0-3,8").
+	CpusetCpus string
+
+	// CpusetMems is the set of NUMA memory nodes the task may allocate
+	// memory from, in the Linux cpuset list format (e.g. "0-1").
+	CpusetMems string
+
+	// MountNamespaceOnly runs the task with the host's root filesystem in a
+	// private mount namespace, rather than chrooted into TaskDir.
+	MountNamespaceOnly bool
+
+	// DisableCgroups runs the task without a cgroup of its own, so no
+	// resource limits are enforced and no resource usage is collected.
+	DisableCgroups bool
+
+	// MemorySwappiness overrides the swappiness of the task's memory cgroup,
+	// which otherwise has swap disabled. -1 inherits the parent's swappiness.
+	MemorySwappiness *int64
+
+	// MemorySwapMB limits the combined memory and swap usage of the task, or
+	// is zero for no limit beyond the memory limit.
+	MemorySwapMB int64
+
+	// PidsLimit is the maximum number of processes in the task's pids
+	// cgroup, or zero for no limit.
+	PidsLimit int64
+
+	// ApparmorProfile is the name of the AppArmor profile the task is
+	// confined by, which must be loaded on the host.
+	ApparmorProfile string
+
+	// OOMScoreAdj is the oom_score_adj of the task, from -1000 to 1000. The
+	// task doesn't inherit the executor's value.
+	OOMScoreAdj int
+
+	// NoNewPrivileges prevents the task's processes from gaining privileges,
+	// such as by running setuid binaries.
+	NoNewPrivileges bool
+
+	// ModeUserns is the user namespace isolation mode (private or host).
+	ModeUserns string
+
+	// UsernsIDOffset is the host user and group ID that root in a private
+	// user namespace maps to, followed by UsernsIDCount-1 more IDs.
+	UsernsIDOffset uint32
+
+	// TmpfsMounts are in-memory filesystems mounted into the task.
+	TmpfsMounts []*TmpfsMount
+
+	// ModeUTS is the UTS namespace isolation mode (private or host).
+	ModeUTS string
+
+	// Hostname is the hostname of the task in a private UTS namespace.
+	Hostname string
+
+	// SeccompProfile is the OCI seccomp profile, in JSON, which the task's
+	// syscalls are filtered by.
+	SeccompProfile []byte
+
+	// Rlimits are the resource limits of the task's processes.
+	Rlimits []*Rlimit
+
+	// CgroupControllers are the cgroup controllers available to the task.
+	// Limits enforced by any other controller are not set. If empty, every
+	// controller is assumed to be available.
+	CgroupControllers []string
+
+	// AdditionalGroups are the GIDs of the supplementary groups of the
+	// task's processes, in addition to those of the task user.
+	AdditionalGroups []uint32
+
+	// WorkDir is the path, as seen by the task, of the directory it starts
+	// in. The task starts in its root directory if empty.
+	WorkDir string
+
+	// SELinuxRelabel relabels the host paths of the task's Mounts with a
+	// shared SELinux label, so that the task can access them.
+	SELinuxRelabel bool
+}
+
+// TmpfsMount is an in-memory filesystem mounted into a task.
+type TmpfsMount struct {
+	// TaskPath is where the filesystem is mounted inside the task.
+	TaskPath string
+
+	// SizeBytes is the most the filesystem may hold.
+	SizeBytes int64
+
+	// Mode is the permissions of the filesystem's root directory.
+	Mode uint32
+}
+
+// Rlimit is a resource limit of a task's processes.
+type Rlimit struct {
+	// Name is the resource being limited, one of RlimitNames.
+	Name string
+
+	// Soft is the limit processes are held to, which they may raise as far
+	// as Hard.
+	Soft uint64
+
+	// Hard is the ceiling of the soft limit.
+	Hard uint64
 }
 
 // SetWriters sets the writer for the process stdout and stderr. This should
@@ -223,6 +358,10 @@ type ProcessState struct {
 	ExitCode int
 	Signal   int
 	Time     time.Time
+
+	// OOMKilled is whether the OOM killer killed any of the task's
+	// processes, as counted by its memory cgroup.
+	OOMKilled bool
 }
 
 // ExecutorVersion is the version of the executor
@@ -4,6 +4,7 @@
 package executor
 
 import (
+	"fmt"
 	"os/exec"
 
 	hclog "github.com/hashicorp/go-hclog"
@@ -16,6 +17,11 @@ func NewExecutorWithIsolation(logger hclog.Logger) Executor {
 	return NewExecutor(logger)
 }
 
+// ValidateSeccompProfile always fails, as seccomp is only supported on Linux.
+func ValidateSeccompProfile([]byte) error {
+	return fmt.Errorf("seccomp is only supported on Linux")
+}
+
 func (e *UniversalExecutor) configureResourceContainer(_ int) error { return nil }
 
 func (e *UniversalExecutor) getAllPids() (map[int]*nomadPid, error) {
@@ -5,12 +5,15 @@ package executor
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -31,21 +34,27 @@ import (
 	lconfigs "github.com/opencontainers/runc/libcontainer/configs"
 	"github.com/opencontainers/runc/libcontainer/devices"
 	ldevices "github.com/opencontainers/runc/libcontainer/devices"
+	"github.com/opencontainers/runc/libcontainer/seccomp"
 	"github.com/opencontainers/runc/libcontainer/specconv"
 	lutils "github.com/opencontainers/runc/libcontainer/utils"
 	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/opencontainers/selinux/go-selinux"
 	"golang.org/x/sys/unix"
 )
 
 const (
 	defaultCgroupParent = "/nomad"
+
+	// firstStatsRetryInterval is how often stats are collected until the
+	// task's cgroup has recorded its first memory usage
+	firstStatsRetryInterval = 100 * time.Millisecond
 )
 
 var (
 	// ExecutorCgroupV1MeasuredMemStats is the list of memory stats captured by the executor with cgroup-v1
 	ExecutorCgroupV1MeasuredMemStats = []string{"RSS", "Cache", "Swap", "Usage", "Max Usage", "Kernel Usage", "Kernel Max Usage"}
 
-	// ExecutorCgroupV2MeasuredMemStats is the list of memory stats captured by the executor with cgroup-v2. cgroup-v2 exposes different memory stats and no longer reports rss or max usage.
+	// ExecutorCgroupV2MeasuredMemStats is the list of memory stats captured by the executor with cgroup-v2. cgroup-v2 exposes different memory stats and no longer reports rss, and only reports max usage on kernels with memory.peak.
 	ExecutorCgroupV2MeasuredMemStats = []string{"Cache", "Swap", "Usage"}
 
 	// ExecutorCgroupMeasuredCpuStats is the list of CPU stats captures by the executor
@@ -137,9 +146,10 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 	path := absPath
 
 	// Ensure that the path is contained in the chroot, and find it relative to the container
-	rel, err := filepath.Rel(command.TaskDir, path)
+	rootfs := taskRootfs(command)
+	rel, err := filepath.Rel(rootfs, path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to determine relative path base=%q target=%q: %v", command.TaskDir, path, err)
+		return nil, fmt.Errorf("failed to determine relative path base=%q target=%q: %v", rootfs, path, err)
 	}
 
 	// Turn relative-to-chroot path into absolute path to avoid
@@ -168,19 +178,25 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 		Stdout: stdout,
 		Stderr: stderr,
 		Init:   true,
+		Cwd:    command.WorkDir,
 	}
 
 	if command.User != "" {
 		process.User = command.User
 	}
+	for _, gid := range command.AdditionalGroups {
+		process.AdditionalGroups = append(process.AdditionalGroups, strconv.FormatUint(uint64(gid), 10))
+	}
 	l.userProc = process
 
 	l.totalCpuStats = stats.NewCpuStats()
 	l.userCpuStats = stats.NewCpuStats()
 	l.systemCpuStats = stats.NewCpuStats()
 
-	// Starts the task
-	if err := container.Run(process); err != nil {
+	// Starts the task. The init process blocks before exec'ing the task binary
+	// until container.Exec is called, so that the scheduling priority applied
+	// below is inherited by the task and all of its children.
+	if err := container.Start(process); err != nil {
 		container.Destroy()
 		return nil, err
 	}
@@ -191,10 +207,23 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 		return nil, err
 	}
 
+	if err := setSchedulingPriority(pid, command); err != nil {
+		container.Destroy()
+		return nil, err
+	}
+
+	if err := container.Exec(); err != nil {
+		container.Destroy()
+		return nil, err
+	}
+
 	// start a goroutine to wait on the process to complete, so Wait calls can
 	// be multiplexed
 	l.userProcExited = make(chan interface{})
-	go l.pidCollector.collectPids(l.userProcExited, l.getAllPids)
+	// without a cgroup there is no way to find the processes of the task
+	if !command.DisableCgroups {
+		go l.pidCollector.collectPids(l.userProcExited, l.getAllPids)
+	}
 	go l.wait()
 
 	return &ProcessState{
@@ -211,12 +240,7 @@ func (l *LibcontainerExecutor) getAllPids() (map[int]*nomadPid, error) {
 	}
 	nPids := make(map[int]*nomadPid)
 	for _, pid := range pids {
-		nPids[pid] = &nomadPid{
-			pid:           pid,
-			cpuStatsTotal: stats.NewCpuStats(),
-			cpuStatsUser:  stats.NewCpuStats(),
-			cpuStatsSys:   stats.NewCpuStats(),
-		}
+		nPids[pid] = newNomadPid(pid)
 	}
 	return nPids, nil
 }
@@ -261,11 +285,64 @@ func (l *LibcontainerExecutor) wait() {
 	}
 
 	l.exitState = &ProcessState{
-		Pid:      ps.Pid(),
-		ExitCode: exitCode,
-		Signal:   signal,
-		Time:     time.Now(),
+		Pid:       ps.Pid(),
+		ExitCode:  exitCode,
+		Signal:    signal,
+		Time:      time.Now(),
+		OOMKilled: l.oomKilled(),
+	}
+}
+
+// oomKilled returns whether the OOM killer killed any of the task's processes,
+// as counted by the oom_kill field of its memory cgroup's events.
+func (l *LibcontainerExecutor) oomKilled() bool {
+	if l.command.DisableCgroups {
+		return false
+	}
+
+	state, err := l.container.State()
+	if err != nil {
+		l.logger.Debug("failed to get container state to check for OOM kills", "error", err)
+		return false
+	}
+
+	path := filepath.Join(state.CgroupPaths["memory"], "memory.oom_control")
+	if cgroups.IsCgroup2UnifiedMode() {
+		path = filepath.Join(state.CgroupPaths[""], "memory.events")
 	}
+
+	kills, err := readOOMKills(path)
+	if err != nil {
+		l.logger.Debug("failed to check task cgroup for OOM kills", "path", path, "error", err)
+		return false
+	}
+	return kills > 0
+}
+
+// readOOMKills returns the oom_kill count of a memory cgroup's
+// memory.oom_control (cgroups v1) or memory.events (cgroups v2) file.
+func readOOMKills(path string) (uint64, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	for _, line := range strings.Split(string(b), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == "oom_kill" {
+			return strconv.ParseUint(fields[1], 10, 64)
+		}
+	}
+	return 0, fmt.Errorf("no oom_kill count in %s", path)
+}
+
+// readMemoryPeak returns the peak memory usage recorded in a cgroups v2
+// memory.peak file.
+func readMemoryPeak(path string) (uint64, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(b)), 10, 64)
 }
 
 // Shutdown stops all processes started and cleans up any resources
@@ -286,6 +363,10 @@ func (l *LibcontainerExecutor) Shutdown(signal string, grace time.Duration) erro
 		return nil
 	}
 
+	// Signalling all processes of the container relies on its cgroup, so
+	// without one only the initial process can be killed.
+	all := !l.command.DisableCgroups
+
 	if grace > 0 {
 		if signal == "" {
 			signal = "SIGINT"
@@ -308,13 +389,13 @@ func (l *LibcontainerExecutor) Shutdown(signal string, grace time.Duration) erro
 			return nil
 		case <-time.After(grace):
 			// Force kill all container processes after grace period,
-			// hence `true` argument.
-			if err := l.container.Signal(os.Kill, true); err != nil {
+			// hence `all` argument.
+			if err := l.container.Signal(os.Kill, all); err != nil {
 				return err
 			}
 		}
 	} else {
-		err := l.container.Signal(os.Kill, true)
+		err := l.container.Signal(os.Kill, all)
 		if err != nil {
 			return err
 		}
@@ -355,6 +436,22 @@ func (l *LibcontainerExecutor) handleStats(ch chan *cstructs.TaskResourceUsage,
 		measuredMemStats = ExecutorCgroupV2MeasuredMemStats
 	}
 
+	// cgroups v2 reports the task's peak memory usage in memory.peak, which
+	// libcontainer doesn't read and older kernels don't provide
+	var peakPath string
+	if cgroups.IsCgroup2UnifiedMode() && !l.command.DisableCgroups {
+		if state, err := l.container.State(); err != nil {
+			l.logger.Debug("failed to get container state to find memory.peak", "error", err)
+		} else {
+			path := filepath.Join(state.CgroupPaths[""], "memory.peak")
+			if _, err := os.Stat(path); err == nil {
+				peakPath = path
+				measuredMemStats = append(append([]string{}, ExecutorCgroupV2MeasuredMemStats...), "Max Usage")
+			}
+		}
+	}
+
+	sampled := false
 	for {
 		select {
 		case <-ctx.Done():
@@ -364,6 +461,17 @@ func (l *LibcontainerExecutor) handleStats(ch chan *cstructs.TaskResourceUsage,
 			timer.Reset(interval)
 		}
 
+		// Without a cgroup there is nothing to measure, so report usage with
+		// no measured stats rather than zeroes that look like real values.
+		if l.command.DisableCgroups {
+			select {
+			case <-ctx.Done():
+				return
+			case ch <- unmeasuredResourceUsage():
+			}
+			continue
+		}
+
 		lstats, err := l.container.Stats()
 		if err != nil {
 			l.logger.Warn("error collecting stats", "error", err)
@@ -379,6 +487,18 @@ func (l *LibcontainerExecutor) handleStats(ch chan *cstructs.TaskResourceUsage,
 		ts := time.Now()
 		stats := lstats.CgroupStats
 
+		// A task that has only just started may not have been charged any
+		// memory yet, so hold back the first sample until it has rather
+		// than reporting zeroes that look like real values.
+		if !sampled && stats.MemoryStats.Usage.Usage == 0 && stats.MemoryStats.Stats["rss"] == 0 {
+			if !timer.Stop() {
+				<-timer.C
+			}
+			timer.Reset(firstStatsRetryInterval)
+			continue
+		}
+		sampled = true
+
 		// Memory Related Stats
 		swap := stats.MemoryStats.SwapUsage
 		maxUsage := stats.MemoryStats.Usage.MaxUsage
@@ -396,6 +516,13 @@ func (l *LibcontainerExecutor) handleStats(ch chan *cstructs.TaskResourceUsage,
 			KernelMaxUsage: stats.MemoryStats.KernelUsage.MaxUsage,
 			Measured:       measuredMemStats,
 		}
+		if peakPath != "" {
+			peak, err := readMemoryPeak(peakPath)
+			if err != nil {
+				l.logger.Warn("error collecting peak memory usage", "path", peakPath, "error", err)
+			}
+			ms.MaxUsage = peak
+		}
 
 		// CPU Related Stats
 		totalProcessCPUUsage := float64(stats.CpuStats.CpuUsage.TotalUsage)
@@ -453,8 +580,9 @@ func (l *LibcontainerExecutor) Exec(deadline time.Time, cmd string, args []strin
 		return nil, 0, err
 	}
 
-	waitCh := make(chan *waitResult)
-	defer close(waitCh)
+	// buffered so the wait doesn't block, or send on a closed channel, once
+	// the deadline has passed
+	waitCh := make(chan *waitResult, 1)
 	go l.handleExecWait(waitCh, process)
 
 	select {
@@ -500,6 +628,8 @@ func (l *LibcontainerExecutor) ExecStreaming(ctx context.Context, cmd []string,
 		User: l.userProc.User,
 		Init: false,
 		Cwd:  "/",
+
+		AdditionalGroups: l.userProc.AdditionalGroups,
 	}
 
 	execHelper := &execHelper{
@@ -582,6 +712,12 @@ func configureIsolation(cfg *lconfigs.Config, command *ExecCommand) error {
 	// set the new root directory for the container
 	cfg.Rootfs = command.TaskDir
 
+	// when keeping the host's root filesystem, mounts made by the task must
+	// not propagate back to the host
+	if command.MountNamespaceOnly {
+		cfg.RootPropagation = unix.MS_SLAVE | unix.MS_REC
+	}
+
 	// disable pivot_root if set in the driver's configuration
 	cfg.NoPivotRoot = command.NoPivotRoot
 
@@ -595,6 +731,24 @@ func configureIsolation(cfg *lconfigs.Config, command *ExecCommand) error {
 		})
 	}
 
+	// a private UTS namespace lets the task have its own hostname
+	if command.ModeUTS == IsolationModePrivate {
+		cfg.Namespaces = append(cfg.Namespaces, lconfigs.Namespace{Type: lconfigs.NEWUTS})
+		cfg.Hostname = command.Hostname
+	}
+
+	// root in a private user namespace is an unprivileged user on the host
+	if command.ModeUserns == IsolationModePrivate {
+		cfg.Namespaces = append(cfg.Namespaces, lconfigs.Namespace{Type: lconfigs.NEWUSER})
+		idMap := []lconfigs.IDMap{{
+			ContainerID: 0,
+			HostID:      int(command.UsernsIDOffset),
+			Size:        UsernsIDCount,
+		}}
+		cfg.UidMappings = idMap
+		cfg.GidMappings = idMap
+	}
+
 	// paths to mask using a bind mount to /dev/null to prevent reading
 	cfg.MaskPaths = []string{
 		"/proc/kcore",
@@ -613,6 +767,12 @@ func configureIsolation(cfg *lconfigs.Config, command *ExecCommand) error {
 			return err
 		}
 		cfg.Devices = append(cfg.Devices, devs...)
+
+		// allow access to the devices in the task's cgroup, limited to the
+		// permissions they were requested with
+		for _, dev := range devs {
+			cfg.Cgroups.Resources.Devices = append(cfg.Cgroups.Resources.Devices, &dev.Rule)
+		}
 	}
 
 	cfg.Mounts = []*lconfigs.Mount{
@@ -657,15 +817,55 @@ func configureIsolation(cfg *lconfigs.Config, command *ExecCommand) error {
 		},
 	}
 
+	// sysfs can only be mounted in a user namespace that owns the network
+	// namespace, so a task in a private user namespace gets a read-only bind
+	// of the host's instead
+	if command.ModeUserns == IsolationModePrivate {
+		cfg.Mounts[len(cfg.Mounts)-1] = &lconfigs.Mount{
+			Source:      "/sys",
+			Destination: "/sys",
+			Device:      "bind",
+			Flags:       defaultMountFlags | unix.MS_BIND | unix.MS_REC | unix.MS_RDONLY,
+		}
+	}
+
+	// to keep the host's root filesystem, bind it over the task directory
+	// before anything else is mounted; the host's /sys comes along with it
+	if command.MountNamespaceOnly {
+		hostRoot := &lconfigs.Mount{
+			Source:      "/",
+			Destination: "/",
+			Device:      "bind",
+			Flags:       unix.MS_BIND | unix.MS_REC,
+		}
+		mounts := cfg.Mounts[:len(cfg.Mounts)-1]
+		cfg.Mounts = append([]*lconfigs.Mount{hostRoot}, mounts...)
+	}
+
 	if len(command.Mounts) > 0 {
-		cfg.Mounts = append(cfg.Mounts, cmdMounts(command.Mounts)...)
+		mounts := cmdMounts(command.Mounts)
+		if command.SELinuxRelabel {
+			cfg.MountLabel = selinuxMountLabel()
+			for _, m := range mounts {
+				m.Relabel = "z"
+			}
+		}
+		cfg.Mounts = append(cfg.Mounts, mounts...)
 	}
+	cfg.Mounts = append(cfg.Mounts, cmdTmpfsMounts(command.TmpfsMounts)...)
 
 	return nil
 }
 
 func configureCgroups(cfg *lconfigs.Config, command *ExecCommand) error {
 
+	// An empty set of cgroup paths keeps libcontainer from creating or
+	// joining any cgroup, leaving the task in the executor's cgroup.
+	if command.DisableCgroups {
+		cfg.Cgroups.Paths = map[string]string{}
+		return nil
+	}
+
 	// If resources are not limited then manually create cgroups needed
 	if !command.ResourceLimits {
 		return configureBasicCgroups(cfg)
@@ -674,10 +874,50 @@ func configureCgroups(cfg *lconfigs.Config, command *ExecCommand) error {
 	id := uuid.Generate()
 	cfg.Cgroups.Path = filepath.Join("/", defaultCgroupParent, id)
 
+	if command.PidsLimit > 0 && cgroupControllerAvailable(command, "pids") {
+		cfg.Cgroups.Resources.PidsLimit = command.PidsLimit
+	}
+
 	if command.Resources == nil || command.Resources.NomadResources == nil {
 		return nil
 	}
 
+	if cgroupControllerAvailable(command, "memory") {
+		configureMemoryCgroup(cfg, command)
+	}
+
+	res := command.Resources.NomadResources
+	cpuShares := res.Cpu.CpuShares
+	if cpuShares < 2 {
+		return fmt.Errorf("resources.Cpu.CpuShares must be equal to or greater than 2: %v", cpuShares)
+	}
+
+	// Set the relative CPU shares for this cgroup, and convert for cgroupv2
+	if cgroupControllerAvailable(command, "cpu") {
+		cfg.Cgroups.Resources.CpuShares = uint64(cpuShares)
+		cfg.Cgroups.Resources.CpuWeight = cgroups.ConvertCPUSharesToCgroupV2Value(uint64(cpuShares))
+	}
+
+	// An explicit cpuset takes precedence over the cpuset cgroup managed by
+	// the client, which would otherwise override it.
+	if command.CpusetCpus != "" || command.CpusetMems != "" {
+		if cgroupControllerAvailable(command, "cpuset") {
+			cfg.Cgroups.Resources.CpusetCpus = command.CpusetCpus
+			cfg.Cgroups.Resources.CpusetMems = command.CpusetMems
+		}
+	} else if command.Resources.LinuxResources != nil && command.Resources.LinuxResources.CpusetCgroupPath != "" {
+		cfg.Hooks = lconfigs.Hooks{
+			lconfigs.CreateRuntime: lconfigs.HookList{
+				newSetCPUSetCgroupHook(command.Resources.LinuxResources.CpusetCgroupPath),
+			},
+		}
+	}
+
+	return nil
+}
+
+// configureMemoryCgroup sets the memory limits of the task's cgroup.
+func configureMemoryCgroup(cfg *lconfigs.Config, command *ExecCommand) {
 	// Total amount of memory allowed to consume
 	res := command.Resources.NomadResources
 	memHard, memSoft := res.Memory.MemoryMaxMB, res.Memory.MemoryMB
@@ -688,31 +928,64 @@ func configureCgroups(cfg *lconfigs.Config, command *ExecCommand) error {
 
 	if memHard > 0 {
 		cfg.Cgroups.Resources.Memory = memHard * 1024 * 1024
-		cfg.Cgroups.Resources.MemoryReservation = memSoft * 1024 * 1024
+
+		// With cgroups v2 the soft limit is memory.high, which throttles and
+		// reclaims from the task once exceeded, rather than memory.low that
+		// runc would set from the reservation, which protects its memory.
+		if memSoft > 0 && cgroups.IsCgroup2UnifiedMode() {
+			cfg.Cgroups.Resources.Unified = map[string]string{
+				"memory.high": strconv.FormatInt(memSoft*1024*1024, 10),
+			}
+		} else {
+			cfg.Cgroups.Resources.MemoryReservation = memSoft * 1024 * 1024
+		}
 
 		// Disable swap to avoid issues on the machine
 		var memSwappiness uint64
 		cfg.Cgroups.Resources.MemorySwappiness = &memSwappiness
 	}
 
-	cpuShares := res.Cpu.CpuShares
-	if cpuShares < 2 {
-		return fmt.Errorf("resources.Cpu.CpuShares must be equal to or greater than 2: %v", cpuShares)
+	// Total amount of memory and swap allowed to consume. runc converts this
+	// to the swap limit alone for cgroups v2.
+	if command.MemorySwapMB > 0 {
+		cfg.Cgroups.Resources.MemorySwap = command.MemorySwapMB * 1024 * 1024
 	}
 
-	// Set the relative CPU shares for this cgroup, and convert for cgroupv2
-	cfg.Cgroups.Resources.CpuShares = uint64(cpuShares)
-	cfg.Cgroups.Resources.CpuWeight = cgroups.ConvertCPUSharesToCgroupV2Value(uint64(cpuShares))
+	// A task may choose its own swappiness instead, where a nil swappiness
+	// inherits the parent cgroup's.
+	if command.MemorySwappiness != nil {
+		cfg.Cgroups.Resources.MemorySwappiness = nil
+		if *command.MemorySwappiness >= 0 {
+			memSwappiness := uint64(*command.MemorySwappiness)
+			cfg.Cgroups.Resources.MemorySwappiness = &memSwappiness
+		}
+	}
+}
 
-	if command.Resources.LinuxResources != nil && command.Resources.LinuxResources.CpusetCgroupPath != "" {
-		cfg.Hooks = lconfigs.Hooks{
-			lconfigs.CreateRuntime: lconfigs.HookList{
-				newSetCPUSetCgroupHook(command.Resources.LinuxResources.CpusetCgroupPath),
-			},
+// cgroupControllerAvailable returns whether the limits enforced by the named
+// cgroup controller can be set for the task.
+func cgroupControllerAvailable(command *ExecCommand, name string) bool {
+	if len(command.CgroupControllers) == 0 {
+		return true
+	}
+	for _, c := range command.CgroupControllers {
+		if c == name {
+			return true
 		}
 	}
+	return false
+}
 
-	return nil
+// unmeasuredResourceUsage returns the resource usage reported for tasks that
+// run without a cgroup, where none of the stats are measured.
+func unmeasuredResourceUsage() *cstructs.TaskResourceUsage {
+	return &cstructs.TaskResourceUsage{
+		ResourceUsage: &cstructs.ResourceUsage{
+			MemoryStats: &cstructs.MemoryStats{},
+			CpuStats:    &cstructs.CpuStats{},
+		},
+		Timestamp: time.Now().UTC().UnixNano(),
+	}
 }
 
 func configureBasicCgroups(cfg *lconfigs.Config) error {
@@ -770,9 +1043,32 @@ func newLibcontainerConfig(command *ExecCommand) (*lconfigs.Config, error) {
 	configureCapabilities(cfg, command)
 
 	// children should not inherit Nomad agent oom_score_adj value
-	oomScoreAdj := 0
+	oomScoreAdj := command.OOMScoreAdj
 	cfg.OomScoreAdj = &oomScoreAdj
 
+	cfg.AppArmorProfile = command.ApparmorProfile
+	cfg.NoNewPrivileges = command.NoNewPrivileges
+
+	for _, r := range command.Rlimits {
+		resource, ok := rlimitResources[r.Name]
+		if !ok {
+			return nil, fmt.Errorf("unknown rlimit %q", r.Name)
+		}
+		cfg.Rlimits = append(cfg.Rlimits, lconfigs.Rlimit{
+			Type: resource,
+			Soft: r.Soft,
+			Hard: r.Hard,
+		})
+	}
+
+	if len(command.SeccompProfile) > 0 {
+		profile, err := parseSeccompProfile(command.SeccompProfile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Seccomp = profile
+	}
+
 	if err := configureIsolation(cfg, command); err != nil {
 		return nil, err
 	}
@@ -784,6 +1080,52 @@ func newLibcontainerConfig(command *ExecCommand) (*lconfigs.Config, error) {
 	return cfg, nil
 }
 
+// rlimitResources maps each of RlimitNames to its resource
+var rlimitResources = map[string]int{
+	"as":         unix.RLIMIT_AS,
+	"core":       unix.RLIMIT_CORE,
+	"cpu":        unix.RLIMIT_CPU,
+	"data":       unix.RLIMIT_DATA,
+	"fsize":      unix.RLIMIT_FSIZE,
+	"locks":      unix.RLIMIT_LOCKS,
+	"memlock":    unix.RLIMIT_MEMLOCK,
+	"msgqueue":   unix.RLIMIT_MSGQUEUE,
+	"nice":       unix.RLIMIT_NICE,
+	"nofile":     unix.RLIMIT_NOFILE,
+	"nproc":      unix.RLIMIT_NPROC,
+	"rss":        unix.RLIMIT_RSS,
+	"rtprio":     unix.RLIMIT_RTPRIO,
+	"rttime":     unix.RLIMIT_RTTIME,
+	"sigpending": unix.RLIMIT_SIGPENDING,
+	"stack":      unix.RLIMIT_STACK,
+}
+
+// ValidateSeccompProfile ensures an OCI seccomp profile, in JSON, can be
+// used to filter the syscalls of tasks.
+func ValidateSeccompProfile(profile []byte) error {
+	if _, err := parseSeccompProfile(profile); err != nil {
+		return err
+	}
+	if major, _, _ := seccomp.Version(); major == 0 {
+		return fmt.Errorf("seccomp is not supported by this build of Nomad")
+	}
+	return nil
+}
+
+// parseSeccompProfile converts an OCI seccomp profile, in JSON, into the
+// libcontainer syscall filter.
+func parseSeccompProfile(profile []byte) (*lconfigs.Seccomp, error) {
+	var spec specs.LinuxSeccomp
+	if err := json.Unmarshal(profile, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse seccomp profile: %v", err)
+	}
+	config, err := specconv.SetupSeccomp(&spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid seccomp profile: %v", err)
+	}
+	return config, nil
+}
+
 // cmdDevices converts a list of driver.DeviceConfigs into excutor.Devices.
 func cmdDevices(driverDevices []*drivers.DeviceConfig) ([]*devices.Device, error) {
 	if len(driverDevices) == 0 {
@@ -793,11 +1135,20 @@ func cmdDevices(driverDevices []*drivers.DeviceConfig) ([]*devices.Device, error
 	r := make([]*devices.Device, len(driverDevices))
 
 	for i, d := range driverDevices {
-		ed, err := ldevices.DeviceFromPath(d.HostPath, d.Permissions)
+		// devices without permissions get full access, as with docker
+		perms := d.Permissions
+		if perms == "" {
+			perms = "rwm"
+		}
+		if strings.Trim(perms, "rwm") != "" {
+			return nil, fmt.Errorf("invalid permissions %q for device %s: must only contain r, w and m", d.Permissions, d.HostPath)
+		}
+		ed, err := ldevices.DeviceFromPath(d.HostPath, perms)
 		if err != nil {
 			return nil, fmt.Errorf("failed to make device out for %s: %v", d.HostPath, err)
 		}
 		ed.Path = d.TaskPath
+		ed.Allow = true
 		r[i] = ed
 	}
 
@@ -839,6 +1190,35 @@ func cmdMounts(mounts []*drivers.MountConfig) []*lconfigs.Mount {
 	return r
 }
 
+// selinuxMountLabel returns the SELinux label of the files in a container,
+// or an empty string if SELinux is disabled.
+func selinuxMountLabel() string {
+	if !selinux.GetEnabled() {
+		return ""
+	}
+
+	// Only the mount label is used, and its level is dropped as mounts are
+	// relabelled as shared, so the level reserved for the process is freed.
+	processLabel, mountLabel := selinux.ContainerLabels()
+	selinux.ReleaseLabel(processLabel)
+	return mountLabel
+}
+
+// cmdTmpfsMounts converts a list of TmpfsMounts into libcontainer mounts.
+func cmdTmpfsMounts(mounts []*TmpfsMount) []*lconfigs.Mount {
+	r := make([]*lconfigs.Mount, len(mounts))
+	for i, m := range mounts {
+		r[i] = &lconfigs.Mount{
+			Source:      "tmpfs",
+			Destination: m.TaskPath,
+			Device:      "tmpfs",
+			Flags:       syscall.MS_NOEXEC | syscall.MS_NOSUID | syscall.MS_NODEV,
+			Data:        fmt.Sprintf("mode=%o,size=%d", m.Mode, m.SizeBytes),
+		}
+	}
+	return r
+}
+
 // lookupTaskBin finds the file `bin` in taskDir/local, taskDir in that order, then performs
 // a PATH search inside taskDir. It returns an absolute path. See also executor.lookupBin
 func lookupTaskBin(command *ExecCommand) (string, error) {
@@ -852,19 +1232,29 @@ func lookupTaskBin(command *ExecCommand) (string, error) {
 		return local, nil
 	}
 
-	// Check at the root of the task's directory
-	root := filepath.Join(taskDir, bin)
+	// Check at the root of the task's filesystem
+	rootfs := taskRootfs(command)
+	root := filepath.Join(rootfs, bin)
 	if _, err := os.Stat(root); err == nil {
 		return root, nil
 	}
 
 	if strings.Contains(bin, "/") {
-		return "", fmt.Errorf("file %s not found under path %s", bin, taskDir)
+		return "", fmt.Errorf("file %s not found under path %s", bin, rootfs)
 	}
 
 	path := "/usr/local/bin:/usr/bin:/bin"
 
-	return lookPathIn(path, taskDir, bin)
+	return lookPathIn(path, rootfs, bin)
+}
+
+// taskRootfs returns the host path of the root filesystem of the task, which
+// is the task directory unless the task keeps the host's root filesystem.
+func taskRootfs(command *ExecCommand) string {
+	if command.MountNamespaceOnly {
+		return "/"
+	}
+	return command.TaskDir
 }
 
 // lookPathIn looks for a file with PATH inside the directory root. Like exec.LookPath
@@ -887,6 +1277,42 @@ func lookPathIn(path string, root string, bin string) (string, error) {
 	return "", fmt.Errorf("file %s not found under path %s", bin, root)
 }
 
+// ioprio constants as defined in linux/ioprio.h
+const (
+	ioprioClassShift = 13
+	ioprioWhoProcess = 1
+)
+
+var ioClassToIOPrioClass = map[string]int{
+	IOClassRealtime:   1,
+	IOClassBestEffort: 2,
+	IOClassIdle:       3,
+}
+
+// setSchedulingPriority applies the nice value and I/O scheduling class of the
+// command to the process with the given pid.
+func setSchedulingPriority(pid int, command *ExecCommand) error {
+	if command.Nice != 0 {
+		if err := unix.Setpriority(unix.PRIO_PROCESS, pid, command.Nice); err != nil {
+			return fmt.Errorf("failed to set nice value %d: %v", command.Nice, err)
+		}
+	}
+
+	if command.IOClass != "" {
+		class, ok := ioClassToIOPrioClass[command.IOClass]
+		if !ok {
+			return fmt.Errorf("unknown io class %q", command.IOClass)
+		}
+		ioprio := class<<ioprioClassShift | command.IOPriority
+		_, _, errno := unix.Syscall(unix.SYS_IOPRIO_SET, ioprioWhoProcess, uintptr(pid), uintptr(ioprio))
+		if errno != 0 {
+			return fmt.Errorf("failed to set io priority: %v", errno)
+		}
+	}
+
+	return nil
+}
+
 func newSetCPUSetCgroupHook(cgroupPath string) lconfigs.Hook {
 	return lconfigs.NewFunctionHook(func(state *specs.State) error {
 		return cgroups.WriteCgroupProc(cgroupPath, state.Pid)
@@ -19,6 +19,7 @@ import (
 	"github.com/hashicorp/nomad/drivers/shared/capabilities"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
 	tu "github.com/hashicorp/nomad/testutil"
 	"github.com/opencontainers/runc/libcontainer/cgroups"
@@ -664,6 +665,7 @@ func TestExecutor_cmdDevices(t *testing.T) {
 			Major:       1,
 			Minor:       3,
 			Permissions: "rwm",
+			Allow:       true,
 		},
 		Path: "/task/dev/null",
 	}
@@ -680,6 +682,38 @@ func TestExecutor_cmdDevices(t *testing.T) {
 	d.Gid = 0
 
 	require.EqualValues(t, expected, d)
+
+	// devices without permissions are given full access
+	input[0].Permissions = ""
+	found, err = cmdDevices(input)
+	require.NoError(t, err)
+	require.Equal(t, devices.Permissions("rwm"), found[0].Permissions)
+
+	input[0].Permissions = "rx"
+	_, err = cmdDevices(input)
+	require.EqualError(t, err, `invalid permissions "rx" for device /dev/null: must only contain r, w and m`)
+}
+
+func TestExecutor_rlimitResources(t *testing.T) {
+	ci.Parallel(t)
+
+	// every rlimit the driver accepts can be set
+	names := make([]string, 0, len(rlimitResources))
+	for name := range rlimitResources {
+		names = append(names, name)
+	}
+	require.ElementsMatch(t, RlimitNames, names)
+
+	cfg, err := newLibcontainerConfig(&ExecCommand{
+		Rlimits: []*Rlimit{{Name: "nofile", Soft: 1024, Hard: 4096}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []lconfigs.Rlimit{{Type: unix.RLIMIT_NOFILE, Soft: 1024, Hard: 4096}}, cfg.Rlimits)
+
+	_, err = newLibcontainerConfig(&ExecCommand{
+		Rlimits: []*Rlimit{{Name: "files", Soft: 1, Hard: 1}},
+	})
+	require.EqualError(t, err, `unknown rlimit "files"`)
 }
 
 func TestExecutor_cmdMounts(t *testing.T) {
@@ -717,6 +751,142 @@ func TestExecutor_cmdMounts(t *testing.T) {
 	require.EqualValues(t, expected, cmdMounts(input))
 }
 
+func TestExecutor_configureIsolation_SELinuxRelabel(t *testing.T) {
+	ci.Parallel(t)
+
+	command := &ExecCommand{
+		TaskDir: "/tmp/task",
+		Mounts: []*drivers.MountConfig{
+			{HostPath: "/host/path", TaskPath: "/task/path"},
+		},
+		TmpfsMounts: []*TmpfsMount{
+			{TaskPath: "/scratch", SizeBytes: 1024, Mode: 01777},
+		},
+		SELinuxRelabel: true,
+	}
+	cfg := &lconfigs.Config{}
+	require.NoError(t, configureIsolation(cfg, command))
+
+	// only the host paths mounted into the task are relabelled
+	var relabelled []string
+	for _, m := range cfg.Mounts {
+		if m.Relabel != "" {
+			require.Equal(t, "z", m.Relabel)
+			relabelled = append(relabelled, m.Destination)
+		}
+	}
+	require.Equal(t, []string{"/task/path"}, relabelled)
+}
+
+func TestExecutor_configureCgroups_MemorySoftLimit(t *testing.T) {
+	ci.Parallel(t)
+
+	command := &ExecCommand{
+		ResourceLimits: true,
+		Resources: &drivers.Resources{
+			NomadResources: &structs.AllocatedTaskResources{
+				Memory: structs.AllocatedMemoryResources{
+					MemoryMB:    256,
+					MemoryMaxMB: 512,
+				},
+				Cpu: structs.AllocatedCpuResources{
+					CpuShares: 500,
+				},
+			},
+		},
+	}
+	cfg := &lconfigs.Config{
+		Cgroups: &lconfigs.Cgroup{
+			Resources: &lconfigs.Resources{},
+		},
+	}
+	require.NoError(t, configureCgroups(cfg, command))
+
+	// the memory_max is the hard limit, and the memory the soft limit
+	res := cfg.Cgroups.Resources
+	require.EqualValues(t, 512*1024*1024, res.Memory)
+	if cgroups.IsCgroup2UnifiedMode() {
+		require.Zero(t, res.MemoryReservation)
+		require.Equal(t, map[string]string{"memory.high": "268435456"}, res.Unified)
+	} else {
+		require.EqualValues(t, 256*1024*1024, res.MemoryReservation)
+		require.Empty(t, res.Unified)
+	}
+}
+
+func TestExecutor_configureCgroups_UnavailableControllers(t *testing.T) {
+	ci.Parallel(t)
+
+	command := &ExecCommand{
+		ResourceLimits: true,
+		Resources: &drivers.Resources{
+			NomadResources: &structs.AllocatedTaskResources{
+				Memory: structs.AllocatedMemoryResources{
+					MemoryMB: 256,
+				},
+				Cpu: structs.AllocatedCpuResources{
+					CpuShares: 500,
+				},
+			},
+		},
+		CpusetCpus:        "0",
+		PidsLimit:         100,
+		CgroupControllers: []string{"memory", "pids"},
+	}
+	cfg := &lconfigs.Config{
+		Cgroups: &lconfigs.Cgroup{
+			Resources: &lconfigs.Resources{},
+		},
+	}
+	require.NoError(t, configureCgroups(cfg, command))
+
+	// only the limits of the available controllers are set
+	res := cfg.Cgroups.Resources
+	require.EqualValues(t, 256*1024*1024, res.Memory)
+	require.EqualValues(t, 100, res.PidsLimit)
+	require.Zero(t, res.CpuShares)
+	require.Zero(t, res.CpuWeight)
+	require.Empty(t, res.CpusetCpus)
+}
+
+func TestExecutor_readOOMKills(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	// cgroups v1
+	kills, err := readOOMKills(write("memory.oom_control", "oom_kill_disable 0\nunder_oom 0\noom_kill 2\n"))
+	require.NoError(t, err)
+	require.EqualValues(t, 2, kills)
+
+	// cgroups v2
+	kills, err = readOOMKills(write("memory.events", "low 0\nhigh 0\nmax 3\noom 1\noom_kill 0\n"))
+	require.NoError(t, err)
+	require.Zero(t, kills)
+
+	// kernels older than 4.13 don't count kills
+	_, err = readOOMKills(write("old.oom_control", "oom_kill_disable 0\nunder_oom 0\n"))
+	require.Error(t, err)
+}
+
+func TestExecutor_readMemoryPeak(t *testing.T) {
+	ci.Parallel(t)
+
+	path := filepath.Join(t.TempDir(), "memory.peak")
+	require.NoError(t, ioutil.WriteFile(path, []byte("67108864\n"), 0644))
+	peak, err := readMemoryPeak(path)
+	require.NoError(t, err)
+	require.EqualValues(t, 67108864, peak)
+
+	_, err = readMemoryPeak(filepath.Join(t.TempDir(), "missing"))
+	require.Error(t, err)
+}
+
 // TestUniversalExecutor_NoCgroup asserts that commands are executed in the
 // same cgroup as parent process
 func TestUniversalExecutor_NoCgroup(t *testing.T) {
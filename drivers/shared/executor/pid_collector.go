@@ -34,6 +34,22 @@ type nomadPid struct {
 	cpuStatsTotal *stats.CpuStats
 	cpuStatsUser  *stats.CpuStats
 	cpuStatsSys   *stats.CpuStats
+
+	// key and proc don't change for the life of the pid, so they are built
+	// once rather than on every stats sample
+	key  string
+	proc *process.Process
+}
+
+func newNomadPid(pid int) *nomadPid {
+	return &nomadPid{
+		pid:           pid,
+		cpuStatsTotal: stats.NewCpuStats(),
+		cpuStatsUser:  stats.NewCpuStats(),
+		cpuStatsSys:   stats.NewCpuStats(),
+		key:           strconv.Itoa(pid),
+		proc:          &process.Process{Pid: int32(pid)},
+	}
 }
 
 // allPidGetter is a func which is used by the pid collector to gather
@@ -119,32 +135,29 @@ func scanPids(parentPid int, allPids []ps.Process) (map[int]*nomadPid, error) {
 
 	res := make(map[int]*nomadPid)
 	for pid := range processFamily {
-		np := nomadPid{
-			pid:           pid,
-			cpuStatsTotal: stats.NewCpuStats(),
-			cpuStatsUser:  stats.NewCpuStats(),
-			cpuStatsSys:   stats.NewCpuStats(),
-		}
-		res[pid] = &np
+		res[pid] = newNomadPid(pid)
 	}
 	return res, nil
 }
 
 // pidStats returns the resource usage stats per pid
 func (c *pidCollector) pidStats() (map[string]*drivers.ResourceUsage, error) {
-	stats := make(map[string]*drivers.ResourceUsage)
 	c.pidLock.RLock()
-	pids := make(map[int]*nomadPid, len(c.pids))
-	for k, v := range c.pids {
-		pids[k] = v
+	pids := make([]*nomadPid, 0, len(c.pids))
+	for _, np := range c.pids {
+		pids = append(pids, np)
 	}
 	c.pidLock.RUnlock()
-	for pid, np := range pids {
-		p, err := process.NewProcess(int32(pid))
-		if err != nil {
-			c.logger.Trace("unable to create new process", "pid", pid, "error", err)
+
+	stats := make(map[string]*drivers.ResourceUsage, len(pids))
+	for _, np := range pids {
+		// only check that the pid is still running, as creating a new
+		// process would also parse its static create time every sample
+		if exists, err := process.PidExists(int32(np.pid)); !exists {
+			c.logger.Trace("unable to find process", "pid", np.pid, "error", err)
 			continue
 		}
+		p := np.proc
 		ms := &drivers.MemoryStats{}
 		if memInfo, err := p.MemoryInfo(); err == nil {
 			ms.RSS = memInfo.RSS
@@ -161,7 +174,7 @@ func (c *pidCollector) pidStats() (map[string]*drivers.ResourceUsage, error) {
 			// calculate cpu usage percent
 			cs.Percent = np.cpuStatsTotal.Percent(cpuStats.Total() * float64(time.Second))
 		}
-		stats[strconv.Itoa(pid)] = &drivers.ResourceUsage{MemoryStats: ms, CpuStats: cs}
+		stats[np.key] = &drivers.ResourceUsage{MemoryStats: ms, CpuStats: cs}
 	}
 
 	return stats, nil
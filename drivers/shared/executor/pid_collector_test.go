@@ -1,10 +1,16 @@
 package executor
 
 import (
+	"os"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/plugins/drivers"
 	"github.com/mitchellh/go-ps"
+	"github.com/shirou/gopsutil/v3/process"
 )
 
 func TestScanPids(t *testing.T) {
@@ -24,6 +30,100 @@ func TestScanPids(t *testing.T) {
 	}
 }
 
+// pidStatsBaseline is pidStats as it was before each pid's key and process
+// were built once, kept as the baseline to measure its allocations against.
+func pidStatsBaseline(c *pidCollector) (map[string]*drivers.ResourceUsage, error) {
+	stats := make(map[string]*drivers.ResourceUsage)
+	c.pidLock.RLock()
+	pids := make(map[int]*nomadPid, len(c.pids))
+	for k, v := range c.pids {
+		pids[k] = v
+	}
+	c.pidLock.RUnlock()
+	for pid, np := range pids {
+		p, err := process.NewProcess(int32(pid))
+		if err != nil {
+			c.logger.Trace("unable to create new process", "pid", pid, "error", err)
+			continue
+		}
+		ms := &drivers.MemoryStats{}
+		if memInfo, err := p.MemoryInfo(); err == nil {
+			ms.RSS = memInfo.RSS
+			ms.Swap = memInfo.Swap
+			ms.Measured = ExecutorBasicMeasuredMemStats
+		}
+
+		cs := &drivers.CpuStats{}
+		if cpuStats, err := p.Times(); err == nil {
+			cs.SystemMode = np.cpuStatsSys.Percent(cpuStats.System * float64(time.Second))
+			cs.UserMode = np.cpuStatsUser.Percent(cpuStats.User * float64(time.Second))
+			cs.Measured = ExecutorBasicMeasuredCpuStats
+
+			// calculate cpu usage percent
+			cs.Percent = np.cpuStatsTotal.Percent(cpuStats.Total() * float64(time.Second))
+		}
+		stats[strconv.Itoa(pid)] = &drivers.ResourceUsage{MemoryStats: ms, CpuStats: cs}
+	}
+
+	return stats, nil
+}
+
+func BenchmarkExecStats(b *testing.B) {
+	allPids, err := ps.Processes()
+	if err != nil {
+		b.Fatalf("error: %v", err)
+	}
+
+	// collect stats for this process and its parent's process tree, so
+	// that there is more than one pid to sample
+	pids, err := scanPids(os.Getppid(), allPids)
+	if err != nil {
+		b.Fatalf("error: %v", err)
+	}
+	c := newPidCollector(testlog.HCLogger(b))
+	c.pids = pids
+
+	for _, bc := range []struct {
+		name     string
+		pidStats func() (map[string]*drivers.ResourceUsage, error)
+	}{
+		{"baseline", func() (map[string]*drivers.ResourceUsage, error) { return pidStatsBaseline(c) }},
+		{"current", c.pidStats},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := bc.pidStats(); err != nil {
+					b.Fatalf("error: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// TestPidStats_Allocs asserts that sampling stats allocates less than the
+// baseline implementation. It isn't parallel, as allocations are counted
+// across the whole process.
+func TestPidStats_Allocs(t *testing.T) {
+	pid := os.Getpid()
+	c := newPidCollector(testlog.HCLogger(t))
+	c.pids = map[int]*nomadPid{pid: newNomadPid(pid)}
+
+	baseline := testing.AllocsPerRun(50, func() {
+		if _, err := pidStatsBaseline(c); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	})
+	current := testing.AllocsPerRun(50, func() {
+		if _, err := c.pidStats(); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	})
+	if current >= baseline {
+		t.Fatalf("expected fewer allocations than the baseline's %v per sample, got %v", baseline, current)
+	}
+}
+
 type FakeProcess struct {
 	pid  int
 	ppid int
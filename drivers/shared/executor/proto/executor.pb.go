@@ -8,6 +8,7 @@ import (
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
 	proto1 "github.com/hashicorp/nomad/plugins/drivers/proto"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
@@ -46,6 +47,30 @@ type LaunchRequest struct {
 	CpusetCgroup         string                       `protobuf:"bytes,17,opt,name=cpuset_cgroup,json=cpusetCgroup,proto3" json:"cpuset_cgroup,omitempty"`
 	AllowCaps            []string                     `protobuf:"bytes,18,rep,name=allow_caps,json=allowCaps,proto3" json:"allow_caps,omitempty"`
 	Capabilities         []string                     `protobuf:"bytes,19,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	Nice                 int32                        `protobuf:"varint,20,opt,name=nice,proto3" json:"nice,omitempty"`
+	IoClass              string                       `protobuf:"bytes,21,opt,name=io_class,json=ioClass,proto3" json:"io_class,omitempty"`
+	IoPriority           int32                        `protobuf:"varint,22,opt,name=io_priority,json=ioPriority,proto3" json:"io_priority,omitempty"`
+	CpusetCpus           string                       `protobuf:"bytes,23,opt,name=cpuset_cpus,json=cpusetCpus,proto3" json:"cpuset_cpus,omitempty"`
+	CpusetMems           string                       `protobuf:"bytes,24,opt,name=cpuset_mems,json=cpusetMems,proto3" json:"cpuset_mems,omitempty"`
+	MountNamespaceOnly   bool                         `protobuf:"varint,25,opt,name=mount_namespace_only,json=mountNamespaceOnly,proto3" json:"mount_namespace_only,omitempty"`
+	DisableCgroups       bool                         `protobuf:"varint,26,opt,name=disable_cgroups,json=disableCgroups,proto3" json:"disable_cgroups,omitempty"`
+	MemorySwappiness     *wrappers.Int64Value         `protobuf:"bytes,27,opt,name=memory_swappiness,json=memorySwappiness,proto3" json:"memory_swappiness,omitempty"`
+	MemorySwapMb         int64                        `protobuf:"varint,28,opt,name=memory_swap_mb,json=memorySwapMb,proto3" json:"memory_swap_mb,omitempty"`
+	ApparmorProfile      string                       `protobuf:"bytes,29,opt,name=apparmor_profile,json=apparmorProfile,proto3" json:"apparmor_profile,omitempty"`
+	OomScoreAdj          int32                        `protobuf:"varint,30,opt,name=oom_score_adj,json=oomScoreAdj,proto3" json:"oom_score_adj,omitempty"`
+	NoNewPrivileges      bool                         `protobuf:"varint,31,opt,name=no_new_privileges,json=noNewPrivileges,proto3" json:"no_new_privileges,omitempty"`
+	UsernsMode           string                       `protobuf:"bytes,32,opt,name=userns_mode,json=usernsMode,proto3" json:"userns_mode,omitempty"`
+	UsernsIdOffset       uint32                       `protobuf:"varint,33,opt,name=userns_id_offset,json=usernsIdOffset,proto3" json:"userns_id_offset,omitempty"`
+	TmpfsMounts          []*TmpfsMount                `protobuf:"bytes,34,rep,name=tmpfs_mounts,json=tmpfsMounts,proto3" json:"tmpfs_mounts,omitempty"`
+	UtsMode              string                       `protobuf:"bytes,35,opt,name=uts_mode,json=utsMode,proto3" json:"uts_mode,omitempty"`
+	Hostname             string                       `protobuf:"bytes,36,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	SeccompProfile       []byte                       `protobuf:"bytes,37,opt,name=seccomp_profile,json=seccompProfile,proto3" json:"seccomp_profile,omitempty"`
+	Rlimits              []*Rlimit                    `protobuf:"bytes,38,rep,name=rlimits,proto3" json:"rlimits,omitempty"`
+	CgroupControllers    []string                     `protobuf:"bytes,39,rep,name=cgroup_controllers,json=cgroupControllers,proto3" json:"cgroup_controllers,omitempty"`
+	PidsLimit            int64                        `protobuf:"varint,40,opt,name=pids_limit,json=pidsLimit,proto3" json:"pids_limit,omitempty"`
+	AdditionalGroups     []uint32                     `protobuf:"varint,41,rep,packed,name=additional_groups,json=additionalGroups,proto3" json:"additional_groups,omitempty"`
+	WorkDir              string                       `protobuf:"bytes,42,opt,name=work_dir,json=workDir,proto3" json:"work_dir,omitempty"`
+	SelinuxRelabel       bool                         `protobuf:"varint,43,opt,name=selinux_relabel,json=selinuxRelabel,proto3" json:"selinux_relabel,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
 	XXX_unrecognized     []byte                       `json:"-"`
 	XXX_sizecache        int32                        `json:"-"`
@@ -209,6 +234,284 @@ func (m *LaunchRequest) GetCapabilities() []string {
 	return nil
 }
 
+func (m *LaunchRequest) GetNice() int32 {
+	if m != nil {
+		return m.Nice
+	}
+	return 0
+}
+
+func (m *LaunchRequest) GetIoClass() string {
+	if m != nil {
+		return m.IoClass
+	}
+	return ""
+}
+
+func (m *LaunchRequest) GetIoPriority() int32 {
+	if m != nil {
+		return m.IoPriority
+	}
+	return 0
+}
+
+func (m *LaunchRequest) GetCpusetCpus() string {
+	if m != nil {
+		return m.CpusetCpus
+	}
+	return ""
+}
+
+func (m *LaunchRequest) GetCpusetMems() string {
+	if m != nil {
+		return m.CpusetMems
+	}
+	return ""
+}
+
+func (m *LaunchRequest) GetMountNamespaceOnly() bool {
+	if m != nil {
+		return m.MountNamespaceOnly
+	}
+	return false
+}
+
+func (m *LaunchRequest) GetDisableCgroups() bool {
+	if m != nil {
+		return m.DisableCgroups
+	}
+	return false
+}
+
+func (m *LaunchRequest) GetMemorySwappiness() *wrappers.Int64Value {
+	if m != nil {
+		return m.MemorySwappiness
+	}
+	return nil
+}
+
+func (m *LaunchRequest) GetMemorySwapMb() int64 {
+	if m != nil {
+		return m.MemorySwapMb
+	}
+	return 0
+}
+
+func (m *LaunchRequest) GetApparmorProfile() string {
+	if m != nil {
+		return m.ApparmorProfile
+	}
+	return ""
+}
+
+func (m *LaunchRequest) GetOomScoreAdj() int32 {
+	if m != nil {
+		return m.OomScoreAdj
+	}
+	return 0
+}
+
+func (m *LaunchRequest) GetNoNewPrivileges() bool {
+	if m != nil {
+		return m.NoNewPrivileges
+	}
+	return false
+}
+
+func (m *LaunchRequest) GetUsernsMode() string {
+	if m != nil {
+		return m.UsernsMode
+	}
+	return ""
+}
+
+func (m *LaunchRequest) GetUsernsIdOffset() uint32 {
+	if m != nil {
+		return m.UsernsIdOffset
+	}
+	return 0
+}
+
+func (m *LaunchRequest) GetTmpfsMounts() []*TmpfsMount {
+	if m != nil {
+		return m.TmpfsMounts
+	}
+	return nil
+}
+
+func (m *LaunchRequest) GetUtsMode() string {
+	if m != nil {
+		return m.UtsMode
+	}
+	return ""
+}
+
+func (m *LaunchRequest) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *LaunchRequest) GetSeccompProfile() []byte {
+	if m != nil {
+		return m.SeccompProfile
+	}
+	return nil
+}
+
+func (m *LaunchRequest) GetRlimits() []*Rlimit {
+	if m != nil {
+		return m.Rlimits
+	}
+	return nil
+}
+
+func (m *LaunchRequest) GetCgroupControllers() []string {
+	if m != nil {
+		return m.CgroupControllers
+	}
+	return nil
+}
+
+func (m *LaunchRequest) GetPidsLimit() int64 {
+	if m != nil {
+		return m.PidsLimit
+	}
+	return 0
+}
+
+func (m *LaunchRequest) GetAdditionalGroups() []uint32 {
+	if m != nil {
+		return m.AdditionalGroups
+	}
+	return nil
+}
+
+func (m *LaunchRequest) GetWorkDir() string {
+	if m != nil {
+		return m.WorkDir
+	}
+	return ""
+}
+
+func (m *LaunchRequest) GetSelinuxRelabel() bool {
+	if m != nil {
+		return m.SelinuxRelabel
+	}
+	return false
+}
+
+type TmpfsMount struct {
+	TaskPath             string   `protobuf:"bytes,1,opt,name=task_path,json=taskPath,proto3" json:"task_path,omitempty"`
+	SizeBytes            int64    `protobuf:"varint,2,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	Mode                 uint32   `protobuf:"varint,3,opt,name=mode,proto3" json:"mode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TmpfsMount) Reset()         { *m = TmpfsMount{} }
+func (m *TmpfsMount) String() string { return proto.CompactTextString(m) }
+func (*TmpfsMount) ProtoMessage()    {}
+func (*TmpfsMount) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{1}
+}
+
+func (m *TmpfsMount) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TmpfsMount.Unmarshal(m, b)
+}
+func (m *TmpfsMount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TmpfsMount.Marshal(b, m, deterministic)
+}
+func (m *TmpfsMount) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TmpfsMount.Merge(m, src)
+}
+func (m *TmpfsMount) XXX_Size() int {
+	return xxx_messageInfo_TmpfsMount.Size(m)
+}
+func (m *TmpfsMount) XXX_DiscardUnknown() {
+	xxx_messageInfo_TmpfsMount.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TmpfsMount proto.InternalMessageInfo
+
+func (m *TmpfsMount) GetTaskPath() string {
+	if m != nil {
+		return m.TaskPath
+	}
+	return ""
+}
+
+func (m *TmpfsMount) GetSizeBytes() int64 {
+	if m != nil {
+		return m.SizeBytes
+	}
+	return 0
+}
+
+func (m *TmpfsMount) GetMode() uint32 {
+	if m != nil {
+		return m.Mode
+	}
+	return 0
+}
+
+type Rlimit struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Soft                 uint64   `protobuf:"varint,2,opt,name=soft,proto3" json:"soft,omitempty"`
+	Hard                 uint64   `protobuf:"varint,3,opt,name=hard,proto3" json:"hard,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Rlimit) Reset()         { *m = Rlimit{} }
+func (m *Rlimit) String() string { return proto.CompactTextString(m) }
+func (*Rlimit) ProtoMessage()    {}
+func (*Rlimit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{2}
+}
+
+func (m *Rlimit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Rlimit.Unmarshal(m, b)
+}
+func (m *Rlimit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Rlimit.Marshal(b, m, deterministic)
+}
+func (m *Rlimit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Rlimit.Merge(m, src)
+}
+func (m *Rlimit) XXX_Size() int {
+	return xxx_messageInfo_Rlimit.Size(m)
+}
+func (m *Rlimit) XXX_DiscardUnknown() {
+	xxx_messageInfo_Rlimit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Rlimit proto.InternalMessageInfo
+
+func (m *Rlimit) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Rlimit) GetSoft() uint64 {
+	if m != nil {
+		return m.Soft
+	}
+	return 0
+}
+
+func (m *Rlimit) GetHard() uint64 {
+	if m != nil {
+		return m.Hard
+	}
+	return 0
+}
+
 type LaunchResponse struct {
 	Process              *ProcessState `protobuf:"bytes,1,opt,name=process,proto3" json:"process,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
@@ -220,7 +523,7 @@ func (m *LaunchResponse) Reset()         { *m = LaunchResponse{} }
 func (m *LaunchResponse) String() string { return proto.CompactTextString(m) }
 func (*LaunchResponse) ProtoMessage()    {}
 func (*LaunchResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{1}
+	return fileDescriptor_66b85426380683f3, []int{3}
 }
 
 func (m *LaunchResponse) XXX_Unmarshal(b []byte) error {
@@ -258,7 +561,7 @@ func (m *WaitRequest) Reset()         { *m = WaitRequest{} }
 func (m *WaitRequest) String() string { return proto.CompactTextString(m) }
 func (*WaitRequest) ProtoMessage()    {}
 func (*WaitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{2}
+	return fileDescriptor_66b85426380683f3, []int{4}
 }
 
 func (m *WaitRequest) XXX_Unmarshal(b []byte) error {
@@ -290,7 +593,7 @@ func (m *WaitResponse) Reset()         { *m = WaitResponse{} }
 func (m *WaitResponse) String() string { return proto.CompactTextString(m) }
 func (*WaitResponse) ProtoMessage()    {}
 func (*WaitResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{3}
+	return fileDescriptor_66b85426380683f3, []int{5}
 }
 
 func (m *WaitResponse) XXX_Unmarshal(b []byte) error {
@@ -330,7 +633,7 @@ func (m *ShutdownRequest) Reset()         { *m = ShutdownRequest{} }
 func (m *ShutdownRequest) String() string { return proto.CompactTextString(m) }
 func (*ShutdownRequest) ProtoMessage()    {}
 func (*ShutdownRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{4}
+	return fileDescriptor_66b85426380683f3, []int{6}
 }
 
 func (m *ShutdownRequest) XXX_Unmarshal(b []byte) error {
@@ -375,7 +678,7 @@ func (m *ShutdownResponse) Reset()         { *m = ShutdownResponse{} }
 func (m *ShutdownResponse) String() string { return proto.CompactTextString(m) }
 func (*ShutdownResponse) ProtoMessage()    {}
 func (*ShutdownResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{5}
+	return fileDescriptor_66b85426380683f3, []int{7}
 }
 
 func (m *ShutdownResponse) XXX_Unmarshal(b []byte) error {
@@ -407,7 +710,7 @@ func (m *UpdateResourcesRequest) Reset()         { *m = UpdateResourcesRequest{}
 func (m *UpdateResourcesRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateResourcesRequest) ProtoMessage()    {}
 func (*UpdateResourcesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{6}
+	return fileDescriptor_66b85426380683f3, []int{8}
 }
 
 func (m *UpdateResourcesRequest) XXX_Unmarshal(b []byte) error {
@@ -445,7 +748,7 @@ func (m *UpdateResourcesResponse) Reset()         { *m = UpdateResourcesResponse
 func (m *UpdateResourcesResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateResourcesResponse) ProtoMessage()    {}
 func (*UpdateResourcesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{7}
+	return fileDescriptor_66b85426380683f3, []int{9}
 }
 
 func (m *UpdateResourcesResponse) XXX_Unmarshal(b []byte) error {
@@ -476,7 +779,7 @@ func (m *VersionRequest) Reset()         { *m = VersionRequest{} }
 func (m *VersionRequest) String() string { return proto.CompactTextString(m) }
 func (*VersionRequest) ProtoMessage()    {}
 func (*VersionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{8}
+	return fileDescriptor_66b85426380683f3, []int{10}
 }
 
 func (m *VersionRequest) XXX_Unmarshal(b []byte) error {
@@ -508,7 +811,7 @@ func (m *VersionResponse) Reset()         { *m = VersionResponse{} }
 func (m *VersionResponse) String() string { return proto.CompactTextString(m) }
 func (*VersionResponse) ProtoMessage()    {}
 func (*VersionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{9}
+	return fileDescriptor_66b85426380683f3, []int{11}
 }
 
 func (m *VersionResponse) XXX_Unmarshal(b []byte) error {
@@ -547,7 +850,7 @@ func (m *StatsRequest) Reset()         { *m = StatsRequest{} }
 func (m *StatsRequest) String() string { return proto.CompactTextString(m) }
 func (*StatsRequest) ProtoMessage()    {}
 func (*StatsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{10}
+	return fileDescriptor_66b85426380683f3, []int{12}
 }
 
 func (m *StatsRequest) XXX_Unmarshal(b []byte) error {
@@ -586,7 +889,7 @@ func (m *StatsResponse) Reset()         { *m = StatsResponse{} }
 func (m *StatsResponse) String() string { return proto.CompactTextString(m) }
 func (*StatsResponse) ProtoMessage()    {}
 func (*StatsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{11}
+	return fileDescriptor_66b85426380683f3, []int{13}
 }
 
 func (m *StatsResponse) XXX_Unmarshal(b []byte) error {
@@ -625,7 +928,7 @@ func (m *SignalRequest) Reset()         { *m = SignalRequest{} }
 func (m *SignalRequest) String() string { return proto.CompactTextString(m) }
 func (*SignalRequest) ProtoMessage()    {}
 func (*SignalRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{12}
+	return fileDescriptor_66b85426380683f3, []int{14}
 }
 
 func (m *SignalRequest) XXX_Unmarshal(b []byte) error {
@@ -663,7 +966,7 @@ func (m *SignalResponse) Reset()         { *m = SignalResponse{} }
 func (m *SignalResponse) String() string { return proto.CompactTextString(m) }
 func (*SignalResponse) ProtoMessage()    {}
 func (*SignalResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{13}
+	return fileDescriptor_66b85426380683f3, []int{15}
 }
 
 func (m *SignalResponse) XXX_Unmarshal(b []byte) error {
@@ -697,7 +1000,7 @@ func (m *ExecRequest) Reset()         { *m = ExecRequest{} }
 func (m *ExecRequest) String() string { return proto.CompactTextString(m) }
 func (*ExecRequest) ProtoMessage()    {}
 func (*ExecRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{14}
+	return fileDescriptor_66b85426380683f3, []int{16}
 }
 
 func (m *ExecRequest) XXX_Unmarshal(b []byte) error {
@@ -751,7 +1054,7 @@ func (m *ExecResponse) Reset()         { *m = ExecResponse{} }
 func (m *ExecResponse) String() string { return proto.CompactTextString(m) }
 func (*ExecResponse) ProtoMessage()    {}
 func (*ExecResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{15}
+	return fileDescriptor_66b85426380683f3, []int{17}
 }
 
 func (m *ExecResponse) XXX_Unmarshal(b []byte) error {
@@ -791,6 +1094,7 @@ type ProcessState struct {
 	ExitCode             int32                `protobuf:"varint,2,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
 	Signal               int32                `protobuf:"varint,3,opt,name=signal,proto3" json:"signal,omitempty"`
 	Time                 *timestamp.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+	OomKilled            bool                 `protobuf:"varint,5,opt,name=oom_killed,json=oomKilled,proto3" json:"oom_killed,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -800,7 +1104,7 @@ func (m *ProcessState) Reset()         { *m = ProcessState{} }
 func (m *ProcessState) String() string { return proto.CompactTextString(m) }
 func (*ProcessState) ProtoMessage()    {}
 func (*ProcessState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{16}
+	return fileDescriptor_66b85426380683f3, []int{18}
 }
 
 func (m *ProcessState) XXX_Unmarshal(b []byte) error {
@@ -849,8 +1153,17 @@ func (m *ProcessState) GetTime() *timestamp.Timestamp {
 	return nil
 }
 
+func (m *ProcessState) GetOomKilled() bool {
+	if m != nil {
+		return m.OomKilled
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*LaunchRequest)(nil), "hashicorp.nomad.plugins.executor.proto.LaunchRequest")
+	proto.RegisterType((*TmpfsMount)(nil), "hashicorp.nomad.plugins.executor.proto.TmpfsMount")
+	proto.RegisterType((*Rlimit)(nil), "hashicorp.nomad.plugins.executor.proto.Rlimit")
 	proto.RegisterType((*LaunchResponse)(nil), "hashicorp.nomad.plugins.executor.proto.LaunchResponse")
 	proto.RegisterType((*WaitRequest)(nil), "hashicorp.nomad.plugins.executor.proto.WaitRequest")
 	proto.RegisterType((*WaitResponse)(nil), "hashicorp.nomad.plugins.executor.proto.WaitResponse")
@@ -874,74 +1187,109 @@ func init() {
 }
 
 var fileDescriptor_66b85426380683f3 = []byte{
-	// 1058 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0x6d, 0x6f, 0x1b, 0x45,
-	0x10, 0xee, 0xc5, 0x89, 0x5f, 0xc6, 0x76, 0xe2, 0x2e, 0xa8, 0x5c, 0x8d, 0x50, 0xcd, 0x21, 0x51,
-	0x0b, 0xca, 0x25, 0x4a, 0xdf, 0x90, 0x90, 0x28, 0x22, 0x2d, 0xa8, 0x52, 0x1a, 0x45, 0x97, 0x42,
-	0x25, 0x3e, 0x70, 0x6c, 0xee, 0xb6, 0xf6, 0x2a, 0xf6, 0xed, 0xb2, 0xbb, 0xe7, 0x04, 0x09, 0x89,
-	0x4f, 0xfc, 0x03, 0x90, 0xf8, 0x31, 0xfc, 0x38, 0xb4, 0x6f, 0x17, 0x3b, 0x2d, 0xd5, 0xb9, 0x88,
-	0x4f, 0xbe, 0x1d, 0x3f, 0xcf, 0xcc, 0xec, 0xce, 0xcc, 0x33, 0x70, 0x27, 0x17, 0x74, 0x41, 0x84,
-	0xdc, 0x95, 0x53, 0x2c, 0x48, 0xbe, 0x4b, 0x2e, 0x48, 0x56, 0x2a, 0x26, 0x76, 0xb9, 0x60, 0x8a,
-	0x55, 0xc7, 0xd8, 0x1c, 0xd1, 0xc7, 0x53, 0x2c, 0xa7, 0x34, 0x63, 0x82, 0xc7, 0x05, 0x9b, 0xe3,
-	0x3c, 0xe6, 0xb3, 0x72, 0x42, 0x0b, 0x19, 0xaf, 0xe2, 0x86, 0xb7, 0x26, 0x8c, 0x4d, 0x66, 0xc4,
-	0x3a, 0x39, 0x2d, 0x5f, 0xee, 0x2a, 0x3a, 0x27, 0x52, 0xe1, 0x39, 0x77, 0x80, 0xc8, 0x11, 0x77,
-	0x7d, 0x78, 0x1b, 0xce, 0x9e, 0x2c, 0x26, 0xfa, 0xbb, 0x09, 0xfd, 0x43, 0x5c, 0x16, 0xd9, 0x34,
-	0x21, 0x3f, 0x97, 0x44, 0x2a, 0x34, 0x80, 0x46, 0x36, 0xcf, 0xc3, 0x60, 0x14, 0x8c, 0x3b, 0x89,
-	0xfe, 0x44, 0x08, 0x36, 0xb1, 0x98, 0xc8, 0x70, 0x63, 0xd4, 0x18, 0x77, 0x12, 0xf3, 0x8d, 0x8e,
-	0xa0, 0x23, 0x88, 0x64, 0xa5, 0xc8, 0x88, 0x0c, 0x1b, 0xa3, 0x60, 0xdc, 0xdd, 0xdf, 0x8b, 0xff,
-	0x2d, 0x71, 0x17, 0xdf, 0x86, 0x8c, 0x13, 0xcf, 0x4b, 0x2e, 0x5d, 0xa0, 0x5b, 0xd0, 0x95, 0x2a,
-	0x67, 0xa5, 0x4a, 0x39, 0x56, 0xd3, 0x70, 0xd3, 0x44, 0x07, 0x6b, 0x3a, 0xc6, 0x6a, 0xea, 0x00,
-	0x44, 0x08, 0x0b, 0xd8, 0xaa, 0x00, 0x44, 0x08, 0x03, 0x18, 0x40, 0x83, 0x14, 0x8b, 0xb0, 0x69,
-	0x92, 0xd4, 0x9f, 0x3a, 0xef, 0x52, 0x12, 0x11, 0xb6, 0x0c, 0xd6, 0x7c, 0xa3, 0x9b, 0xd0, 0x56,
-	0x58, 0x9e, 0xa5, 0x39, 0x15, 0x61, 0xdb, 0xd8, 0x5b, 0xfa, 0xfc, 0x98, 0x0a, 0x74, 0x1b, 0x76,
-	0x7c, 0x3e, 0xe9, 0x8c, 0xce, 0xa9, 0x92, 0x61, 0x67, 0x14, 0x8c, 0xdb, 0xc9, 0xb6, 0x37, 0x1f,
-	0x1a, 0x2b, 0xda, 0x83, 0x77, 0x4f, 0xb1, 0xa4, 0x59, 0xca, 0x05, 0xcb, 0x88, 0x94, 0x69, 0x36,
-	0x11, 0xac, 0xe4, 0x21, 0x18, 0x34, 0x32, 0xff, 0x1d, 0xdb, 0xbf, 0x0e, 0xcc, 0x3f, 0xe8, 0x31,
-	0x34, 0xe7, 0xac, 0x2c, 0x94, 0x0c, 0xbb, 0xa3, 0xc6, 0xb8, 0xbb, 0x7f, 0xa7, 0xe6, 0x53, 0x3d,
-	0xd3, 0xa4, 0xc4, 0x71, 0xd1, 0xb7, 0xd0, 0xca, 0xc9, 0x82, 0xea, 0x17, 0xef, 0x19, 0x37, 0x9f,
-	0xd5, 0x74, 0xf3, 0xd8, 0xb0, 0x12, 0xcf, 0x46, 0x53, 0xb8, 0x5e, 0x10, 0x75, 0xce, 0xc4, 0x59,
-	0x4a, 0x25, 0x9b, 0x61, 0x45, 0x59, 0x11, 0xf6, 0x4d, 0x11, 0xbf, 0xa8, 0xe9, 0xf2, 0xc8, 0xf2,
-	0x9f, 0x7a, 0xfa, 0x09, 0x27, 0x59, 0x32, 0x28, 0xae, 0x58, 0x51, 0x04, 0xfd, 0x82, 0xa5, 0x9c,
-	0x2e, 0x98, 0x4a, 0x05, 0x63, 0x2a, 0xdc, 0x36, 0x6f, 0xd4, 0x2d, 0xd8, 0xb1, 0xb6, 0x25, 0x8c,
-	0x29, 0x34, 0x86, 0x41, 0x4e, 0x5e, 0xe2, 0x72, 0xa6, 0x52, 0x4e, 0xf3, 0x74, 0xce, 0x72, 0x12,
-	0xee, 0x98, 0xd2, 0x6c, 0x3b, 0xfb, 0x31, 0xcd, 0x9f, 0xb1, 0x9c, 0x2c, 0x23, 0x29, 0xcf, 0x2c,
-	0x72, 0xb0, 0x82, 0x7c, 0xca, 0x33, 0x83, 0xfc, 0x08, 0xfa, 0x19, 0x2f, 0x25, 0x51, 0xbe, 0x36,
-	0xd7, 0x0d, 0xac, 0x67, 0x8d, 0xae, 0x2a, 0x1f, 0x00, 0xe0, 0xd9, 0x8c, 0x9d, 0xa7, 0x19, 0xe6,
-	0x32, 0x44, 0xa6, 0x71, 0x3a, 0xc6, 0x72, 0x80, 0xb9, 0x44, 0x11, 0xf4, 0x32, 0xcc, 0xf1, 0x29,
-	0x9d, 0x51, 0x45, 0x89, 0x0c, 0xdf, 0x31, 0x80, 0x15, 0x5b, 0xf4, 0x13, 0x6c, 0xfb, 0xe9, 0x91,
-	0x9c, 0x15, 0x92, 0xa0, 0x23, 0x68, 0xb9, 0xb6, 0x30, 0x23, 0xd4, 0xdd, 0xbf, 0x17, 0xd7, 0x9b,
-	0xe7, 0xd8, 0xb5, 0xcc, 0x89, 0xc2, 0x8a, 0x24, 0xde, 0x49, 0xd4, 0x87, 0xee, 0x0b, 0x4c, 0x95,
-	0x9b, 0xce, 0xe8, 0x47, 0xe8, 0xd9, 0xe3, 0xff, 0x14, 0xee, 0x10, 0x76, 0x4e, 0xa6, 0xa5, 0xca,
-	0xd9, 0x79, 0xe1, 0x05, 0xe1, 0x06, 0x34, 0x25, 0x9d, 0x14, 0x78, 0xe6, 0x34, 0xc1, 0x9d, 0xd0,
-	0x87, 0xd0, 0x9b, 0x08, 0x9c, 0x91, 0x94, 0x13, 0x41, 0x59, 0x1e, 0x6e, 0x8c, 0x82, 0x71, 0x23,
-	0xe9, 0x1a, 0xdb, 0xb1, 0x31, 0x45, 0x08, 0x06, 0x97, 0xde, 0x6c, 0xc6, 0xd1, 0x14, 0x6e, 0x7c,
-	0xc7, 0x73, 0x1d, 0xb4, 0xd2, 0x01, 0x17, 0x68, 0x45, 0x53, 0x82, 0xff, 0xac, 0x29, 0xd1, 0x4d,
-	0x78, 0xef, 0x95, 0x48, 0x2e, 0x89, 0x01, 0x6c, 0x7f, 0x4f, 0x84, 0xa4, 0xcc, 0xdf, 0x32, 0xfa,
-	0x14, 0x76, 0x2a, 0x8b, 0x7b, 0xdb, 0x10, 0x5a, 0x0b, 0x6b, 0x72, 0x37, 0xf7, 0xc7, 0xe8, 0x13,
-	0xe8, 0xe9, 0x77, 0xab, 0x32, 0x1f, 0x42, 0x9b, 0x16, 0x8a, 0x88, 0x85, 0x7b, 0xa4, 0x46, 0x52,
-	0x9d, 0xa3, 0x17, 0xd0, 0x77, 0x58, 0xe7, 0xf6, 0x1b, 0xd8, 0x92, 0xda, 0xb0, 0xe6, 0x15, 0x9f,
-	0x63, 0x79, 0x66, 0x1d, 0x59, 0x7a, 0x74, 0x1b, 0xfa, 0x27, 0xa6, 0x12, 0xaf, 0x2f, 0xd4, 0x96,
-	0x2f, 0x94, 0xbe, 0xac, 0x07, 0xba, 0xeb, 0x9f, 0x41, 0xf7, 0xc9, 0x05, 0xc9, 0x3c, 0xf1, 0x01,
-	0xb4, 0x73, 0x82, 0xf3, 0x19, 0x2d, 0x88, 0x4b, 0x6a, 0x18, 0xdb, 0xe5, 0x12, 0xfb, 0xe5, 0x12,
-	0x3f, 0xf7, 0xcb, 0x25, 0xa9, 0xb0, 0x7e, 0x55, 0x6c, 0xbc, 0xba, 0x2a, 0x1a, 0x97, 0xab, 0x22,
-	0x3a, 0x80, 0x9e, 0x0d, 0xe6, 0xee, 0x7f, 0x03, 0x9a, 0xac, 0x54, 0xbc, 0x54, 0x26, 0x56, 0x2f,
-	0x71, 0x27, 0xf4, 0x3e, 0x74, 0xc8, 0x05, 0x55, 0x69, 0xa6, 0xc7, 0x7a, 0xc3, 0xdc, 0xa0, 0xad,
-	0x0d, 0x07, 0x2c, 0x27, 0xd1, 0xef, 0x01, 0xf4, 0x96, 0x3b, 0x56, 0xc7, 0xe6, 0x34, 0x77, 0x37,
-	0xd5, 0x9f, 0x6f, 0xe4, 0x2f, 0xbd, 0x4d, 0x63, 0xf9, 0x6d, 0x50, 0x0c, 0x9b, 0x7a, 0x6d, 0x9a,
-	0x85, 0xf3, 0xe6, 0x6b, 0x1b, 0xdc, 0xfe, 0x9f, 0x1d, 0x68, 0x3f, 0x71, 0x83, 0x84, 0x7e, 0x81,
-	0xa6, 0x9d, 0x7e, 0x74, 0xbf, 0xee, 0xd4, 0xad, 0xec, 0xda, 0xe1, 0x83, 0x75, 0x69, 0xae, 0x7e,
-	0xd7, 0x90, 0x84, 0x4d, 0xad, 0x03, 0xe8, 0x6e, 0x5d, 0x0f, 0x4b, 0x22, 0x32, 0xbc, 0xb7, 0x1e,
-	0xa9, 0x0a, 0xfa, 0x1b, 0xb4, 0xfd, 0x38, 0xa3, 0x87, 0x75, 0x7d, 0x5c, 0x91, 0x93, 0xe1, 0xe7,
-	0xeb, 0x13, 0xab, 0x04, 0xfe, 0x08, 0x60, 0xe7, 0xca, 0x48, 0xa3, 0x2f, 0xeb, 0xfa, 0x7b, 0xbd,
-	0xea, 0x0c, 0x1f, 0xbd, 0x35, 0xbf, 0x4a, 0xeb, 0x57, 0x68, 0x39, 0xed, 0x40, 0xb5, 0x2b, 0xba,
-	0x2a, 0x3f, 0xc3, 0x87, 0x6b, 0xf3, 0xaa, 0xe8, 0x17, 0xb0, 0x65, 0x74, 0x01, 0xd5, 0x2e, 0xeb,
-	0xb2, 0x76, 0x0d, 0xef, 0xaf, 0xc9, 0xf2, 0x71, 0xf7, 0x02, 0xdd, 0xff, 0x56, 0x58, 0xea, 0xf7,
-	0xff, 0x8a, 0x62, 0xd5, 0xef, 0xff, 0x2b, 0xfa, 0x65, 0xfa, 0x5f, 0x8f, 0x61, 0xfd, 0xfe, 0x5f,
-	0xd2, 0xbb, 0xfa, 0xfd, 0xbf, 0xac, 0x5b, 0xd1, 0x35, 0xf4, 0x57, 0x00, 0x7d, 0x6d, 0x3a, 0x51,
-	0x82, 0xe0, 0x39, 0x2d, 0x26, 0xe8, 0x51, 0x4d, 0xf1, 0xd6, 0x2c, 0x2b, 0xe0, 0x8e, 0xe9, 0x53,
-	0xf9, 0xea, 0xed, 0x1d, 0xf8, 0xb4, 0xc6, 0xc1, 0x5e, 0xf0, 0x75, 0xeb, 0x87, 0x2d, 0xab, 0x59,
-	0x4d, 0xf3, 0x73, 0xf7, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x2e, 0x63, 0xc4, 0xd3, 0x74, 0x0c,
-	0x00, 0x00,
+	// 1624 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0x6d, 0x6f, 0x1c, 0xb7,
+	0x11, 0xee, 0xf9, 0xf4, 0x72, 0x37, 0xf7, 0xa2, 0x13, 0x9b, 0x3a, 0xf4, 0xb9, 0x8e, 0x2f, 0x9b,
+	0x34, 0xbe, 0xd8, 0xc9, 0xc9, 0x50, 0x1c, 0xa7, 0x40, 0x81, 0xa6, 0x8d, 0x9c, 0x26, 0x46, 0x6d,
+	0x45, 0x58, 0x39, 0x09, 0x50, 0x14, 0xdd, 0x52, 0xbb, 0xd4, 0x1d, 0xa3, 0xdd, 0x25, 0x4b, 0x72,
+	0xf5, 0x52, 0x14, 0xe8, 0x9f, 0x68, 0x81, 0x7e, 0xeb, 0x97, 0xfe, 0xd0, 0x82, 0x43, 0xee, 0xea,
+	0x64, 0xa7, 0xed, 0xc9, 0x45, 0x3e, 0x1d, 0xf9, 0x70, 0x66, 0x38, 0x9c, 0x79, 0xf8, 0x70, 0x0f,
+	0x3e, 0xc8, 0xb4, 0x38, 0xe5, 0xda, 0xec, 0x98, 0x05, 0xd3, 0x3c, 0xdb, 0xe1, 0xe7, 0x3c, 0xad,
+	0xac, 0xd4, 0x3b, 0x4a, 0x4b, 0x2b, 0x9b, 0xe9, 0x0c, 0xa7, 0xe4, 0xbd, 0x05, 0x33, 0x0b, 0x91,
+	0x4a, 0xad, 0x66, 0xa5, 0x2c, 0x58, 0x36, 0x53, 0x79, 0x35, 0x17, 0xa5, 0x99, 0x5d, 0xb5, 0x1b,
+	0xdf, 0x9d, 0x4b, 0x39, 0xcf, 0xb9, 0x0f, 0x72, 0x54, 0x1d, 0xef, 0x58, 0x51, 0x70, 0x63, 0x59,
+	0xa1, 0x82, 0xc1, 0x5b, 0x2f, 0x1b, 0x9c, 0x69, 0xa6, 0x14, 0xd7, 0x26, 0xac, 0x47, 0x21, 0xf0,
+	0x4e, 0x9d, 0x9e, 0x4f, 0xc7, 0xcf, 0xbc, 0x4d, 0xf4, 0xcf, 0x21, 0x0c, 0x9e, 0xb1, 0xaa, 0x4c,
+	0x17, 0x31, 0xff, 0x53, 0xc5, 0x8d, 0x25, 0x23, 0x68, 0xa7, 0x45, 0x46, 0x5b, 0x93, 0xd6, 0xb4,
+	0x1b, 0xbb, 0x21, 0x21, 0xb0, 0xc6, 0xf4, 0xdc, 0xd0, 0x1b, 0x93, 0xf6, 0xb4, 0x1b, 0xe3, 0x98,
+	0xec, 0x43, 0x57, 0x73, 0x23, 0x2b, 0x9d, 0x72, 0x43, 0xdb, 0x93, 0xd6, 0xb4, 0xb7, 0xfb, 0x70,
+	0xf6, 0x9f, 0x0e, 0x16, 0xf6, 0xf7, 0x5b, 0xce, 0xe2, 0xda, 0x2f, 0xbe, 0x0c, 0x41, 0xee, 0x42,
+	0xcf, 0xd8, 0x4c, 0x56, 0x36, 0x51, 0xcc, 0x2e, 0xe8, 0x1a, 0xee, 0x0e, 0x1e, 0x3a, 0x60, 0x76,
+	0x11, 0x0c, 0xb8, 0xd6, 0xde, 0x60, 0xbd, 0x31, 0xe0, 0x5a, 0xa3, 0xc1, 0x08, 0xda, 0xbc, 0x3c,
+	0xa5, 0x1b, 0x98, 0xa4, 0x1b, 0xba, 0xbc, 0x2b, 0xc3, 0x35, 0xdd, 0x44, 0x5b, 0x1c, 0x93, 0x5b,
+	0xd0, 0xb1, 0xcc, 0x9c, 0x24, 0x99, 0xd0, 0xb4, 0x83, 0xf8, 0xa6, 0x9b, 0x3f, 0x11, 0x9a, 0xdc,
+	0x83, 0xad, 0x3a, 0x9f, 0x24, 0x17, 0x85, 0xb0, 0x86, 0x76, 0x27, 0xad, 0x69, 0x27, 0x1e, 0xd6,
+	0xf0, 0x33, 0x44, 0xc9, 0x43, 0x78, 0xe3, 0x88, 0x19, 0x91, 0x26, 0x4a, 0xcb, 0x94, 0x1b, 0x93,
+	0xa4, 0x73, 0x2d, 0x2b, 0x45, 0x01, 0xad, 0x09, 0xae, 0x1d, 0xf8, 0xa5, 0x3d, 0x5c, 0x21, 0x4f,
+	0x60, 0xa3, 0x90, 0x55, 0x69, 0x0d, 0xed, 0x4d, 0xda, 0xd3, 0xde, 0xee, 0x07, 0x2b, 0x96, 0xea,
+	0xb9, 0x73, 0x8a, 0x83, 0x2f, 0xf9, 0x02, 0x36, 0x33, 0x7e, 0x2a, 0x5c, 0xc5, 0xfb, 0x18, 0xe6,
+	0xc3, 0x15, 0xc3, 0x3c, 0x41, 0xaf, 0xb8, 0xf6, 0x26, 0x0b, 0xd8, 0x2e, 0xb9, 0x3d, 0x93, 0xfa,
+	0x24, 0x11, 0x46, 0xe6, 0xcc, 0x0a, 0x59, 0xd2, 0x01, 0x36, 0xf1, 0x17, 0x2b, 0x86, 0xdc, 0xf7,
+	0xfe, 0x4f, 0x6b, 0xf7, 0x43, 0xc5, 0xd3, 0x78, 0x54, 0xbe, 0x84, 0x92, 0x08, 0x06, 0xa5, 0x4c,
+	0x94, 0x38, 0x95, 0x36, 0xd1, 0x52, 0x5a, 0x3a, 0xc4, 0x1a, 0xf5, 0x4a, 0x79, 0xe0, 0xb0, 0x58,
+	0x4a, 0x4b, 0xa6, 0x30, 0xca, 0xf8, 0x31, 0xab, 0x72, 0x9b, 0x28, 0x91, 0x25, 0x85, 0xcc, 0x38,
+	0xdd, 0xc2, 0xd6, 0x0c, 0x03, 0x7e, 0x20, 0xb2, 0xe7, 0x32, 0xe3, 0xcb, 0x96, 0x42, 0xa5, 0xde,
+	0x72, 0x74, 0xc5, 0xf2, 0xa9, 0x4a, 0xd1, 0xf2, 0x1d, 0x18, 0xa4, 0xaa, 0x32, 0xdc, 0xd6, 0xbd,
+	0xd9, 0x46, 0xb3, 0xbe, 0x07, 0x43, 0x57, 0xee, 0x00, 0xb0, 0x3c, 0x97, 0x67, 0x49, 0xca, 0x94,
+	0xa1, 0x04, 0x89, 0xd3, 0x45, 0x64, 0x8f, 0x29, 0x43, 0x22, 0xe8, 0xa7, 0x4c, 0xb1, 0x23, 0x91,
+	0x0b, 0x2b, 0xb8, 0xa1, 0x3f, 0x46, 0x83, 0x2b, 0x98, 0xa3, 0x58, 0x29, 0x52, 0x4e, 0xdf, 0x98,
+	0xb4, 0xa6, 0xeb, 0x31, 0x8e, 0x1d, 0xc5, 0x84, 0x4c, 0xd2, 0x9c, 0x19, 0x43, 0x7f, 0xe2, 0x29,
+	0x26, 0xe4, 0x9e, 0x9b, 0x3a, 0x12, 0x0b, 0x99, 0x28, 0x2d, 0xa4, 0x16, 0xf6, 0x82, 0xde, 0x44,
+	0x2f, 0x10, 0xf2, 0x20, 0x20, 0xce, 0xa0, 0xce, 0x5b, 0x55, 0x86, 0xbe, 0xe9, 0x59, 0x1e, 0xb2,
+	0x56, 0x95, 0x59, 0x32, 0x28, 0x78, 0x61, 0x28, 0x5d, 0x36, 0x78, 0xce, 0x0b, 0x24, 0x27, 0xd2,
+	0x25, 0x29, 0x59, 0xc1, 0x8d, 0x62, 0x29, 0x4f, 0x64, 0x99, 0x5f, 0xd0, 0x5b, 0x9e, 0x9c, 0xb8,
+	0xb6, 0x5f, 0x2f, 0x7d, 0x55, 0xe6, 0x17, 0x8e, 0xf7, 0x99, 0x30, 0xec, 0x28, 0xe7, 0xa1, 0x58,
+	0x86, 0x8e, 0x3d, 0xef, 0x03, 0xec, 0xcb, 0x65, 0xc8, 0x97, 0xb0, 0x5d, 0xf0, 0x42, 0xea, 0x8b,
+	0xc4, 0x9c, 0x31, 0xa5, 0x44, 0xc9, 0x8d, 0xa1, 0xb7, 0x91, 0x36, 0xb7, 0x67, 0x5e, 0x8b, 0x66,
+	0xb5, 0x16, 0xcd, 0x9e, 0x96, 0xf6, 0xf1, 0xa3, 0x6f, 0x58, 0x5e, 0xf1, 0x78, 0xe4, 0xbd, 0x0e,
+	0x1b, 0x27, 0xf2, 0x2e, 0x0c, 0x97, 0x22, 0x25, 0xc5, 0x11, 0xfd, 0xe9, 0xa4, 0x35, 0x6d, 0xc7,
+	0xfd, 0x4b, 0xcb, 0xe7, 0x47, 0xe4, 0x7d, 0x18, 0x31, 0xa5, 0x98, 0x2e, 0xa4, 0x76, 0x57, 0xed,
+	0x58, 0xe4, 0x9c, 0xde, 0xc1, 0x03, 0x6f, 0xd5, 0xf8, 0x81, 0x87, 0x1d, 0xcf, 0xa4, 0x2c, 0x12,
+	0x93, 0x4a, 0xcd, 0x13, 0x96, 0x7d, 0x47, 0xdf, 0xc2, 0xd2, 0xf6, 0xa4, 0x2c, 0x0e, 0x1d, 0xf6,
+	0xeb, 0xec, 0x3b, 0x72, 0x1f, 0xb6, 0x4b, 0x99, 0x94, 0xfc, 0xcc, 0x35, 0xe0, 0x54, 0xe4, 0x7c,
+	0xce, 0x0d, 0xbd, 0x8b, 0x27, 0xdd, 0x2a, 0xe5, 0x3e, 0x3f, 0x3b, 0x68, 0x60, 0x57, 0x66, 0x27,
+	0x17, 0xa5, 0xf1, 0x24, 0x9b, 0xf8, 0x32, 0x7b, 0xa8, 0xa6, 0x62, 0x30, 0x10, 0x59, 0x22, 0x8f,
+	0x8f, 0x0d, 0xb7, 0xf4, 0xed, 0x49, 0x6b, 0x3a, 0x88, 0x87, 0x1e, 0x7f, 0x9a, 0x7d, 0x85, 0x28,
+	0xf9, 0x1a, 0xfa, 0xb6, 0x50, 0xc7, 0x26, 0xf1, 0xb7, 0x98, 0x46, 0x78, 0x75, 0x77, 0x67, 0xab,
+	0xbd, 0x02, 0xb3, 0x17, 0xce, 0xd7, 0xeb, 0x40, 0xcf, 0x36, 0x63, 0xe3, 0x58, 0x56, 0xd9, 0x90,
+	0xde, 0x3b, 0x9e, 0x65, 0x95, 0xf5, 0xb9, 0x8d, 0xa1, 0xb3, 0x90, 0xc6, 0x3a, 0x02, 0xd0, 0x77,
+	0x71, 0xa9, 0x99, 0xbb, 0x66, 0x1b, 0x9e, 0xa6, 0xb2, 0x50, 0x4d, 0x49, 0x7f, 0x36, 0x69, 0x4d,
+	0xfb, 0xf1, 0x30, 0xc0, 0x75, 0x45, 0xbf, 0x84, 0x4d, 0x1d, 0x54, 0xf0, 0x3d, 0xcc, 0x78, 0xb6,
+	0x6a, 0xc6, 0x31, 0xba, 0xc5, 0xb5, 0x3b, 0xf9, 0x10, 0x88, 0xe7, 0x55, 0x92, 0xca, 0xd2, 0x6a,
+	0x99, 0xe7, 0x5c, 0x1b, 0x7a, 0x0f, 0x6f, 0xd3, 0xb6, 0x5f, 0xd9, 0xbb, 0x5c, 0x70, 0xb7, 0x52,
+	0x89, 0xcc, 0x78, 0x09, 0xa6, 0x53, 0xe4, 0x45, 0xd7, 0x21, 0xa8, 0xbe, 0xe4, 0x01, 0x6c, 0xb3,
+	0x2c, 0x13, 0x4e, 0x5d, 0x58, 0x9e, 0x04, 0xbe, 0xbe, 0x3f, 0x69, 0x4f, 0x07, 0xf1, 0xe8, 0x72,
+	0xe1, 0x0b, 0xc4, 0x5d, 0x91, 0x50, 0xe5, 0x9c, 0xda, 0xdf, 0xf7, 0x45, 0x72, 0xf3, 0xa0, 0xf6,
+	0x86, 0xe7, 0xa2, 0xac, 0xce, 0x13, 0xcd, 0x73, 0x76, 0xc4, 0x73, 0xfa, 0xc0, 0xb3, 0x3e, 0xc0,
+	0xb1, 0x47, 0xa3, 0xdf, 0x03, 0x5c, 0xf6, 0x80, 0xdc, 0x86, 0x2e, 0xbe, 0x1f, 0xf8, 0x08, 0xf9,
+	0x37, 0x12, 0x1f, 0x14, 0x7c, 0x82, 0xee, 0x00, 0x18, 0xf1, 0x67, 0x9e, 0x1c, 0x5d, 0x58, 0xee,
+	0x9e, 0x4b, 0x4c, 0xdd, 0x21, 0x9f, 0x5d, 0x58, 0x2f, 0x16, 0xd8, 0xae, 0x36, 0xf2, 0x04, 0xc7,
+	0xd1, 0x13, 0xd8, 0xf0, 0xf5, 0x72, 0xab, 0xd8, 0x31, 0x1f, 0x14, 0xc7, 0x0e, 0x33, 0xf2, 0xd8,
+	0x62, 0xa8, 0xb5, 0x18, 0xc7, 0x0e, 0x5b, 0x30, 0x9d, 0x61, 0x94, 0xb5, 0x18, 0xc7, 0xd1, 0x1f,
+	0x61, 0x58, 0x3f, 0xe2, 0x46, 0xc9, 0xd2, 0x70, 0xb2, 0x0f, 0x9b, 0xe1, 0x75, 0xc2, 0x80, 0xbd,
+	0xdd, 0x47, 0xab, 0xb6, 0x2f, 0xbc, 0x5c, 0x87, 0x96, 0x59, 0x1e, 0xd7, 0x41, 0xa2, 0x01, 0xf4,
+	0xbe, 0x65, 0xc2, 0x86, 0x8f, 0x84, 0xe8, 0x0f, 0xd0, 0xf7, 0xd3, 0x1f, 0x68, 0xbb, 0x67, 0xb0,
+	0x75, 0xb8, 0xa8, 0x6c, 0x26, 0xcf, 0xca, 0xb0, 0x25, 0xb9, 0x09, 0x1b, 0x46, 0xcc, 0x4b, 0x96,
+	0x87, 0x0a, 0x85, 0x19, 0x79, 0x1b, 0xfa, 0x73, 0xed, 0x64, 0x4e, 0x71, 0x2d, 0x64, 0x16, 0xca,
+	0xde, 0x43, 0xec, 0x00, 0xa1, 0x88, 0xc0, 0xe8, 0x32, 0x9a, 0xcf, 0x38, 0x5a, 0xc0, 0xcd, 0xaf,
+	0x55, 0xe6, 0x36, 0x6d, 0x3e, 0x47, 0xc2, 0x46, 0x57, 0x3e, 0x6d, 0x5a, 0xff, 0xf7, 0xa7, 0x4d,
+	0x74, 0x0b, 0xde, 0x7c, 0x65, 0xa7, 0x90, 0xc4, 0x08, 0x86, 0xdf, 0x70, 0x6d, 0x84, 0xac, 0x4f,
+	0x19, 0x3d, 0x80, 0xad, 0x06, 0x09, 0xb5, 0xa5, 0xb0, 0x79, 0xea, 0xa1, 0x70, 0xf2, 0x7a, 0x1a,
+	0xdd, 0x87, 0xbe, 0xab, 0x5b, 0x93, 0xf9, 0x18, 0x3a, 0xa2, 0xb4, 0x5c, 0x9f, 0x86, 0x22, 0xb5,
+	0xe3, 0x66, 0x1e, 0x7d, 0x0b, 0x83, 0x60, 0x1b, 0xc2, 0xfe, 0x06, 0xd6, 0x8d, 0x03, 0xae, 0x79,
+	0xc4, 0x17, 0xcc, 0x9c, 0xf8, 0x40, 0xde, 0x3d, 0xba, 0x07, 0x83, 0x43, 0xec, 0xc4, 0xf7, 0x37,
+	0x6a, 0xbd, 0x6e, 0x94, 0x3b, 0x6c, 0x6d, 0x18, 0x8e, 0x7f, 0x02, 0xbd, 0xcf, 0xcf, 0x79, 0x5a,
+	0x3b, 0x3e, 0x86, 0x4e, 0xc6, 0x59, 0x96, 0x8b, 0x92, 0x87, 0xa4, 0xc6, 0xaf, 0x3c, 0x2b, 0x2f,
+	0xea, 0x6f, 0xe0, 0xb8, 0xb1, 0xad, 0xbf, 0x58, 0x6f, 0xbc, 0xfa, 0xc5, 0xda, 0xbe, 0xfc, 0x62,
+	0x8d, 0xf6, 0xa0, 0xef, 0x37, 0x0b, 0xe7, 0xbf, 0x09, 0x1b, 0xb2, 0xb2, 0xaa, 0xb2, 0xb8, 0x57,
+	0x3f, 0x0e, 0x33, 0x77, 0xc3, 0xf9, 0xb9, 0xb0, 0x49, 0xea, 0xae, 0xea, 0x0d, 0x3c, 0x41, 0xc7,
+	0x01, 0x7b, 0xee, 0xba, 0xfe, 0xab, 0x05, 0xfd, 0x65, 0xc6, 0xba, 0xbd, 0x95, 0xc8, 0xc2, 0x49,
+	0xdd, 0xf0, 0xbf, 0xfa, 0x2f, 0xd5, 0xa6, 0xbd, 0x5c, 0x1b, 0x32, 0x83, 0x35, 0xf7, 0x75, 0x4f,
+	0xd7, 0xfe, 0xe7, 0xb1, 0xd1, 0xce, 0x29, 0x8d, 0x7b, 0xef, 0x4e, 0x44, 0x9e, 0xf3, 0x0c, 0x3f,
+	0x86, 0x3b, 0x71, 0x57, 0xca, 0xe2, 0xb7, 0x08, 0xec, 0xfe, 0xbd, 0x0b, 0x9d, 0xcf, 0xc3, 0x3d,
+	0x23, 0x17, 0xb0, 0xe1, 0xc5, 0x81, 0x7c, 0xbc, 0xea, 0xa5, 0xbc, 0xf2, 0x8f, 0x60, 0xfc, 0xf8,
+	0xba, 0x6e, 0xa1, 0xbd, 0x3f, 0x22, 0x06, 0xd6, 0x9c, 0x4c, 0x90, 0x8f, 0x56, 0x8d, 0xb0, 0xa4,
+	0x31, 0xe3, 0x47, 0xd7, 0x73, 0x6a, 0x36, 0xfd, 0x2b, 0x74, 0xea, 0xdb, 0x4e, 0x3e, 0x59, 0x35,
+	0xc6, 0x4b, 0x6a, 0x33, 0xfe, 0xf9, 0xf5, 0x1d, 0x9b, 0x04, 0xfe, 0xd6, 0x82, 0xad, 0x97, 0x6e,
+	0x3c, 0xf9, 0xe5, 0xaa, 0xf1, 0xbe, 0x5f, 0x94, 0xc6, 0x9f, 0xbe, 0xb6, 0x7f, 0x93, 0xd6, 0x5f,
+	0x60, 0x33, 0x48, 0x0b, 0x59, 0xb9, 0xa3, 0x57, 0xd5, 0x69, 0xfc, 0xc9, 0xb5, 0xfd, 0x9a, 0xdd,
+	0xcf, 0x61, 0x1d, 0x65, 0x83, 0xac, 0xdc, 0xd6, 0x65, 0x69, 0x1b, 0x7f, 0x7c, 0x4d, 0xaf, 0x7a,
+	0xdf, 0x87, 0x2d, 0xc7, 0x7f, 0xaf, 0x3b, 0xab, 0xf3, 0xff, 0x8a, 0xa0, 0x8d, 0x1f, 0x5f, 0xd7,
+	0x6d, 0x99, 0xff, 0xee, 0x1a, 0xae, 0xce, 0xff, 0x25, 0x39, 0x1c, 0x3f, 0xba, 0x9e, 0x53, 0xb3,
+	0xe9, 0x3f, 0x5a, 0x30, 0x70, 0xd0, 0xa1, 0xd5, 0x9c, 0x15, 0xa2, 0x9c, 0x93, 0x4f, 0x57, 0xd4,
+	0x76, 0xe7, 0xe5, 0xf5, 0x3d, 0x78, 0xd6, 0xa9, 0xfc, 0xea, 0xf5, 0x03, 0xd4, 0x69, 0x4d, 0x5b,
+	0x0f, 0x5b, 0x9f, 0x6d, 0xfe, 0x6e, 0xdd, 0x4b, 0xda, 0x06, 0xfe, 0x7c, 0xf4, 0xef, 0x01, 0x00,
+	0x8b, 0x5e, 0x8d, 0x8a, 0x3a, 0x11, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -4,7 +4,6 @@ import (
 	"os"
 	"sync"
 
-	"github.com/hashicorp/nomad/client/stats"
 	"github.com/opencontainers/runc/libcontainer/cgroups"
 	cgroupConfig "github.com/opencontainers/runc/libcontainer/configs"
 )
@@ -50,12 +49,7 @@ func (rc *resourceContainerContext) getAllPidsByCgroup() (map[int]*nomadPid, err
 	}
 
 	for _, pid := range pids {
-		nPids[pid] = &nomadPid{
-			pid:           pid,
-			cpuStatsTotal: stats.NewCpuStats(),
-			cpuStatsUser:  stats.NewCpuStats(),
-			cpuStatsSys:   stats.NewCpuStats(),
-		}
+		nPids[pid] = newNomadPid(pid)
 	}
 
 	return nPids, nil
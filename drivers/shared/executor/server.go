@@ -38,6 +38,30 @@ func (s *grpcExecutorServer) Launch(ctx context.Context, req *proto.LaunchReques
 		ModePID:            req.DefaultPidMode,
 		ModeIPC:            req.DefaultIpcMode,
 		Capabilities:       req.Capabilities,
+		Nice:               int(req.Nice),
+		IOClass:            req.IoClass,
+		IOPriority:         int(req.IoPriority),
+		CpusetCpus:         req.CpusetCpus,
+		CpusetMems:         req.CpusetMems,
+		MountNamespaceOnly: req.MountNamespaceOnly,
+		DisableCgroups:     req.DisableCgroups,
+		MemorySwappiness:   unwrapInt64(req.MemorySwappiness),
+		MemorySwapMB:       req.MemorySwapMb,
+		ApparmorProfile:    req.ApparmorProfile,
+		OOMScoreAdj:        int(req.OomScoreAdj),
+		NoNewPrivileges:    req.NoNewPrivileges,
+		ModeUserns:         req.UsernsMode,
+		UsernsIDOffset:     req.UsernsIdOffset,
+		TmpfsMounts:        tmpfsMountsFromProto(req.TmpfsMounts),
+		ModeUTS:            req.UtsMode,
+		Hostname:           req.Hostname,
+		SeccompProfile:     req.SeccompProfile,
+		Rlimits:            rlimitsFromProto(req.Rlimits),
+		CgroupControllers:  req.CgroupControllers,
+		PidsLimit:          req.PidsLimit,
+		AdditionalGroups:   req.AdditionalGroups,
+		WorkDir:            req.WorkDir,
+		SELinuxRelabel:     req.SelinuxRelabel,
 	})
 
 	if err != nil {
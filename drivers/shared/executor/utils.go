@@ -7,6 +7,7 @@ import (
 	"os/exec"
 
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	hclog "github.com/hashicorp/go-hclog"
 	plugin "github.com/hashicorp/go-plugin"
 	"github.com/hashicorp/nomad/drivers/shared/executor/proto"
@@ -117,10 +118,11 @@ func processStateToProto(ps *ProcessState) (*proto.ProcessState, error) {
 		return nil, err
 	}
 	pb := &proto.ProcessState{
-		Pid:      int32(ps.Pid),
-		ExitCode: int32(ps.ExitCode),
-		Signal:   int32(ps.Signal),
-		Time:     timestamp,
+		Pid:       int32(ps.Pid),
+		ExitCode:  int32(ps.ExitCode),
+		Signal:    int32(ps.Signal),
+		Time:      timestamp,
+		OomKilled: ps.OOMKilled,
 	}
 
 	return pb, nil
@@ -133,13 +135,95 @@ func processStateFromProto(pb *proto.ProcessState) (*ProcessState, error) {
 	}
 
 	return &ProcessState{
-		Pid:      int(pb.Pid),
-		ExitCode: int(pb.ExitCode),
-		Signal:   int(pb.Signal),
-		Time:     timestamp,
+		Pid:       int(pb.Pid),
+		ExitCode:  int(pb.ExitCode),
+		Signal:    int(pb.Signal),
+		Time:      timestamp,
+		OOMKilled: pb.OomKilled,
 	}, nil
 }
 
+func tmpfsMountsToProto(mounts []*TmpfsMount) []*proto.TmpfsMount {
+	if len(mounts) == 0 {
+		return nil
+	}
+
+	pb := make([]*proto.TmpfsMount, len(mounts))
+	for i, m := range mounts {
+		pb[i] = &proto.TmpfsMount{
+			TaskPath:  m.TaskPath,
+			SizeBytes: m.SizeBytes,
+			Mode:      m.Mode,
+		}
+	}
+	return pb
+}
+
+func tmpfsMountsFromProto(pb []*proto.TmpfsMount) []*TmpfsMount {
+	if len(pb) == 0 {
+		return nil
+	}
+
+	mounts := make([]*TmpfsMount, len(pb))
+	for i, m := range pb {
+		mounts[i] = &TmpfsMount{
+			TaskPath:  m.TaskPath,
+			SizeBytes: m.SizeBytes,
+			Mode:      m.Mode,
+		}
+	}
+	return mounts
+}
+
+func rlimitsToProto(rlimits []*Rlimit) []*proto.Rlimit {
+	if len(rlimits) == 0 {
+		return nil
+	}
+
+	pb := make([]*proto.Rlimit, len(rlimits))
+	for i, r := range rlimits {
+		pb[i] = &proto.Rlimit{
+			Name: r.Name,
+			Soft: r.Soft,
+			Hard: r.Hard,
+		}
+	}
+	return pb
+}
+
+func rlimitsFromProto(pb []*proto.Rlimit) []*Rlimit {
+	if len(pb) == 0 {
+		return nil
+	}
+
+	rlimits := make([]*Rlimit, len(pb))
+	for i, r := range pb {
+		rlimits[i] = &Rlimit{
+			Name: r.Name,
+			Soft: r.Soft,
+			Hard: r.Hard,
+		}
+	}
+	return rlimits
+}
+
+func unwrapInt64(w *wrappers.Int64Value) *int64 {
+	if w == nil {
+		return nil
+	}
+
+	v := w.Value
+	return &v
+}
+
+func wrapInt64(v *int64) *wrappers.Int64Value {
+	if v == nil {
+		return nil
+	}
+
+	return &wrappers.Int64Value{Value: *v}
+}
+
 // IsolationMode returns the namespace isolation mode as determined from agent
 // plugin configuration and task driver configuration. The task configuration
 // takes precedence, if it is configured.
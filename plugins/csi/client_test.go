@@ -203,6 +203,21 @@ func TestClient_RPC_PluginGetCapabilities(t *testing.T) {
 			},
 			ExpectedResponse: &PluginCapabilitySet{hasTopologies: true},
 		},
+		{
+			Name: "HasVolumeExpansion is true when it's part of the response",
+			Response: &csipbv1.GetPluginCapabilitiesResponse{
+				Capabilities: []*csipbv1.PluginCapability{
+					{
+						Type: &csipbv1.PluginCapability_VolumeExpansion_{
+							VolumeExpansion: &csipbv1.PluginCapability_VolumeExpansion{
+								Type: csipbv1.PluginCapability_VolumeExpansion_ONLINE,
+							},
+						},
+					},
+				},
+			},
+			ExpectedResponse: &PluginCapabilitySet{hasVolumeExpansion: true},
+		},
 	}
 
 	for _, tc := range cases {
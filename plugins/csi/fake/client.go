@@ -141,8 +141,8 @@ func (c *Client) PluginGetInfo(ctx context.Context) (string, string, error) {
 }
 
 // PluginGetCapabilities is used to return the available capabilities from the
-// identity service. This currently only looks for the CONTROLLER_SERVICE and
-// Accessible Topology Support
+// identity service. This currently only looks for the CONTROLLER_SERVICE,
+// Accessible Topology and Volume Expansion Support
 func (c *Client) PluginGetCapabilities(ctx context.Context) (*csi.PluginCapabilitySet, error) {
 	c.Mu.Lock()
 	defer c.Mu.Unlock()
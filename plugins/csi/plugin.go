@@ -27,8 +27,8 @@ type CSIPlugin interface {
 	PluginGetInfo(ctx context.Context) (string, string, error)
 
 	// PluginGetCapabilities is used to return the available capabilities from the
-	// identity service. This currently only looks for the CONTROLLER_SERVICE and
-	// Accessible Topology Support
+	// identity service. This currently only looks for the CONTROLLER_SERVICE,
+	// Accessible Topology and Volume Expansion Support
 	PluginGetCapabilities(ctx context.Context) (*PluginCapabilitySet, error)
 
 	// GetControllerCapabilities is used to get controller-specific capabilities
@@ -244,6 +244,7 @@ func (r *NodeStageVolumeRequest) Validate() error {
 type PluginCapabilitySet struct {
 	hasControllerService bool
 	hasTopologies        bool
+	hasVolumeExpansion   bool
 }
 
 func (p *PluginCapabilitySet) HasControllerService() bool {
@@ -257,8 +258,16 @@ func (p *PluginCapabilitySet) HasToplogies() bool {
 	return p.hasTopologies
 }
 
+// HasVolumeExpansion indicates whether the plugin supports expanding volumes,
+// either online or offline.
+func (p *PluginCapabilitySet) HasVolumeExpansion() bool {
+	return p.hasVolumeExpansion
+}
+
 func (p *PluginCapabilitySet) IsEqual(o *PluginCapabilitySet) bool {
-	return p.hasControllerService == o.hasControllerService && p.hasTopologies == o.hasTopologies
+	return p.hasControllerService == o.hasControllerService &&
+		p.hasTopologies == o.hasTopologies &&
+		p.hasVolumeExpansion == o.hasVolumeExpansion
 }
 
 func NewTestPluginCapabilitySet(topologies, controller bool) *PluginCapabilitySet {
@@ -286,6 +295,13 @@ func NewPluginCapabilitySet(capabilities *csipbv1.GetPluginCapabilitiesResponse)
 				continue
 			}
 		}
+		if expCap := pcap.GetVolumeExpansion(); expCap != nil {
+			switch expCap.Type {
+			case csipbv1.PluginCapability_VolumeExpansion_ONLINE,
+				csipbv1.PluginCapability_VolumeExpansion_OFFLINE:
+				cs.hasVolumeExpansion = true
+			}
+		}
 	}
 
 	return cs
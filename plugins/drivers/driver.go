@@ -86,6 +86,14 @@ type ExecOptions struct {
 	ResizeCh <-chan TerminalSize
 }
 
+// TaskLogTailer is the interface for drivers that can return the last lines
+// of a task's output, as marked by the TailLogs capability.
+type TaskLogTailer interface {
+	// TailTaskLogs returns up to the last lines lines the task wrote to
+	// stream, which must be "stdout" or "stderr".
+	TailTaskLogs(taskID, stream string, lines int) ([]string, error)
+}
+
 // DriverNetworkManager is the interface with exposes function for creating a
 // network namespace for which tasks can join. This only needs to be implemented
 // if the driver MUST create the network namespace
@@ -171,6 +179,10 @@ type Capabilities struct {
 	// adjust behavior such as propogating task handles between allocations
 	// to avoid downtime when a client is lost.
 	RemoteTasks bool
+
+	// TailLogs marks the driver as being able to return the last lines of a
+	// task's stdout and stderr. Used by the TaskLogTailer interface.
+	TailLogs bool
 }
 
 func (c *Capabilities) HasNetIsolationMode(m NetIsolationMode) bool {
@@ -284,6 +296,7 @@ type TaskConfig struct {
 	AllocID          string
 	NetworkIsolation *NetworkIsolationSpec
 	DNS              *DNSConfig
+	KillSignal       string
 }
 
 func (tc *TaskConfig) Copy() *TaskConfig {
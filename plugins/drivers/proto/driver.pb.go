@@ -1848,7 +1848,10 @@ type DriverCapabilities struct {
 	MountConfigs DriverCapabilities_MountConfigs `protobuf:"varint,6,opt,name=mount_configs,json=mountConfigs,proto3,enum=hashicorp.nomad.plugins.drivers.proto.DriverCapabilities_MountConfigs" json:"mount_configs,omitempty"`
 	// remote_tasks indicates whether the driver executes tasks remotely such
 	// on cloud runtimes like AWS ECS.
-	RemoteTasks          bool     `protobuf:"varint,7,opt,name=remote_tasks,json=remoteTasks,proto3" json:"remote_tasks,omitempty"`
+	RemoteTasks bool `protobuf:"varint,7,opt,name=remote_tasks,json=remoteTasks,proto3" json:"remote_tasks,omitempty"`
+	// tail_logs indicates that the driver can return the last lines of a
+	// task's stdout and stderr.
+	TailLogs             bool     `protobuf:"varint,8,opt,name=tail_logs,json=tailLogs,proto3" json:"tail_logs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1928,6 +1931,13 @@ func (m *DriverCapabilities) GetRemoteTasks() bool {
 	return false
 }
 
+func (m *DriverCapabilities) GetTailLogs() bool {
+	if m != nil {
+		return m.TailLogs
+	}
+	return false
+}
+
 type NetworkIsolationSpec struct {
 	Mode                 NetworkIsolationSpec_NetworkIsolationMode `protobuf:"varint,1,opt,name=mode,proto3,enum=hashicorp.nomad.plugins.drivers.proto.NetworkIsolationSpec_NetworkIsolationMode" json:"mode,omitempty"`
 	Path                 string                                    `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
@@ -2133,10 +2143,13 @@ type TaskConfig struct {
 	// to use for the task. *Only supported on Linux
 	NetworkIsolationSpec *NetworkIsolationSpec `protobuf:"bytes,16,opt,name=network_isolation_spec,json=networkIsolationSpec,proto3" json:"network_isolation_spec,omitempty"`
 	// DNSConfig is the configuration for task DNS resolvers and other options
-	Dns                  *DNSConfig `protobuf:"bytes,17,opt,name=dns,proto3" json:"dns,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	Dns *DNSConfig `protobuf:"bytes,17,opt,name=dns,proto3" json:"dns,omitempty"`
+	// KillSignal is the signal configured on the task to stop it, used when
+	// StopTask is not given an explicit signal
+	KillSignal           string   `protobuf:"bytes,18,opt,name=kill_signal,json=killSignal,proto3" json:"kill_signal,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *TaskConfig) Reset()         { *m = TaskConfig{} }
@@ -2283,6 +2296,13 @@ func (m *TaskConfig) GetDns() *DNSConfig {
 	return nil
 }
 
+func (m *TaskConfig) GetKillSignal() string {
+	if m != nil {
+		return m.KillSignal
+	}
+	return ""
+}
+
 type Resources struct {
 	// AllocatedResources are the resources set for the task
 	AllocatedResources *AllocatedTaskResources `protobuf:"bytes,1,opt,name=allocated_resources,json=allocatedResources,proto3" json:"allocated_resources,omitempty"`
@@ -3682,243 +3702,244 @@ func init() {
 }
 
 var fileDescriptor_4a8f45747846a74d = []byte{
-	// 3776 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0xcd, 0x6f, 0x1b, 0x49,
-	0x76, 0x57, 0xb3, 0xf9, 0xf9, 0x48, 0x51, 0xad, 0xb2, 0x6c, 0xd3, 0x9c, 0x24, 0xe3, 0xed, 0x60,
-	0x02, 0x61, 0x77, 0x86, 0x9e, 0xd5, 0x22, 0xe3, 0xf1, 0xac, 0x67, 0x3d, 0x1c, 0x8a, 0xb6, 0x34,
-	0x96, 0x28, 0xa5, 0x48, 0xc1, 0xeb, 0x38, 0x3b, 0x9d, 0x16, 0xbb, 0x4c, 0xb5, 0xc5, 0xfe, 0x98,
-	0xae, 0xa6, 0x2c, 0x6d, 0x10, 0x24, 0xd8, 0x00, 0xc1, 0x06, 0x48, 0x90, 0x5c, 0x26, 0x7b, 0xc9,
-	0x69, 0x81, 0x9c, 0xf2, 0x0f, 0x04, 0x1b, 0xec, 0x29, 0x87, 0xfc, 0x13, 0xb9, 0xe4, 0x96, 0x63,
-	0x72, 0xca, 0x35, 0xa8, 0x8f, 0x6e, 0x76, 0x93, 0xf4, 0xb8, 0x49, 0xf9, 0xc4, 0x7e, 0xf5, 0xf1,
-	0xab, 0xc7, 0xf7, 0x5e, 0xbd, 0x7a, 0xf5, 0xea, 0x81, 0xee, 0x8f, 0x27, 0x23, 0xdb, 0xa5, 0xf7,
-	0xac, 0xc0, 0xbe, 0x20, 0x01, 0xbd, 0xe7, 0x07, 0x5e, 0xe8, 0x49, 0xaa, 0xc5, 0x09, 0xf4, 0xc1,
-	0x99, 0x49, 0xcf, 0xec, 0xa1, 0x17, 0xf8, 0x2d, 0xd7, 0x73, 0x4c, 0xab, 0x25, 0xe7, 0xb4, 0xe4,
-	0x1c, 0x31, 0xac, 0xf9, 0x7b, 0x23, 0xcf, 0x1b, 0x8d, 0x89, 0x40, 0x38, 0x9d, 0xbc, 0xbc, 0x67,
-	0x4d, 0x02, 0x33, 0xb4, 0x3d, 0x57, 0xf6, 0xbf, 0x3f, 0xdb, 0x1f, 0xda, 0x0e, 0xa1, 0xa1, 0xe9,
-	0xf8, 0x72, 0xc0, 0x07, 0x11, 0x2f, 0xf4, 0xcc, 0x0c, 0x88, 0x75, 0xef, 0x6c, 0x38, 0xa6, 0x3e,
-	0x19, 0xb2, 0x5f, 0x83, 0x7d, 0xc8, 0x61, 0x1f, 0xce, 0x0c, 0xa3, 0x61, 0x30, 0x19, 0x86, 0x11,
-	0xe7, 0x66, 0x18, 0x06, 0xf6, 0xe9, 0x24, 0x24, 0x62, 0xb4, 0x7e, 0x07, 0x6e, 0x0f, 0x4c, 0x7a,
-	0xde, 0xf1, 0xdc, 0x97, 0xf6, 0xa8, 0x3f, 0x3c, 0x23, 0x8e, 0x89, 0xc9, 0x37, 0x13, 0x42, 0x43,
-	0xfd, 0x4f, 0xa0, 0x31, 0xdf, 0x45, 0x7d, 0xcf, 0xa5, 0x04, 0x7d, 0x01, 0x79, 0xb6, 0x64, 0x43,
-	0xb9, 0xab, 0x6c, 0x57, 0x77, 0x3e, 0x6c, 0xbd, 0x49, 0x04, 0x82, 0x87, 0x96, 0x64, 0xb5, 0xd5,
-	0xf7, 0xc9, 0x10, 0xf3, 0x99, 0xfa, 0x4d, 0xb8, 0xd1, 0x31, 0x7d, 0xf3, 0xd4, 0x1e, 0xdb, 0xa1,
-	0x4d, 0x68, 0xb4, 0xe8, 0x04, 0xb6, 0xd2, 0xcd, 0x72, 0xc1, 0x9f, 0x41, 0x6d, 0x98, 0x68, 0x97,
-	0x0b, 0x3f, 0x68, 0x65, 0x92, 0x7d, 0x6b, 0x97, 0x53, 0x29, 0xe0, 0x14, 0x9c, 0xbe, 0x05, 0xe8,
-	0xb1, 0xed, 0x8e, 0x48, 0xe0, 0x07, 0xb6, 0x1b, 0x46, 0xcc, 0xfc, 0x56, 0x85, 0x1b, 0xa9, 0x66,
-	0xc9, 0xcc, 0x2b, 0x80, 0x58, 0x8e, 0x8c, 0x15, 0x75, 0xbb, 0xba, 0xf3, 0x55, 0x46, 0x56, 0x16,
-	0xe0, 0xb5, 0xda, 0x31, 0x58, 0xd7, 0x0d, 0x83, 0x2b, 0x9c, 0x40, 0x47, 0x5f, 0x43, 0xf1, 0x8c,
-	0x98, 0xe3, 0xf0, 0xac, 0x91, 0xbb, 0xab, 0x6c, 0xd7, 0x77, 0x1e, 0x5f, 0x63, 0x9d, 0x3d, 0x0e,
-	0xd4, 0x0f, 0xcd, 0x90, 0x60, 0x89, 0x8a, 0x3e, 0x02, 0x24, 0xbe, 0x0c, 0x8b, 0xd0, 0x61, 0x60,
-	0xfb, 0xcc, 0x24, 0x1b, 0xea, 0x5d, 0x65, 0xbb, 0x82, 0x37, 0x45, 0xcf, 0xee, 0xb4, 0xa3, 0xe9,
-	0xc3, 0xc6, 0x0c, 0xb7, 0x48, 0x03, 0xf5, 0x9c, 0x5c, 0x71, 0x8d, 0x54, 0x30, 0xfb, 0x44, 0x4f,
-	0xa0, 0x70, 0x61, 0x8e, 0x27, 0x84, 0xb3, 0x5c, 0xdd, 0xf9, 0xe1, 0xdb, 0xcc, 0x43, 0x9a, 0xe8,
-	0x54, 0x0e, 0x58, 0xcc, 0xff, 0x2c, 0xf7, 0xa9, 0xa2, 0x3f, 0x80, 0x6a, 0x82, 0x6f, 0x54, 0x07,
-	0x38, 0xe9, 0xed, 0x76, 0x07, 0xdd, 0xce, 0xa0, 0xbb, 0xab, 0xad, 0xa1, 0x75, 0xa8, 0x9c, 0xf4,
-	0xf6, 0xba, 0xed, 0x83, 0xc1, 0xde, 0x73, 0x4d, 0x41, 0x55, 0x28, 0x45, 0x44, 0x4e, 0xbf, 0x04,
-	0x84, 0xc9, 0xd0, 0xbb, 0x20, 0x01, 0x33, 0x64, 0xa9, 0x55, 0x74, 0x1b, 0x4a, 0xa1, 0x49, 0xcf,
-	0x0d, 0xdb, 0x92, 0x3c, 0x17, 0x19, 0xb9, 0x6f, 0xa1, 0x7d, 0x28, 0x9e, 0x99, 0xae, 0x35, 0x7e,
-	0x3b, 0xdf, 0x69, 0x51, 0x33, 0xf0, 0x3d, 0x3e, 0x11, 0x4b, 0x00, 0x66, 0xdd, 0xa9, 0x95, 0x85,
-	0x02, 0xf4, 0xe7, 0xa0, 0xf5, 0x43, 0x33, 0x08, 0x93, 0xec, 0x74, 0x21, 0xcf, 0xd6, 0x97, 0x16,
-	0xbd, 0xcc, 0x9a, 0x62, 0x67, 0x62, 0x3e, 0x5d, 0xff, 0xdf, 0x1c, 0x6c, 0x26, 0xb0, 0xa5, 0xa5,
-	0x3e, 0x83, 0x62, 0x40, 0xe8, 0x64, 0x1c, 0x72, 0xf8, 0xfa, 0xce, 0xa3, 0x8c, 0xf0, 0x73, 0x48,
-	0x2d, 0xcc, 0x61, 0xb0, 0x84, 0x43, 0xdb, 0xa0, 0x89, 0x19, 0x06, 0x09, 0x02, 0x2f, 0x30, 0x1c,
-	0x3a, 0xe2, 0x52, 0xab, 0xe0, 0xba, 0x68, 0xef, 0xb2, 0xe6, 0x43, 0x3a, 0x4a, 0x48, 0x55, 0xbd,
-	0xa6, 0x54, 0x91, 0x09, 0x9a, 0x4b, 0xc2, 0xd7, 0x5e, 0x70, 0x6e, 0x30, 0xd1, 0x06, 0xb6, 0x45,
-	0x1a, 0x79, 0x0e, 0xfa, 0x49, 0x46, 0xd0, 0x9e, 0x98, 0x7e, 0x24, 0x67, 0xe3, 0x0d, 0x37, 0xdd,
-	0xa0, 0xff, 0x00, 0x8a, 0xe2, 0x9f, 0x32, 0x4b, 0xea, 0x9f, 0x74, 0x3a, 0xdd, 0x7e, 0x5f, 0x5b,
-	0x43, 0x15, 0x28, 0xe0, 0xee, 0x00, 0x33, 0x0b, 0xab, 0x40, 0xe1, 0x71, 0x7b, 0xd0, 0x3e, 0xd0,
-	0x72, 0xfa, 0xf7, 0x61, 0xe3, 0x99, 0x69, 0x87, 0x59, 0x8c, 0x4b, 0xf7, 0x40, 0x9b, 0x8e, 0x95,
-	0xda, 0xd9, 0x4f, 0x69, 0x27, 0xbb, 0x68, 0xba, 0x97, 0x76, 0x38, 0xa3, 0x0f, 0x0d, 0x54, 0x12,
-	0x04, 0x52, 0x05, 0xec, 0x53, 0x7f, 0x0d, 0x1b, 0xfd, 0xd0, 0xf3, 0x33, 0x59, 0xfe, 0x8f, 0xa0,
-	0xc4, 0x4e, 0x1b, 0x6f, 0x12, 0x4a, 0xd3, 0xbf, 0xd3, 0x12, 0xa7, 0x51, 0x2b, 0x3a, 0x8d, 0x5a,
-	0xbb, 0xf2, 0xb4, 0xc2, 0xd1, 0x48, 0x74, 0x0b, 0x8a, 0xd4, 0x1e, 0xb9, 0xe6, 0x58, 0x7a, 0x0b,
-	0x49, 0xe9, 0x88, 0x19, 0x79, 0xb4, 0xb0, 0x34, 0xfc, 0x0e, 0xa0, 0x5d, 0x42, 0xc3, 0xc0, 0xbb,
-	0xca, 0xc4, 0xcf, 0x16, 0x14, 0x5e, 0x7a, 0xc1, 0x50, 0x6c, 0xc4, 0x32, 0x16, 0x04, 0xdb, 0x54,
-	0x29, 0x10, 0x89, 0xfd, 0x11, 0xa0, 0x7d, 0x97, 0x9d, 0x29, 0xd9, 0x14, 0xf1, 0x0f, 0x39, 0xb8,
-	0x91, 0x1a, 0x2f, 0x95, 0xb1, 0xfa, 0x3e, 0x64, 0x8e, 0x69, 0x42, 0xc5, 0x3e, 0x44, 0x47, 0x50,
-	0x14, 0x23, 0xa4, 0x24, 0xef, 0x2f, 0x01, 0x24, 0x8e, 0x29, 0x09, 0x27, 0x61, 0x16, 0x1a, 0xbd,
-	0xfa, 0x6e, 0x8d, 0xfe, 0x35, 0x68, 0xd1, 0xff, 0xa0, 0x6f, 0xd5, 0xcd, 0x57, 0x70, 0x63, 0xe8,
-	0x8d, 0xc7, 0x64, 0xc8, 0xac, 0xc1, 0xb0, 0xdd, 0x90, 0x04, 0x17, 0xe6, 0xf8, 0xed, 0x76, 0x83,
-	0xa6, 0xb3, 0xf6, 0xe5, 0x24, 0xfd, 0x05, 0x6c, 0x26, 0x16, 0x96, 0x8a, 0x78, 0x0c, 0x05, 0xca,
-	0x1a, 0xa4, 0x26, 0x3e, 0x5e, 0x52, 0x13, 0x14, 0x8b, 0xe9, 0xfa, 0x0d, 0x01, 0xde, 0xbd, 0x20,
-	0x6e, 0xfc, 0xb7, 0xf4, 0x5d, 0xd8, 0xec, 0x73, 0x33, 0xcd, 0x64, 0x87, 0x53, 0x13, 0xcf, 0xa5,
-	0x4c, 0x7c, 0x0b, 0x50, 0x12, 0x45, 0x1a, 0xe2, 0x15, 0x6c, 0x74, 0x2f, 0xc9, 0x30, 0x13, 0x72,
-	0x03, 0x4a, 0x43, 0xcf, 0x71, 0x4c, 0xd7, 0x6a, 0xe4, 0xee, 0xaa, 0xdb, 0x15, 0x1c, 0x91, 0xc9,
-	0xbd, 0xa8, 0x66, 0xdd, 0x8b, 0xfa, 0xdf, 0x29, 0xa0, 0x4d, 0xd7, 0x96, 0x82, 0x64, 0xdc, 0x87,
-	0x16, 0x03, 0x62, 0x6b, 0xd7, 0xb0, 0xa4, 0x64, 0x7b, 0xe4, 0x2e, 0x44, 0x3b, 0x09, 0x82, 0x84,
-	0x3b, 0x52, 0xaf, 0xe9, 0x8e, 0xf4, 0x3d, 0xf8, 0x9d, 0x88, 0x9d, 0x7e, 0x18, 0x10, 0xd3, 0xb1,
-	0xdd, 0xd1, 0xfe, 0xd1, 0x91, 0x4f, 0x04, 0xe3, 0x08, 0x41, 0xde, 0x32, 0x43, 0x53, 0x32, 0xc6,
-	0xbf, 0xd9, 0xa6, 0x1f, 0x8e, 0x3d, 0x1a, 0x6f, 0x7a, 0x4e, 0xe8, 0xff, 0xa1, 0x42, 0x63, 0x0e,
-	0x2a, 0x12, 0xef, 0x0b, 0x28, 0x50, 0x12, 0x4e, 0x7c, 0x69, 0x2a, 0xdd, 0xcc, 0x0c, 0x2f, 0xc6,
-	0x6b, 0xf5, 0x19, 0x18, 0x16, 0x98, 0x68, 0x04, 0xe5, 0x30, 0xbc, 0x32, 0xa8, 0xfd, 0xf3, 0x28,
-	0x20, 0x38, 0xb8, 0x2e, 0xfe, 0x80, 0x04, 0x8e, 0xed, 0x9a, 0xe3, 0xbe, 0xfd, 0x73, 0x82, 0x4b,
-	0x61, 0x78, 0xc5, 0x3e, 0xd0, 0x73, 0x66, 0xf0, 0x96, 0xed, 0x4a, 0xb1, 0x77, 0x56, 0x5d, 0x25,
-	0x21, 0x60, 0x2c, 0x10, 0x9b, 0x07, 0x50, 0xe0, 0xff, 0x69, 0x15, 0x43, 0xd4, 0x40, 0x0d, 0xc3,
-	0x2b, 0xce, 0x54, 0x19, 0xb3, 0xcf, 0xe6, 0x43, 0xa8, 0x25, 0xff, 0x01, 0x33, 0xa4, 0x33, 0x62,
-	0x8f, 0xce, 0x84, 0x81, 0x15, 0xb0, 0xa4, 0x98, 0x26, 0x5f, 0xdb, 0x96, 0x0c, 0x59, 0x0b, 0x58,
-	0x10, 0xfa, 0xbf, 0xe6, 0xe0, 0xce, 0x02, 0xc9, 0x48, 0x63, 0x7d, 0x91, 0x32, 0xd6, 0x77, 0x24,
-	0x85, 0xc8, 0xe2, 0x5f, 0xa4, 0x2c, 0xfe, 0x1d, 0x82, 0xb3, 0x6d, 0x73, 0x0b, 0x8a, 0xe4, 0xd2,
-	0x0e, 0x89, 0x25, 0x45, 0x25, 0xa9, 0xc4, 0x76, 0xca, 0x5f, 0x77, 0x3b, 0x1d, 0xc2, 0x56, 0x27,
-	0x20, 0x66, 0x48, 0xa4, 0x2b, 0x8f, 0xec, 0xff, 0x0e, 0x94, 0xcd, 0xf1, 0xd8, 0x1b, 0x4e, 0xd5,
-	0x5a, 0xe2, 0xf4, 0xbe, 0x85, 0x9a, 0x50, 0x3e, 0xf3, 0x68, 0xe8, 0x9a, 0x0e, 0x91, 0xce, 0x2b,
-	0xa6, 0xf5, 0x6f, 0x15, 0xb8, 0x39, 0x83, 0x27, 0xb5, 0x70, 0x0a, 0x75, 0x9b, 0x7a, 0x63, 0xfe,
-	0x07, 0x8d, 0xc4, 0x0d, 0xef, 0xc7, 0xcb, 0x1d, 0x35, 0xfb, 0x11, 0x06, 0xbf, 0xf0, 0xad, 0xdb,
-	0x49, 0x92, 0x5b, 0x1c, 0x5f, 0xdc, 0x92, 0x3b, 0x3d, 0x22, 0xf5, 0x7f, 0x54, 0xe0, 0xa6, 0x3c,
-	0xe1, 0xb3, 0xff, 0xd1, 0x79, 0x96, 0x73, 0xef, 0x9a, 0x65, 0xbd, 0x01, 0xb7, 0x66, 0xf9, 0x92,
-	0x3e, 0xff, 0xff, 0xf2, 0x80, 0xe6, 0x6f, 0x97, 0xe8, 0x7b, 0x50, 0xa3, 0xc4, 0xb5, 0x0c, 0x71,
-	0x5e, 0x88, 0xa3, 0xac, 0x8c, 0xab, 0xac, 0x4d, 0x1c, 0x1c, 0x94, 0xb9, 0x40, 0x72, 0x29, 0xb9,
-	0x2d, 0x63, 0xfe, 0x8d, 0xce, 0xa0, 0xf6, 0x92, 0x1a, 0xf1, 0xda, 0xdc, 0xa0, 0xea, 0x99, 0xdd,
-	0xda, 0x3c, 0x1f, 0xad, 0xc7, 0xfd, 0xf8, 0x7f, 0xe1, 0xea, 0x4b, 0x1a, 0x13, 0xe8, 0x97, 0x0a,
-	0xdc, 0x8e, 0xc2, 0x8a, 0xa9, 0xf8, 0x1c, 0xcf, 0x22, 0xb4, 0x91, 0xbf, 0xab, 0x6e, 0xd7, 0x77,
-	0x8e, 0xaf, 0x21, 0xbf, 0xb9, 0xc6, 0x43, 0xcf, 0x22, 0xf8, 0xa6, 0xbb, 0xa0, 0x95, 0xa2, 0x16,
-	0xdc, 0x70, 0x26, 0x34, 0x34, 0x84, 0x15, 0x18, 0x72, 0x50, 0xa3, 0xc0, 0xe5, 0xb2, 0xc9, 0xba,
-	0x52, 0xb6, 0x8a, 0xce, 0x61, 0xdd, 0xf1, 0x26, 0x6e, 0x68, 0x0c, 0xf9, 0xfd, 0x87, 0x36, 0x8a,
-	0x4b, 0x5d, 0x8c, 0x17, 0x48, 0xe9, 0x90, 0xc1, 0x89, 0xdb, 0x14, 0xc5, 0x35, 0x27, 0x41, 0x31,
-	0x45, 0x06, 0xc4, 0xf1, 0x42, 0x62, 0x30, 0x7f, 0x49, 0x1b, 0x25, 0xa1, 0x48, 0xd1, 0xc6, 0x5c,
-	0x03, 0xd5, 0x5b, 0x50, 0x4d, 0x88, 0x19, 0x95, 0x21, 0xdf, 0x3b, 0xea, 0x75, 0xb5, 0x35, 0x04,
-	0x50, 0xec, 0xec, 0xe1, 0xa3, 0xa3, 0x81, 0xb8, 0x35, 0xec, 0x1f, 0xb6, 0x9f, 0x74, 0xb5, 0x9c,
-	0xde, 0x85, 0x5a, 0x72, 0x41, 0x84, 0xa0, 0x7e, 0xd2, 0x7b, 0xda, 0x3b, 0x7a, 0xd6, 0x33, 0x0e,
-	0x8f, 0x4e, 0x7a, 0x03, 0x76, 0xdf, 0xa8, 0x03, 0xb4, 0x7b, 0xcf, 0xa7, 0xf4, 0x3a, 0x54, 0x7a,
-	0x47, 0x11, 0xa9, 0x34, 0x73, 0x9a, 0xa2, 0xff, 0xbb, 0x0a, 0x5b, 0x8b, 0x64, 0x8f, 0x2c, 0xc8,
-	0x33, 0x3d, 0xca, 0x1b, 0xdf, 0xbb, 0x57, 0x23, 0x47, 0x67, 0xe6, 0xeb, 0x9b, 0xd2, 0xc5, 0x57,
-	0x30, 0xff, 0x46, 0x06, 0x14, 0xc7, 0xe6, 0x29, 0x19, 0xd3, 0x86, 0xca, 0x73, 0x22, 0x4f, 0xae,
-	0xb3, 0xf6, 0x01, 0x47, 0x12, 0x09, 0x11, 0x09, 0x8b, 0x06, 0x50, 0x65, 0x4e, 0x8c, 0x0a, 0xd1,
-	0x49, 0xbf, 0xba, 0x93, 0x71, 0x95, 0xbd, 0xe9, 0x4c, 0x9c, 0x84, 0x69, 0x3e, 0x80, 0x6a, 0x62,
-	0xb1, 0x05, 0xf9, 0x8c, 0xad, 0x64, 0x3e, 0xa3, 0x92, 0x4c, 0x4e, 0x3c, 0x9a, 0xd7, 0x01, 0x93,
-	0x11, 0x33, 0x82, 0xbd, 0xa3, 0xfe, 0x40, 0xdc, 0x1c, 0x9f, 0xe0, 0xa3, 0x93, 0x63, 0x4d, 0x61,
-	0x8d, 0x83, 0x76, 0xff, 0xa9, 0x96, 0x8b, 0x6d, 0x44, 0xd5, 0x3b, 0x50, 0x4d, 0xf0, 0x95, 0xf2,
-	0xda, 0x4a, 0xda, 0x6b, 0x33, 0xbf, 0x69, 0x5a, 0x56, 0x40, 0x28, 0x95, 0x7c, 0x44, 0xa4, 0xfe,
-	0x02, 0x2a, 0xbb, 0xbd, 0xbe, 0x84, 0x68, 0x40, 0x89, 0x92, 0x80, 0xfd, 0x6f, 0x9e, 0x99, 0xaa,
-	0xe0, 0x88, 0x64, 0xe0, 0x94, 0x98, 0xc1, 0xf0, 0x8c, 0x50, 0x79, 0xd6, 0xc7, 0x34, 0x9b, 0xe5,
-	0xf1, 0x0c, 0x8f, 0xd0, 0x5d, 0x05, 0x47, 0xa4, 0xfe, 0x3f, 0x25, 0x80, 0x69, 0xb6, 0x01, 0xd5,
-	0x21, 0x17, 0xfb, 0xe0, 0x9c, 0x6d, 0x31, 0x3b, 0x48, 0x9c, 0x31, 0xfc, 0x1b, 0xed, 0xc0, 0x4d,
-	0x87, 0x8e, 0x7c, 0x73, 0x78, 0x6e, 0xc8, 0x24, 0x81, 0xd8, 0xaa, 0xdc, 0x9f, 0xd5, 0xf0, 0x0d,
-	0xd9, 0x29, 0x77, 0xa2, 0xc0, 0x3d, 0x00, 0x95, 0xb8, 0x17, 0xdc, 0xf7, 0x54, 0x77, 0x3e, 0x5b,
-	0x3a, 0x0b, 0xd2, 0xea, 0xba, 0x17, 0xc2, 0x56, 0x18, 0x0c, 0x32, 0x00, 0x2c, 0x72, 0x61, 0x0f,
-	0x89, 0xc1, 0x40, 0x0b, 0x1c, 0xf4, 0x8b, 0xe5, 0x41, 0x77, 0x39, 0x46, 0x0c, 0x5d, 0xb1, 0x22,
-	0x1a, 0xf5, 0xa0, 0x12, 0x10, 0xea, 0x4d, 0x82, 0x21, 0x11, 0x0e, 0x28, 0xfb, 0x45, 0x05, 0x47,
-	0xf3, 0xf0, 0x14, 0x02, 0xed, 0x42, 0x91, 0xfb, 0x1d, 0xe6, 0x61, 0xd4, 0xef, 0x4c, 0xa9, 0xa6,
-	0xc1, 0xb8, 0x27, 0xc1, 0x72, 0x2e, 0x7a, 0x02, 0x25, 0xc1, 0x22, 0x6d, 0x94, 0x39, 0xcc, 0x47,
-	0x59, 0x9d, 0x22, 0x9f, 0x85, 0xa3, 0xd9, 0x4c, 0xab, 0x13, 0x4a, 0x82, 0x46, 0x45, 0x68, 0x95,
-	0x7d, 0xa3, 0xf7, 0xa0, 0x22, 0xce, 0x60, 0xcb, 0x0e, 0x1a, 0x20, 0x8c, 0x93, 0x37, 0xec, 0xda,
-	0x01, 0x7a, 0x1f, 0xaa, 0x22, 0xd6, 0x32, 0xb8, 0x57, 0xa8, 0xf2, 0x6e, 0x10, 0x4d, 0xc7, 0xcc,
-	0x37, 0x88, 0x01, 0x24, 0x08, 0xc4, 0x80, 0x5a, 0x3c, 0x80, 0x04, 0x01, 0x1f, 0xf0, 0x07, 0xb0,
-	0xc1, 0x23, 0xd4, 0x51, 0xe0, 0x4d, 0x7c, 0x83, 0xdb, 0xd4, 0x3a, 0x1f, 0xb4, 0xce, 0x9a, 0x9f,
-	0xb0, 0xd6, 0x1e, 0x33, 0xae, 0x3b, 0x50, 0x7e, 0xe5, 0x9d, 0x8a, 0x01, 0x75, 0xb1, 0x0f, 0x5e,
-	0x79, 0xa7, 0x51, 0x57, 0x1c, 0x25, 0x6c, 0xa4, 0xa3, 0x84, 0x6f, 0xe0, 0xd6, 0xfc, 0x71, 0xc7,
-	0xa3, 0x05, 0xed, 0xfa, 0xd1, 0xc2, 0x96, 0xbb, 0xc8, 0x0f, 0x7f, 0x09, 0xaa, 0xe5, 0xd2, 0xc6,
-	0xe6, 0x52, 0xc6, 0x11, 0xef, 0x63, 0xcc, 0x26, 0x37, 0x3f, 0x81, 0x72, 0x64, 0x7d, 0xcb, 0xf8,
-	0xa5, 0xe6, 0x43, 0xa8, 0xa7, 0x6d, 0x77, 0x29, 0xaf, 0xf6, 0xcf, 0x39, 0xa8, 0xc4, 0x56, 0x8a,
-	0x5c, 0xb8, 0xc1, 0xa5, 0xc8, 0x42, 0x34, 0x63, 0x6a, 0xf4, 0x22, 0x30, 0xfc, 0x3c, 0xe3, 0xff,
-	0x6a, 0x47, 0x08, 0xf2, 0x86, 0x2a, 0x77, 0x00, 0x8a, 0x91, 0xa7, 0xeb, 0x7d, 0x0d, 0x1b, 0x63,
-	0xdb, 0x9d, 0x5c, 0x26, 0xd6, 0x12, 0x11, 0xdd, 0x1f, 0x66, 0x5c, 0xeb, 0x80, 0xcd, 0x9e, 0xae,
-	0x51, 0x1f, 0xa7, 0x68, 0xb4, 0x07, 0x05, 0xdf, 0x0b, 0xc2, 0xe8, 0x90, 0xca, 0x7a, 0x7c, 0x1c,
-	0x7b, 0x41, 0x78, 0x68, 0xfa, 0x3e, 0xbb, 0xb4, 0x08, 0x00, 0xfd, 0xdb, 0x1c, 0xdc, 0x5a, 0xfc,
-	0xc7, 0x50, 0x0f, 0xd4, 0xa1, 0x3f, 0x91, 0x42, 0x7a, 0xb8, 0xac, 0x90, 0x3a, 0xfe, 0x64, 0xca,
-	0x3f, 0x03, 0x42, 0xcf, 0xa0, 0xe8, 0x10, 0xc7, 0x0b, 0xae, 0xa4, 0x2c, 0x1e, 0x2d, 0x0b, 0x79,
-	0xc8, 0x67, 0x4f, 0x51, 0x25, 0x1c, 0xc2, 0x50, 0x96, 0xd6, 0x4b, 0xa5, 0x9f, 0x5c, 0x32, 0xad,
-	0x14, 0x41, 0xe2, 0x18, 0x47, 0xff, 0x04, 0x6e, 0x2e, 0xfc, 0x2b, 0xe8, 0x77, 0x01, 0x86, 0xfe,
-	0xc4, 0xe0, 0x69, 0x7f, 0x61, 0x41, 0x2a, 0xae, 0x0c, 0xfd, 0x49, 0x9f, 0x37, 0xe8, 0x2f, 0xa0,
-	0xf1, 0x26, 0x7e, 0x99, 0xf7, 0x11, 0x1c, 0x1b, 0xce, 0x29, 0x97, 0x81, 0x8a, 0xcb, 0xa2, 0xe1,
-	0xf0, 0x14, 0xe9, 0xb0, 0x1e, 0x75, 0x9a, 0x97, 0x6c, 0x80, 0xca, 0x07, 0x54, 0xe5, 0x00, 0xf3,
-	0xf2, 0xf0, 0x54, 0xff, 0x55, 0x0e, 0x36, 0x66, 0x58, 0x66, 0x57, 0x37, 0xe1, 0xf1, 0xa2, 0x4b,
-	0xb1, 0xa0, 0x98, 0xfb, 0x1b, 0xda, 0x56, 0x94, 0x4e, 0xe5, 0xdf, 0xfc, 0xe0, 0xf3, 0x65, 0xaa,
-	0x33, 0x67, 0xfb, 0x6c, 0xfb, 0x38, 0xa7, 0x76, 0x48, 0x79, 0x14, 0x52, 0xc0, 0x82, 0x40, 0xcf,
-	0xa1, 0x1e, 0x10, 0x7e, 0xe0, 0x5a, 0x86, 0xb0, 0xb2, 0xc2, 0x52, 0x56, 0x26, 0x39, 0x64, 0xc6,
-	0x86, 0xd7, 0x23, 0x24, 0x46, 0x51, 0xf4, 0x0c, 0xd6, 0xad, 0x2b, 0xd7, 0x74, 0xec, 0xa1, 0x44,
-	0x2e, 0xae, 0x8c, 0x5c, 0x93, 0x40, 0x1c, 0x58, 0x7f, 0x00, 0xd5, 0x44, 0x27, 0xfb, 0x63, 0x3c,
-	0xdc, 0x92, 0x32, 0x11, 0x44, 0xda, 0x5b, 0x14, 0xa4, 0xb7, 0xd0, 0x4f, 0xa1, 0x9a, 0xd8, 0x17,
-	0xcb, 0x4c, 0x65, 0xf2, 0x0c, 0x3d, 0x2e, 0xcf, 0x02, 0xce, 0x85, 0x1e, 0xba, 0x0d, 0x25, 0x16,
-	0xea, 0x18, 0xb6, 0xcf, 0x25, 0x5a, 0xc1, 0x45, 0x46, 0xee, 0xfb, 0xfa, 0x6f, 0x72, 0x50, 0x4f,
-	0x6f, 0xe9, 0xc8, 0x8e, 0x7c, 0x12, 0xd8, 0x9e, 0x95, 0xb0, 0xa3, 0x63, 0xde, 0xc0, 0x6c, 0x85,
-	0x75, 0x7f, 0x33, 0xf1, 0x42, 0x33, 0xb2, 0x95, 0xa1, 0x3f, 0xf9, 0x23, 0x46, 0xcf, 0xd8, 0xa0,
-	0x3a, 0x63, 0x83, 0xe8, 0x43, 0x40, 0xd2, 0x94, 0xc6, 0xb6, 0x63, 0x87, 0xc6, 0xe9, 0x55, 0x48,
-	0x84, 0x8e, 0x55, 0xac, 0x89, 0x9e, 0x03, 0xd6, 0xf1, 0x25, 0x6b, 0x67, 0x86, 0xe7, 0x79, 0x8e,
-	0x41, 0x87, 0x5e, 0x40, 0x0c, 0xd3, 0x7a, 0xc5, 0x6f, 0x2d, 0x2a, 0xae, 0x7a, 0x9e, 0xd3, 0x67,
-	0x6d, 0x6d, 0xeb, 0x15, 0x3b, 0xf9, 0x86, 0xfe, 0x84, 0x92, 0xd0, 0x60, 0x3f, 0x3c, 0x58, 0xa8,
-	0x60, 0x10, 0x4d, 0x1d, 0x7f, 0x42, 0xd1, 0xef, 0xc3, 0x7a, 0x34, 0x80, 0x1f, 0x7e, 0xf2, 0xd4,
-	0xad, 0xc9, 0x21, 0xbc, 0x0d, 0xe9, 0x50, 0x3b, 0x26, 0xc1, 0x90, 0xb8, 0xe1, 0xc0, 0x1e, 0x9e,
-	0xb3, 0xf3, 0x5d, 0xd9, 0x56, 0x70, 0xaa, 0xed, 0xab, 0x7c, 0xb9, 0xa4, 0x95, 0x71, 0xb4, 0x9a,
-	0x43, 0x1c, 0xaa, 0xff, 0x0c, 0x0a, 0x3c, 0x44, 0x60, 0x32, 0xe1, 0xc7, 0x2b, 0x3f, 0x7d, 0x65,
-	0x68, 0xc9, 0x1a, 0xf8, 0xd9, 0xfb, 0x1e, 0x54, 0xb8, 0xec, 0x13, 0x11, 0x3d, 0x8f, 0x3b, 0x79,
-	0x67, 0x13, 0xca, 0x01, 0x31, 0x2d, 0xcf, 0x1d, 0x47, 0xc9, 0xa0, 0x98, 0xd6, 0xbf, 0x81, 0xa2,
-	0x38, 0x67, 0xae, 0x81, 0xff, 0x11, 0x20, 0xf1, 0xbf, 0x99, 0x3e, 0x1d, 0x9b, 0x52, 0x19, 0x85,
-	0xf2, 0x17, 0x48, 0xd1, 0x73, 0x3c, 0xed, 0xd0, 0xff, 0x53, 0x11, 0xf1, 0xa8, 0x78, 0x1b, 0x62,
-	0x81, 0x2b, 0x33, 0x72, 0x76, 0x5b, 0x16, 0x49, 0xa8, 0x88, 0x44, 0xfb, 0x50, 0x94, 0x61, 0x67,
-	0x6e, 0xd5, 0xa7, 0x35, 0x09, 0x10, 0xa5, 0xa4, 0x89, 0xbc, 0x90, 0x2f, 0x9b, 0x92, 0x26, 0x22,
-	0x25, 0x4d, 0xd8, 0x6d, 0x52, 0x06, 0xc4, 0x02, 0x2e, 0xcf, 0xe3, 0xe1, 0xaa, 0x15, 0xe7, 0xfd,
-	0x89, 0xfe, 0xdf, 0x4a, 0xec, 0xa6, 0xa2, 0xfc, 0x3c, 0xfa, 0x1a, 0xca, 0x6c, 0xc7, 0x1b, 0x8e,
-	0xe9, 0xcb, 0xd7, 0xe6, 0xce, 0x6a, 0xa9, 0xff, 0xe8, 0x10, 0x13, 0xe1, 0x6c, 0xc9, 0x17, 0x14,
-	0x73, 0x77, 0xec, 0x2a, 0x11, 0xb9, 0x3b, 0xf6, 0x8d, 0x3e, 0x80, 0xba, 0x39, 0x09, 0x3d, 0xc3,
-	0xb4, 0x2e, 0x48, 0x10, 0xda, 0x94, 0x48, 0xdd, 0xaf, 0xb3, 0xd6, 0x76, 0xd4, 0xd8, 0xfc, 0x0c,
-	0x6a, 0x49, 0xcc, 0xb7, 0x85, 0x19, 0x85, 0x64, 0x98, 0xf1, 0xa7, 0x00, 0xd3, 0x5c, 0x17, 0xb3,
-	0x11, 0x72, 0x69, 0xb3, 0x5b, 0xbd, 0xbc, 0xbb, 0x16, 0x70, 0x99, 0x35, 0x74, 0xd8, 0x7d, 0x2a,
-	0x9d, 0x88, 0x2f, 0x44, 0x89, 0x78, 0xb6, 0x99, 0xd9, 0xfe, 0x3b, 0xb7, 0xc7, 0xe3, 0x38, 0xff,
-	0x56, 0xf1, 0x3c, 0xe7, 0x29, 0x6f, 0xd0, 0x7f, 0x9b, 0x13, 0xb6, 0x22, 0x9e, 0x54, 0x32, 0xdd,
-	0x5d, 0xde, 0x95, 0xaa, 0x1f, 0x00, 0xd0, 0xd0, 0x0c, 0x58, 0xcc, 0x64, 0x46, 0x19, 0xc0, 0xe6,
-	0x5c, 0x26, 0x7f, 0x10, 0xd5, 0x78, 0xe0, 0x8a, 0x1c, 0xdd, 0x0e, 0xd1, 0xe7, 0x50, 0x1b, 0x7a,
-	0x8e, 0x3f, 0x26, 0x72, 0x72, 0xe1, 0xad, 0x93, 0xab, 0xf1, 0xf8, 0x76, 0x98, 0xc8, 0x3b, 0x16,
-	0xaf, 0x9b, 0x77, 0xfc, 0x8d, 0x22, 0x5e, 0x86, 0x92, 0x0f, 0x53, 0x68, 0xb4, 0xa0, 0xfa, 0xe1,
-	0xc9, 0x8a, 0xaf, 0x5c, 0xdf, 0x55, 0xfa, 0xd0, 0xfc, 0x3c, 0x4b, 0xad, 0xc1, 0x9b, 0xa3, 0xd8,
-	0x7f, 0x53, 0xa1, 0x12, 0x3f, 0x0a, 0xcd, 0xe9, 0xfe, 0x53, 0xa8, 0xc4, 0x05, 0x36, 0xd2, 0x41,
-	0x7c, 0xa7, 0x7a, 0xe2, 0xc1, 0xe8, 0x25, 0x20, 0x73, 0x34, 0x8a, 0xa3, 0x53, 0x63, 0x42, 0xcd,
-	0x51, 0xf4, 0x24, 0xf7, 0xe9, 0x12, 0x72, 0x88, 0x8e, 0xb3, 0x13, 0x36, 0x1f, 0x6b, 0xe6, 0x68,
-	0x94, 0x6a, 0x41, 0x7f, 0x06, 0x37, 0xd3, 0x6b, 0x18, 0xa7, 0x57, 0x86, 0x6f, 0x5b, 0xf2, 0x8e,
-	0xbc, 0xb7, 0xec, 0xbb, 0x58, 0x2b, 0x05, 0xff, 0xe5, 0xd5, 0xb1, 0x6d, 0x09, 0x99, 0xa3, 0x60,
-	0xae, 0xa3, 0xf9, 0x17, 0x70, 0xfb, 0x0d, 0xc3, 0x17, 0xe8, 0xa0, 0x97, 0xae, 0xf7, 0x58, 0x5d,
-	0x08, 0x09, 0xed, 0xfd, 0x5a, 0x11, 0xcf, 0x77, 0x69, 0x99, 0xb4, 0x93, 0x61, 0xf5, 0xbd, 0x8c,
-	0xeb, 0x74, 0x8e, 0x4f, 0x04, 0x3c, 0x8f, 0xa4, 0xbf, 0x9a, 0x89, 0xa4, 0xb3, 0xc6, 0x4f, 0x22,
-	0x20, 0x15, 0x40, 0x12, 0x41, 0xff, 0x17, 0x15, 0xca, 0x11, 0x3a, 0xbf, 0xe1, 0x5e, 0xd1, 0x90,
-	0x38, 0x46, 0x9c, 0x7e, 0x53, 0x30, 0x88, 0x26, 0x9e, 0x14, 0x7a, 0x0f, 0x2a, 0xec, 0x22, 0x2d,
-	0xba, 0x73, 0xbc, 0xbb, 0xcc, 0x1a, 0x78, 0xe7, 0xfb, 0x50, 0x0d, 0xbd, 0xd0, 0x1c, 0x1b, 0x21,
-	0x3f, 0xde, 0x55, 0x31, 0x9b, 0x37, 0xf1, 0xc3, 0x1d, 0xfd, 0x00, 0x36, 0xc3, 0xb3, 0xc0, 0x0b,
-	0xc3, 0x31, 0x0b, 0x2d, 0x79, 0xa0, 0x23, 0xe2, 0x92, 0x3c, 0xd6, 0xe2, 0x0e, 0x11, 0x00, 0x51,
-	0xe6, 0xbd, 0xa7, 0x83, 0x99, 0xe9, 0x72, 0x27, 0x92, 0xc7, 0xeb, 0x71, 0x2b, 0x33, 0x6d, 0x76,
-	0x78, 0xfa, 0x22, 0x80, 0xe0, 0xbe, 0x42, 0xc1, 0x11, 0x89, 0x0c, 0xd8, 0x70, 0x88, 0x49, 0x27,
-	0x01, 0xb1, 0x8c, 0x97, 0x36, 0x19, 0x5b, 0x22, 0x31, 0x51, 0xcf, 0x7c, 0x3b, 0x88, 0xc4, 0xd2,
-	0x7a, 0xcc, 0x67, 0xe3, 0x7a, 0x04, 0x27, 0x68, 0x16, 0x39, 0x88, 0x2f, 0xb4, 0x01, 0xd5, 0xfe,
-	0xf3, 0xfe, 0xa0, 0x7b, 0x68, 0x1c, 0x1e, 0xed, 0x76, 0x65, 0x49, 0x4f, 0xbf, 0x8b, 0x05, 0xa9,
-	0xb0, 0xfe, 0xc1, 0xd1, 0xa0, 0x7d, 0x60, 0x0c, 0xf6, 0x3b, 0x4f, 0xfb, 0x5a, 0x0e, 0xdd, 0x84,
-	0xcd, 0xc1, 0x1e, 0x3e, 0x1a, 0x0c, 0x0e, 0xba, 0xbb, 0xc6, 0x71, 0x17, 0xef, 0x1f, 0xed, 0xf6,
-	0x35, 0x15, 0x21, 0xa8, 0x4f, 0x9b, 0x07, 0xfb, 0x87, 0x5d, 0x2d, 0x8f, 0xaa, 0x50, 0x3a, 0xee,
-	0xe2, 0x4e, 0xb7, 0x37, 0xd0, 0x0a, 0xfa, 0xaf, 0x54, 0xa8, 0x26, 0xb4, 0xc8, 0x0c, 0x39, 0xa0,
-	0xe2, 0x1a, 0x92, 0xc7, 0xec, 0x93, 0x3f, 0x41, 0x9a, 0xc3, 0x33, 0xa1, 0x9d, 0x3c, 0x16, 0x04,
-	0xbf, 0x7a, 0x98, 0x97, 0x89, 0x7d, 0x9e, 0xc7, 0x65, 0xc7, 0xbc, 0x14, 0x20, 0xdf, 0x83, 0xda,
-	0x39, 0x09, 0x5c, 0x32, 0x96, 0xfd, 0x42, 0x23, 0x55, 0xd1, 0x26, 0x86, 0x6c, 0x83, 0x26, 0x87,
-	0x4c, 0x61, 0x84, 0x3a, 0xea, 0xa2, 0xfd, 0x30, 0x02, 0xdb, 0x82, 0x82, 0xe8, 0x2e, 0x89, 0xf5,
-	0x39, 0xc1, 0x8e, 0x29, 0xfa, 0xda, 0xf4, 0x79, 0xc8, 0x97, 0xc7, 0xfc, 0x1b, 0x9d, 0xce, 0xeb,
-	0xa7, 0xc8, 0xf5, 0xf3, 0x60, 0x79, 0x73, 0x7e, 0x93, 0x8a, 0xce, 0x62, 0x15, 0x95, 0x40, 0xc5,
-	0x51, 0x1d, 0x4c, 0xa7, 0xdd, 0xd9, 0x63, 0x6a, 0x59, 0x87, 0xca, 0x61, 0xfb, 0xa7, 0xc6, 0x49,
-	0x9f, 0x67, 0xb5, 0x91, 0x06, 0xb5, 0xa7, 0x5d, 0xdc, 0xeb, 0x1e, 0xc8, 0x16, 0x15, 0x6d, 0x81,
-	0x26, 0x5b, 0xa6, 0xe3, 0xf2, 0x0c, 0x41, 0x7c, 0x16, 0x50, 0x19, 0xf2, 0xfd, 0x67, 0xed, 0x63,
-	0xad, 0xa8, 0xff, 0x57, 0x0e, 0x36, 0xc4, 0xb1, 0x10, 0xbf, 0xd8, 0xbf, 0xf9, 0xc5, 0x32, 0x99,
-	0xe5, 0xc9, 0xa5, 0xb3, 0x3c, 0x51, 0x10, 0xca, 0x4f, 0x75, 0x75, 0x1a, 0x84, 0xf2, 0xec, 0x50,
-	0xca, 0xe3, 0xe7, 0x97, 0xf1, 0xf8, 0x0d, 0x28, 0x39, 0x84, 0xc6, 0x7a, 0xab, 0xe0, 0x88, 0x44,
-	0x36, 0x54, 0x4d, 0xd7, 0xf5, 0x42, 0x53, 0xa4, 0x4e, 0x8b, 0x4b, 0x1d, 0x86, 0x33, 0xff, 0xb8,
-	0xd5, 0x9e, 0x22, 0x09, 0xc7, 0x9c, 0xc4, 0x6e, 0xfe, 0x04, 0xb4, 0xd9, 0x01, 0xcb, 0x1c, 0x87,
-	0xdf, 0xff, 0xe1, 0xf4, 0x34, 0x24, 0x6c, 0x5f, 0xc8, 0x37, 0x07, 0x6d, 0x8d, 0x11, 0xf8, 0xa4,
-	0xd7, 0xdb, 0xef, 0x3d, 0xd1, 0x14, 0x04, 0x50, 0xec, 0xfe, 0x74, 0x7f, 0xd0, 0xdd, 0xd5, 0x72,
-	0x3b, 0xbf, 0xde, 0x84, 0xa2, 0x60, 0x12, 0x7d, 0x2b, 0x23, 0x81, 0x64, 0x35, 0x28, 0xfa, 0xc9,
-	0xd2, 0x11, 0x75, 0xaa, 0xc2, 0xb4, 0xf9, 0x68, 0xe5, 0xf9, 0xf2, 0xf5, 0x6d, 0x0d, 0xfd, 0x8d,
-	0x02, 0xb5, 0xd4, 0xcb, 0x5b, 0xd6, 0xd4, 0xf1, 0x82, 0xe2, 0xd3, 0xe6, 0x8f, 0x57, 0x9a, 0x1b,
-	0xf3, 0xf2, 0x4b, 0x05, 0xaa, 0x89, 0xb2, 0x4b, 0xf4, 0x60, 0x95, 0x52, 0x4d, 0xc1, 0xc9, 0x67,
-	0xab, 0x57, 0x79, 0xea, 0x6b, 0x1f, 0x2b, 0xe8, 0xaf, 0x15, 0xa8, 0x26, 0x0a, 0x10, 0x33, 0xb3,
-	0x32, 0x5f, 0x2e, 0x99, 0x99, 0x95, 0x45, 0xf5, 0x8e, 0x6b, 0xe8, 0x2f, 0x15, 0xa8, 0xc4, 0xc5,
-	0x84, 0xe8, 0xfe, 0xf2, 0xe5, 0x87, 0x82, 0x89, 0x4f, 0x57, 0xad, 0x5b, 0xd4, 0xd7, 0xd0, 0x9f,
-	0x43, 0x39, 0xaa, 0xbc, 0x43, 0x59, 0x4f, 0xaf, 0x99, 0xb2, 0xbe, 0xe6, 0xfd, 0xa5, 0xe7, 0x25,
-	0x97, 0x8f, 0xca, 0xe1, 0x32, 0x2f, 0x3f, 0x53, 0xb8, 0xd7, 0xbc, 0xbf, 0xf4, 0xbc, 0x78, 0x79,
-	0x66, 0x09, 0x89, 0xaa, 0xb9, 0xcc, 0x96, 0x30, 0x5f, 0xae, 0x97, 0xd9, 0x12, 0x16, 0x15, 0xe9,
-	0x09, 0x46, 0x12, 0x75, 0x77, 0x99, 0x19, 0x99, 0xaf, 0xed, 0xcb, 0xcc, 0xc8, 0x82, 0x32, 0x3f,
-	0x7d, 0x0d, 0xfd, 0x42, 0x49, 0xde, 0x0b, 0xee, 0x2f, 0x5d, 0x5e, 0xb6, 0xa4, 0x49, 0xce, 0x15,
-	0xb8, 0xf1, 0x0d, 0xfa, 0x0b, 0x99, 0xc5, 0x10, 0xd5, 0x69, 0x68, 0x19, 0xb0, 0x54, 0x41, 0x5b,
-	0xf3, 0x93, 0xd5, 0x0e, 0x1b, 0xce, 0xc4, 0x5f, 0x29, 0x00, 0xd3, 0x3a, 0xb6, 0xcc, 0x4c, 0xcc,
-	0x15, 0xd0, 0x35, 0x1f, 0xac, 0x30, 0x33, 0xb9, 0x41, 0xa2, 0x3a, 0x9b, 0xcc, 0x1b, 0x64, 0xa6,
-	0xce, 0x2e, 0xf3, 0x06, 0x99, 0xad, 0x91, 0xd3, 0xd7, 0xd0, 0x3f, 0x29, 0xb0, 0x39, 0x57, 0xe7,
-	0x83, 0x1e, 0x5d, 0xb3, 0xd4, 0xab, 0xf9, 0xc5, 0xea, 0x00, 0x11, 0x6b, 0xdb, 0xca, 0xc7, 0x0a,
-	0xfa, 0x5b, 0x05, 0xd6, 0xd3, 0xf5, 0x0f, 0x99, 0x4f, 0xa9, 0x05, 0x15, 0x43, 0xcd, 0x87, 0xab,
-	0x4d, 0x8e, 0xa5, 0xf5, 0xf7, 0x0a, 0xd4, 0xd3, 0xa5, 0x30, 0xe8, 0xe1, 0x72, 0x6e, 0x61, 0x86,
-	0xa1, 0xcf, 0x57, 0x9c, 0x1d, 0x71, 0xf4, 0x65, 0xe9, 0x8f, 0x0b, 0x22, 0x7a, 0x2b, 0xf2, 0x9f,
-	0x1f, 0xfd, 0x7f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x76, 0x8b, 0xb6, 0x52, 0xb4, 0x33, 0x00, 0x00,
+	// 3791 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0x5f, 0x6f, 0x1b, 0x49,
+	0x72, 0xf7, 0xf0, 0x9f, 0xc8, 0x22, 0x45, 0x8d, 0xda, 0xb2, 0x97, 0xe6, 0x26, 0x59, 0xdf, 0x04,
+	0x1b, 0x18, 0x77, 0xbb, 0xf4, 0x9e, 0x0e, 0x59, 0xaf, 0x7d, 0xde, 0xf3, 0x72, 0x29, 0xda, 0xd2,
+	0x5a, 0xa2, 0x94, 0x26, 0x05, 0x9f, 0xe3, 0xdc, 0x4e, 0x46, 0x9c, 0x36, 0x35, 0x16, 0x39, 0x33,
+	0x3b, 0x3d, 0x94, 0xa5, 0x0b, 0x82, 0x04, 0x17, 0x20, 0xb8, 0x00, 0x09, 0x92, 0x00, 0xd9, 0xdc,
+	0x4b, 0x9e, 0x0e, 0xc8, 0x53, 0xbe, 0x40, 0x70, 0xc1, 0x3d, 0xe5, 0x21, 0x5f, 0x22, 0x2f, 0x79,
+	0xcb, 0x6b, 0xbe, 0x41, 0x50, 0xdd, 0x3d, 0xc3, 0x19, 0x51, 0x5e, 0x0f, 0x29, 0x3f, 0x71, 0xaa,
+	0xba, 0xfb, 0xd7, 0xc5, 0xaa, 0xea, 0xea, 0xea, 0xea, 0x06, 0xc3, 0x1f, 0x4f, 0x47, 0x8e, 0xcb,
+	0xef, 0xda, 0x81, 0x73, 0xca, 0x02, 0x7e, 0xd7, 0x0f, 0xbc, 0xd0, 0x53, 0x54, 0x4b, 0x10, 0xe4,
+	0xc3, 0x63, 0x8b, 0x1f, 0x3b, 0x43, 0x2f, 0xf0, 0x5b, 0xae, 0x37, 0xb1, 0xec, 0x96, 0x1a, 0xd3,
+	0x52, 0x63, 0x64, 0xb7, 0xe6, 0xef, 0x8d, 0x3c, 0x6f, 0x34, 0x66, 0x12, 0xe1, 0x68, 0xfa, 0xf2,
+	0xae, 0x3d, 0x0d, 0xac, 0xd0, 0xf1, 0x5c, 0xd5, 0xfe, 0xc1, 0xc5, 0xf6, 0xd0, 0x99, 0x30, 0x1e,
+	0x5a, 0x13, 0x5f, 0x75, 0xf8, 0x30, 0x92, 0x85, 0x1f, 0x5b, 0x01, 0xb3, 0xef, 0x1e, 0x0f, 0xc7,
+	0xdc, 0x67, 0x43, 0xfc, 0x35, 0xf1, 0x43, 0x75, 0xfb, 0xe8, 0x42, 0x37, 0x1e, 0x06, 0xd3, 0x61,
+	0x18, 0x49, 0x6e, 0x85, 0x61, 0xe0, 0x1c, 0x4d, 0x43, 0x26, 0x7b, 0x1b, 0xb7, 0xe0, 0xbd, 0x81,
+	0xc5, 0x4f, 0x3a, 0x9e, 0xfb, 0xd2, 0x19, 0xf5, 0x87, 0xc7, 0x6c, 0x62, 0x51, 0xf6, 0xcd, 0x94,
+	0xf1, 0xd0, 0xf8, 0x13, 0x68, 0xcc, 0x37, 0x71, 0xdf, 0x73, 0x39, 0x23, 0x5f, 0x40, 0x01, 0xa7,
+	0x6c, 0x68, 0xb7, 0xb5, 0x3b, 0xd5, 0xcd, 0x8f, 0x5a, 0x6f, 0x52, 0x81, 0x94, 0xa1, 0xa5, 0x44,
+	0x6d, 0xf5, 0x7d, 0x36, 0xa4, 0x62, 0xa4, 0x71, 0x03, 0xae, 0x77, 0x2c, 0xdf, 0x3a, 0x72, 0xc6,
+	0x4e, 0xe8, 0x30, 0x1e, 0x4d, 0x3a, 0x85, 0x8d, 0x34, 0x5b, 0x4d, 0xf8, 0x33, 0xa8, 0x0d, 0x13,
+	0x7c, 0x35, 0xf1, 0xfd, 0x56, 0x26, 0xdd, 0xb7, 0xb6, 0x04, 0x95, 0x02, 0x4e, 0xc1, 0x19, 0x1b,
+	0x40, 0x1e, 0x3b, 0xee, 0x88, 0x05, 0x7e, 0xe0, 0xb8, 0x61, 0x24, 0xcc, 0x6f, 0xf3, 0x70, 0x3d,
+	0xc5, 0x56, 0xc2, 0xbc, 0x02, 0x88, 0xf5, 0x88, 0xa2, 0xe4, 0xef, 0x54, 0x37, 0xbf, 0xca, 0x28,
+	0xca, 0x25, 0x78, 0xad, 0x76, 0x0c, 0xd6, 0x75, 0xc3, 0xe0, 0x9c, 0x26, 0xd0, 0xc9, 0xd7, 0x50,
+	0x3a, 0x66, 0xd6, 0x38, 0x3c, 0x6e, 0xe4, 0x6e, 0x6b, 0x77, 0xea, 0x9b, 0x8f, 0xaf, 0x30, 0xcf,
+	0xb6, 0x00, 0xea, 0x87, 0x56, 0xc8, 0xa8, 0x42, 0x25, 0x1f, 0x03, 0x91, 0x5f, 0xa6, 0xcd, 0xf8,
+	0x30, 0x70, 0x7c, 0x74, 0xc9, 0x46, 0xfe, 0xb6, 0x76, 0xa7, 0x42, 0xd7, 0x65, 0xcb, 0xd6, 0xac,
+	0xa1, 0xe9, 0xc3, 0xda, 0x05, 0x69, 0x89, 0x0e, 0xf9, 0x13, 0x76, 0x2e, 0x2c, 0x52, 0xa1, 0xf8,
+	0x49, 0x9e, 0x40, 0xf1, 0xd4, 0x1a, 0x4f, 0x99, 0x10, 0xb9, 0xba, 0xf9, 0xc3, 0xb7, 0xb9, 0x87,
+	0x72, 0xd1, 0x99, 0x1e, 0xa8, 0x1c, 0xff, 0x20, 0xf7, 0x99, 0x66, 0xdc, 0x87, 0x6a, 0x42, 0x6e,
+	0x52, 0x07, 0x38, 0xec, 0x6d, 0x75, 0x07, 0xdd, 0xce, 0xa0, 0xbb, 0xa5, 0x5f, 0x23, 0xab, 0x50,
+	0x39, 0xec, 0x6d, 0x77, 0xdb, 0xbb, 0x83, 0xed, 0xe7, 0xba, 0x46, 0xaa, 0xb0, 0x12, 0x11, 0x39,
+	0xe3, 0x0c, 0x08, 0x65, 0x43, 0xef, 0x94, 0x05, 0xe8, 0xc8, 0xca, 0xaa, 0xe4, 0x3d, 0x58, 0x09,
+	0x2d, 0x7e, 0x62, 0x3a, 0xb6, 0x92, 0xb9, 0x84, 0xe4, 0x8e, 0x4d, 0x76, 0xa0, 0x74, 0x6c, 0xb9,
+	0xf6, 0xf8, 0xed, 0x72, 0xa7, 0x55, 0x8d, 0xe0, 0xdb, 0x62, 0x20, 0x55, 0x00, 0xe8, 0xdd, 0xa9,
+	0x99, 0xa5, 0x01, 0x8c, 0xe7, 0xa0, 0xf7, 0x43, 0x2b, 0x08, 0x93, 0xe2, 0x74, 0xa1, 0x80, 0xf3,
+	0x37, 0xb4, 0x85, 0xe7, 0x94, 0x2b, 0x93, 0x8a, 0xe1, 0xc6, 0xff, 0xe5, 0x60, 0x3d, 0x81, 0xad,
+	0x3c, 0xf5, 0x19, 0x94, 0x02, 0xc6, 0xa7, 0xe3, 0x50, 0xc0, 0xd7, 0x37, 0x1f, 0x65, 0x84, 0x9f,
+	0x43, 0x6a, 0x51, 0x01, 0x43, 0x15, 0x1c, 0xb9, 0x03, 0xba, 0x1c, 0x61, 0xb2, 0x20, 0xf0, 0x02,
+	0x73, 0xc2, 0x47, 0x42, 0x6b, 0x15, 0x5a, 0x97, 0xfc, 0x2e, 0xb2, 0xf7, 0xf8, 0x28, 0xa1, 0xd5,
+	0xfc, 0x15, 0xb5, 0x4a, 0x2c, 0xd0, 0x5d, 0x16, 0xbe, 0xf6, 0x82, 0x13, 0x13, 0x55, 0x1b, 0x38,
+	0x36, 0x6b, 0x14, 0x04, 0xe8, 0xa7, 0x19, 0x41, 0x7b, 0x72, 0xf8, 0xbe, 0x1a, 0x4d, 0xd7, 0xdc,
+	0x34, 0xc3, 0xf8, 0x01, 0x94, 0xe4, 0x3f, 0x45, 0x4f, 0xea, 0x1f, 0x76, 0x3a, 0xdd, 0x7e, 0x5f,
+	0xbf, 0x46, 0x2a, 0x50, 0xa4, 0xdd, 0x01, 0x45, 0x0f, 0xab, 0x40, 0xf1, 0x71, 0x7b, 0xd0, 0xde,
+	0xd5, 0x73, 0xc6, 0xf7, 0x61, 0xed, 0x99, 0xe5, 0x84, 0x59, 0x9c, 0xcb, 0xf0, 0x40, 0x9f, 0xf5,
+	0x55, 0xd6, 0xd9, 0x49, 0x59, 0x27, 0xbb, 0x6a, 0xba, 0x67, 0x4e, 0x78, 0xc1, 0x1e, 0x3a, 0xe4,
+	0x59, 0x10, 0x28, 0x13, 0xe0, 0xa7, 0xf1, 0x1a, 0xd6, 0xfa, 0xa1, 0xe7, 0x67, 0xf2, 0xfc, 0x1f,
+	0xc1, 0x0a, 0xee, 0x36, 0xde, 0x34, 0x54, 0xae, 0x7f, 0xab, 0x25, 0x77, 0xa3, 0x56, 0xb4, 0x1b,
+	0xb5, 0xb6, 0xd4, 0x6e, 0x45, 0xa3, 0x9e, 0xe4, 0x26, 0x94, 0xb8, 0x33, 0x72, 0xad, 0xb1, 0x8a,
+	0x16, 0x8a, 0x32, 0x08, 0xe8, 0xb3, 0x89, 0x95, 0xe3, 0x77, 0x80, 0x6c, 0x31, 0x1e, 0x06, 0xde,
+	0x79, 0x26, 0x79, 0x36, 0xa0, 0xf8, 0xd2, 0x0b, 0x86, 0x72, 0x21, 0x96, 0xa9, 0x24, 0x70, 0x51,
+	0xa5, 0x40, 0x14, 0xf6, 0xc7, 0x40, 0x76, 0x5c, 0xdc, 0x53, 0xb2, 0x19, 0xe2, 0x1f, 0x72, 0x70,
+	0x3d, 0xd5, 0x5f, 0x19, 0x63, 0xf9, 0x75, 0x88, 0x81, 0x69, 0xca, 0xe5, 0x3a, 0x24, 0xfb, 0x50,
+	0x92, 0x3d, 0x94, 0x26, 0xef, 0x2d, 0x00, 0x24, 0xb7, 0x29, 0x05, 0xa7, 0x60, 0x2e, 0x75, 0xfa,
+	0xfc, 0xbb, 0x75, 0xfa, 0xd7, 0xa0, 0x47, 0xff, 0x83, 0xbf, 0xd5, 0x36, 0x5f, 0xc1, 0xf5, 0xa1,
+	0x37, 0x1e, 0xb3, 0x21, 0x7a, 0x83, 0xe9, 0xb8, 0x21, 0x0b, 0x4e, 0xad, 0xf1, 0xdb, 0xfd, 0x86,
+	0xcc, 0x46, 0xed, 0xa8, 0x41, 0xc6, 0x0b, 0x58, 0x4f, 0x4c, 0xac, 0x0c, 0xf1, 0x18, 0x8a, 0x1c,
+	0x19, 0xca, 0x12, 0x9f, 0x2c, 0x68, 0x09, 0x4e, 0xe5, 0x70, 0xe3, 0xba, 0x04, 0xef, 0x9e, 0x32,
+	0x37, 0xfe, 0x5b, 0xc6, 0x16, 0xac, 0xf7, 0x85, 0x9b, 0x66, 0xf2, 0xc3, 0x99, 0x8b, 0xe7, 0x52,
+	0x2e, 0xbe, 0x01, 0x24, 0x89, 0xa2, 0x1c, 0xf1, 0x1c, 0xd6, 0xba, 0x67, 0x6c, 0x98, 0x09, 0xb9,
+	0x01, 0x2b, 0x43, 0x6f, 0x32, 0xb1, 0x5c, 0xbb, 0x91, 0xbb, 0x9d, 0xbf, 0x53, 0xa1, 0x11, 0x99,
+	0x5c, 0x8b, 0xf9, 0xac, 0x6b, 0xd1, 0xf8, 0x3b, 0x0d, 0xf4, 0xd9, 0xdc, 0x4a, 0x91, 0x28, 0x7d,
+	0x68, 0x23, 0x10, 0xce, 0x5d, 0xa3, 0x8a, 0x52, 0xfc, 0x28, 0x5c, 0x48, 0x3e, 0x0b, 0x82, 0x44,
+	0x38, 0xca, 0x5f, 0x31, 0x1c, 0x19, 0xdb, 0xf0, 0x3b, 0x91, 0x38, 0xfd, 0x30, 0x60, 0xd6, 0xc4,
+	0x71, 0x47, 0x3b, 0xfb, 0xfb, 0x3e, 0x93, 0x82, 0x13, 0x02, 0x05, 0xdb, 0x0a, 0x2d, 0x25, 0x98,
+	0xf8, 0xc6, 0x45, 0x3f, 0x1c, 0x7b, 0x3c, 0x5e, 0xf4, 0x82, 0x30, 0xfe, 0x2b, 0x0f, 0x8d, 0x39,
+	0xa8, 0x48, 0xbd, 0x2f, 0xa0, 0xc8, 0x59, 0x38, 0xf5, 0x95, 0xab, 0x74, 0x33, 0x0b, 0x7c, 0x39,
+	0x5e, 0xab, 0x8f, 0x60, 0x54, 0x62, 0x92, 0x11, 0x94, 0xc3, 0xf0, 0xdc, 0xe4, 0xce, 0xcf, 0xa3,
+	0x84, 0x60, 0xf7, 0xaa, 0xf8, 0x03, 0x16, 0x4c, 0x1c, 0xd7, 0x1a, 0xf7, 0x9d, 0x9f, 0x33, 0xba,
+	0x12, 0x86, 0xe7, 0xf8, 0x41, 0x9e, 0xa3, 0xc3, 0xdb, 0x8e, 0xab, 0xd4, 0xde, 0x59, 0x76, 0x96,
+	0x84, 0x82, 0xa9, 0x44, 0x6c, 0xee, 0x42, 0x51, 0xfc, 0xa7, 0x65, 0x1c, 0x51, 0x87, 0x7c, 0x18,
+	0x9e, 0x0b, 0xa1, 0xca, 0x14, 0x3f, 0x9b, 0x0f, 0xa1, 0x96, 0xfc, 0x07, 0xe8, 0x48, 0xc7, 0xcc,
+	0x19, 0x1d, 0x4b, 0x07, 0x2b, 0x52, 0x45, 0xa1, 0x25, 0x5f, 0x3b, 0xb6, 0x4a, 0x59, 0x8b, 0x54,
+	0x12, 0xc6, 0xbf, 0xe7, 0xe0, 0xd6, 0x25, 0x9a, 0x51, 0xce, 0xfa, 0x22, 0xe5, 0xac, 0xef, 0x48,
+	0x0b, 0x91, 0xc7, 0xbf, 0x48, 0x79, 0xfc, 0x3b, 0x04, 0xc7, 0x65, 0x73, 0x13, 0x4a, 0xec, 0xcc,
+	0x09, 0x99, 0xad, 0x54, 0xa5, 0xa8, 0xc4, 0x72, 0x2a, 0x5c, 0x75, 0x39, 0xed, 0xc1, 0x46, 0x27,
+	0x60, 0x56, 0xc8, 0x54, 0x28, 0x8f, 0xfc, 0xff, 0x16, 0x94, 0xad, 0xf1, 0xd8, 0x1b, 0xce, 0xcc,
+	0xba, 0x22, 0xe8, 0x1d, 0x9b, 0x34, 0xa1, 0x7c, 0xec, 0xf1, 0xd0, 0xb5, 0x26, 0x4c, 0x05, 0xaf,
+	0x98, 0x36, 0xbe, 0xd5, 0xe0, 0xc6, 0x05, 0x3c, 0x65, 0x85, 0x23, 0xa8, 0x3b, 0xdc, 0x1b, 0x8b,
+	0x3f, 0x68, 0x26, 0x4e, 0x78, 0x3f, 0x5e, 0x6c, 0xab, 0xd9, 0x89, 0x30, 0xc4, 0x81, 0x6f, 0xd5,
+	0x49, 0x92, 0xc2, 0xe3, 0xc4, 0xe4, 0xb6, 0x5a, 0xe9, 0x11, 0x69, 0xfc, 0xb3, 0x06, 0x37, 0xd4,
+	0x0e, 0x9f, 0xfd, 0x8f, 0xce, 0x8b, 0x9c, 0x7b, 0xd7, 0x22, 0x1b, 0x0d, 0xb8, 0x79, 0x51, 0x2e,
+	0x15, 0xf3, 0xff, 0xa9, 0x08, 0x64, 0xfe, 0x74, 0x49, 0xbe, 0x07, 0x35, 0xce, 0x5c, 0xdb, 0x94,
+	0xfb, 0x85, 0xdc, 0xca, 0xca, 0xb4, 0x8a, 0x3c, 0xb9, 0x71, 0x70, 0x0c, 0x81, 0xec, 0x4c, 0x49,
+	0x5b, 0xa6, 0xe2, 0x9b, 0x1c, 0x43, 0xed, 0x25, 0x37, 0xe3, 0xb9, 0x85, 0x43, 0xd5, 0x33, 0x87,
+	0xb5, 0x79, 0x39, 0x5a, 0x8f, 0xfb, 0xf1, 0xff, 0xa2, 0xd5, 0x97, 0x3c, 0x26, 0xc8, 0x2f, 0x35,
+	0x78, 0x2f, 0x4a, 0x2b, 0x66, 0xea, 0x9b, 0x78, 0x36, 0xe3, 0x8d, 0xc2, 0xed, 0xfc, 0x9d, 0xfa,
+	0xe6, 0xc1, 0x15, 0xf4, 0x37, 0xc7, 0xdc, 0xf3, 0x6c, 0x46, 0x6f, 0xb8, 0x97, 0x70, 0x39, 0x69,
+	0xc1, 0xf5, 0xc9, 0x94, 0x87, 0xa6, 0xf4, 0x02, 0x53, 0x75, 0x6a, 0x14, 0x85, 0x5e, 0xd6, 0xb1,
+	0x29, 0xe5, 0xab, 0xe4, 0x04, 0x56, 0x27, 0xde, 0xd4, 0x0d, 0xcd, 0xa1, 0x38, 0xff, 0xf0, 0x46,
+	0x69, 0xa1, 0x83, 0xf1, 0x25, 0x5a, 0xda, 0x43, 0x38, 0x79, 0x9a, 0xe2, 0xb4, 0x36, 0x49, 0x50,
+	0x68, 0xc8, 0x80, 0x4d, 0xbc, 0x90, 0x99, 0x18, 0x2f, 0x79, 0x63, 0x45, 0x1a, 0x52, 0xf2, 0x30,
+	0x34, 0x70, 0xf2, 0x3e, 0x54, 0x42, 0xcb, 0x19, 0x9b, 0x63, 0x6f, 0xc4, 0x1b, 0x65, 0xd1, 0x5e,
+	0x46, 0xc6, 0xae, 0x37, 0xe2, 0x46, 0x0b, 0xaa, 0x09, 0x1b, 0x90, 0x32, 0x14, 0x7a, 0xfb, 0xbd,
+	0xae, 0x7e, 0x8d, 0x00, 0x94, 0x3a, 0xdb, 0x74, 0x7f, 0x7f, 0x20, 0x8f, 0x14, 0x3b, 0x7b, 0xed,
+	0x27, 0x5d, 0x3d, 0x67, 0x74, 0xa1, 0x96, 0x94, 0x86, 0x10, 0xa8, 0x1f, 0xf6, 0x9e, 0xf6, 0xf6,
+	0x9f, 0xf5, 0xcc, 0xbd, 0xfd, 0xc3, 0xde, 0x00, 0x0f, 0x23, 0x75, 0x80, 0x76, 0xef, 0xf9, 0x8c,
+	0x5e, 0x85, 0x4a, 0x6f, 0x3f, 0x22, 0xb5, 0x66, 0x4e, 0xd7, 0x8c, 0xff, 0xcc, 0xc3, 0xc6, 0x65,
+	0x86, 0x21, 0x36, 0x14, 0xd0, 0xc8, 0xea, 0x38, 0xf8, 0xee, 0x6d, 0x2c, 0xd0, 0xd1, 0xb7, 0x7d,
+	0x4b, 0xc5, 0xff, 0x0a, 0x15, 0xdf, 0xc4, 0x84, 0xd2, 0xd8, 0x3a, 0x62, 0x63, 0xde, 0xc8, 0x8b,
+	0x82, 0xc9, 0x93, 0xab, 0xcc, 0xbd, 0x2b, 0x90, 0x64, 0xb5, 0x44, 0xc1, 0x92, 0x01, 0x54, 0x31,
+	0xc2, 0x71, 0xa9, 0x3a, 0x15, 0x74, 0x37, 0x33, 0xce, 0xb2, 0x3d, 0x1b, 0x49, 0x93, 0x30, 0xcd,
+	0xfb, 0x50, 0x4d, 0x4c, 0x76, 0x49, 0xb1, 0x63, 0x23, 0x59, 0xec, 0xa8, 0x24, 0x2b, 0x17, 0x8f,
+	0x60, 0xe3, 0x32, 0x1d, 0xa1, 0x13, 0x6c, 0xef, 0xf7, 0x07, 0xf2, 0x58, 0xf9, 0x84, 0xee, 0x1f,
+	0x1e, 0xe8, 0x1a, 0x32, 0x07, 0xed, 0xfe, 0x53, 0x3d, 0x17, 0xfb, 0x48, 0xde, 0xe8, 0x40, 0x35,
+	0x21, 0x57, 0x2a, 0xa4, 0x6b, 0xe9, 0x90, 0x8e, 0x41, 0xd5, 0xb2, 0xed, 0x80, 0x71, 0xae, 0xe4,
+	0x88, 0x48, 0xe3, 0x05, 0x54, 0xb6, 0x7a, 0x7d, 0x05, 0xd1, 0x80, 0x15, 0xce, 0x02, 0xfc, 0xdf,
+	0xa2, 0x6c, 0x55, 0xa1, 0x11, 0x89, 0xe0, 0x9c, 0x59, 0xc1, 0xf0, 0x98, 0x71, 0x95, 0x08, 0xc4,
+	0x34, 0x8e, 0xf2, 0x44, 0xf9, 0x47, 0xda, 0xae, 0x42, 0x23, 0xd2, 0xf8, 0xc7, 0x32, 0xc0, 0xac,
+	0x14, 0x41, 0xea, 0x90, 0x8b, 0x03, 0x74, 0xce, 0xb1, 0xd1, 0x0f, 0x12, 0x1b, 0x90, 0xf8, 0x26,
+	0x9b, 0x70, 0x63, 0xc2, 0x47, 0xbe, 0x35, 0x3c, 0x31, 0x55, 0x05, 0x41, 0xae, 0x63, 0x11, 0xec,
+	0x6a, 0xf4, 0xba, 0x6a, 0x54, 0xcb, 0x54, 0xe2, 0xee, 0x42, 0x9e, 0xb9, 0xa7, 0x22, 0x30, 0x55,
+	0x37, 0x1f, 0x2c, 0x5c, 0x22, 0x69, 0x75, 0xdd, 0x53, 0xe9, 0x2b, 0x08, 0x43, 0x4c, 0x00, 0x9b,
+	0x9d, 0x3a, 0x43, 0x66, 0x22, 0x68, 0x51, 0x80, 0x7e, 0xb1, 0x38, 0xe8, 0x96, 0xc0, 0x88, 0xa1,
+	0x2b, 0x76, 0x44, 0x93, 0x1e, 0x54, 0x02, 0xc6, 0xbd, 0x69, 0x30, 0x64, 0x32, 0x3a, 0x65, 0x3f,
+	0xc5, 0xd0, 0x68, 0x1c, 0x9d, 0x41, 0x90, 0x2d, 0x28, 0x89, 0xa0, 0x84, 0xe1, 0x27, 0xff, 0x9d,
+	0xf5, 0xd6, 0x34, 0x98, 0x88, 0x24, 0x54, 0x8d, 0x25, 0x4f, 0x60, 0x45, 0x8a, 0x88, 0x51, 0x0a,
+	0x61, 0x3e, 0xce, 0x1a, 0x31, 0xc5, 0x28, 0x1a, 0x8d, 0x46, 0xab, 0x4e, 0x39, 0x0b, 0x1a, 0x15,
+	0x69, 0x55, 0xfc, 0xc6, 0x20, 0x28, 0x37, 0x68, 0xdb, 0x09, 0x1a, 0x20, 0x9d, 0x53, 0x30, 0xb6,
+	0x9c, 0x80, 0x7c, 0x00, 0x55, 0x99, 0x88, 0x99, 0x22, 0x2a, 0x54, 0x45, 0x33, 0x48, 0xd6, 0x01,
+	0xc6, 0x06, 0xd9, 0x81, 0x05, 0x81, 0xec, 0x50, 0x8b, 0x3b, 0xb0, 0x20, 0x10, 0x1d, 0xfe, 0x00,
+	0xd6, 0x44, 0xfa, 0x3a, 0x0a, 0xbc, 0xa9, 0x6f, 0x0a, 0x9f, 0x5a, 0x15, 0x9d, 0x56, 0x91, 0xfd,
+	0x04, 0xb9, 0x3d, 0x74, 0xae, 0x5b, 0x50, 0x7e, 0xe5, 0x1d, 0xc9, 0x0e, 0x75, 0xb9, 0x0e, 0x5e,
+	0x79, 0x47, 0x51, 0x53, 0x9c, 0x42, 0xac, 0xa5, 0x53, 0x88, 0x6f, 0xe0, 0xe6, 0xfc, 0x5e, 0x28,
+	0x52, 0x09, 0xfd, 0xea, 0xa9, 0xc4, 0x86, 0x7b, 0x09, 0x97, 0x7c, 0x09, 0x79, 0xdb, 0xe5, 0x8d,
+	0xf5, 0x85, 0x9c, 0x23, 0x5e, 0xc7, 0x14, 0x07, 0xa3, 0xd6, 0x4e, 0x9c, 0xf1, 0x58, 0x25, 0x19,
+	0x0d, 0x22, 0xb5, 0x86, 0x2c, 0x99, 0x63, 0x34, 0x3f, 0x85, 0x72, 0xe4, 0x9e, 0x8b, 0x04, 0xae,
+	0xe6, 0x43, 0xa8, 0xa7, 0x9d, 0x7b, 0xa1, 0xb0, 0xf7, 0xaf, 0x39, 0xa8, 0xc4, 0x6e, 0x4c, 0x5c,
+	0xb8, 0x2e, 0xd4, 0x6c, 0x85, 0xcc, 0x36, 0x67, 0xab, 0x42, 0xa6, 0x95, 0x9f, 0x67, 0xfc, 0xe3,
+	0xed, 0x08, 0x41, 0x9d, 0x6f, 0xd5, 0x12, 0x21, 0x31, 0xf2, 0x6c, 0xbe, 0xaf, 0x61, 0x6d, 0xec,
+	0xb8, 0xd3, 0xb3, 0xc4, 0x5c, 0x32, 0x1f, 0xfc, 0xc3, 0x8c, 0x73, 0xed, 0xe2, 0xe8, 0xd9, 0x1c,
+	0xf5, 0x71, 0x8a, 0x26, 0xdb, 0x50, 0xf4, 0xbd, 0x20, 0x8c, 0x76, 0xb1, 0xac, 0xfb, 0xcb, 0x81,
+	0x17, 0x84, 0x7b, 0x96, 0xef, 0xe3, 0x91, 0x47, 0x02, 0x18, 0xdf, 0xe6, 0xe0, 0xe6, 0xe5, 0x7f,
+	0x8c, 0xf4, 0x20, 0x3f, 0xf4, 0xa7, 0x4a, 0x49, 0x0f, 0x17, 0x55, 0x52, 0xc7, 0x9f, 0xce, 0xe4,
+	0x47, 0x20, 0x2c, 0x03, 0x4f, 0xd8, 0xc4, 0x0b, 0xce, 0x95, 0x2e, 0x1e, 0x2d, 0x0a, 0xb9, 0x27,
+	0x46, 0xcf, 0x50, 0x15, 0x1c, 0xa1, 0x50, 0x56, 0xee, 0xcd, 0x55, 0x20, 0x5d, 0xb0, 0x28, 0x15,
+	0x41, 0xd2, 0x18, 0xc7, 0xf8, 0x14, 0x6e, 0x5c, 0xfa, 0x57, 0xc8, 0xef, 0x02, 0x0c, 0xfd, 0xa9,
+	0x29, 0x2e, 0x0d, 0xa4, 0x07, 0xe5, 0x69, 0x65, 0xe8, 0x4f, 0xfb, 0x82, 0x61, 0xbc, 0x80, 0xc6,
+	0x9b, 0xe4, 0xc5, 0xf0, 0x24, 0x25, 0x36, 0x27, 0x47, 0x42, 0x07, 0x79, 0x5a, 0x96, 0x8c, 0xbd,
+	0x23, 0x62, 0xc0, 0x6a, 0xd4, 0x68, 0x9d, 0x61, 0x87, 0xbc, 0xe8, 0x50, 0x55, 0x1d, 0xac, 0xb3,
+	0xbd, 0x23, 0xe3, 0x57, 0x39, 0x58, 0xbb, 0x20, 0x32, 0x1e, 0xfc, 0x64, 0x48, 0x8c, 0x8e, 0xd4,
+	0x92, 0xc2, 0xf8, 0x38, 0x74, 0xec, 0xa8, 0x18, 0x2b, 0xbe, 0xc5, 0xce, 0xe8, 0xab, 0x42, 0x69,
+	0xce, 0xf1, 0x71, 0xf9, 0x4c, 0x8e, 0x9c, 0x90, 0x8b, 0x34, 0xa5, 0x48, 0x25, 0x41, 0x9e, 0x43,
+	0x3d, 0x60, 0x62, 0x47, 0xb6, 0x4d, 0xe9, 0x65, 0xc5, 0x85, 0xbc, 0x4c, 0x49, 0x88, 0xce, 0x46,
+	0x57, 0x23, 0x24, 0xa4, 0x38, 0x79, 0x06, 0xab, 0xf6, 0xb9, 0x6b, 0x4d, 0x9c, 0xa1, 0x42, 0x2e,
+	0x2d, 0x8d, 0x5c, 0x53, 0x40, 0x02, 0x18, 0xef, 0x67, 0x12, 0x8d, 0xf8, 0xc7, 0x44, 0x3e, 0xa6,
+	0x74, 0x22, 0x89, 0x74, 0xb4, 0x28, 0xaa, 0x68, 0x61, 0x1c, 0x41, 0x35, 0xb1, 0x2e, 0x16, 0x19,
+	0x8a, 0xfa, 0x0c, 0x3d, 0xa1, 0xcf, 0x22, 0xcd, 0x85, 0x1e, 0xd6, 0x37, 0x30, 0x17, 0x32, 0x1d,
+	0x5f, 0x68, 0xb4, 0x42, 0x4b, 0x48, 0xee, 0xf8, 0xc6, 0x6f, 0x72, 0x50, 0x4f, 0x2f, 0xe9, 0xc8,
+	0x8f, 0x7c, 0x16, 0x38, 0x9e, 0x9d, 0xf0, 0xa3, 0x03, 0xc1, 0x40, 0x5f, 0xc1, 0xe6, 0x6f, 0xa6,
+	0x5e, 0x68, 0x45, 0xbe, 0x32, 0xf4, 0xa7, 0x7f, 0x84, 0xf4, 0x05, 0x1f, 0xcc, 0x5f, 0xf0, 0x41,
+	0xf2, 0x11, 0x10, 0xe5, 0x4a, 0x63, 0x67, 0xe2, 0x84, 0xe6, 0xd1, 0x79, 0xc8, 0xa4, 0x8d, 0xf3,
+	0x54, 0x97, 0x2d, 0xbb, 0xd8, 0xf0, 0x25, 0xf2, 0xd1, 0xf1, 0x3c, 0x6f, 0x62, 0xf2, 0xa1, 0x17,
+	0x30, 0xd3, 0xb2, 0x5f, 0x89, 0x33, 0x4f, 0x9e, 0x56, 0x3d, 0x6f, 0xd2, 0x47, 0x5e, 0xdb, 0x7e,
+	0x85, 0x41, 0x7e, 0xe8, 0x4f, 0x39, 0x0b, 0x4d, 0xfc, 0x11, 0xd9, 0x44, 0x85, 0x82, 0x64, 0x75,
+	0xfc, 0x29, 0x27, 0xbf, 0x0f, 0xab, 0x51, 0x07, 0xb1, 0x3b, 0xaa, 0x6d, 0xb9, 0xa6, 0xba, 0x08,
+	0x1e, 0x31, 0xa0, 0x76, 0xc0, 0x82, 0x21, 0x73, 0xc3, 0x81, 0x33, 0x3c, 0x91, 0xc7, 0x14, 0x8d,
+	0xa6, 0x78, 0x5f, 0x15, 0xca, 0x2b, 0x7a, 0x99, 0x46, 0xb3, 0x4d, 0xd8, 0x84, 0x1b, 0x3f, 0x83,
+	0xa2, 0xc8, 0x21, 0xe4, 0x19, 0x87, 0x9f, 0xc8, 0xed, 0x59, 0xe5, 0x9e, 0xc8, 0x10, 0x9b, 0xf3,
+	0xfb, 0x50, 0x11, 0xba, 0x4f, 0xa4, 0xfc, 0x22, 0x31, 0x15, 0x8d, 0x4d, 0x28, 0x07, 0xcc, 0xb2,
+	0x3d, 0x77, 0x1c, 0x95, 0x92, 0x62, 0xda, 0xf8, 0x06, 0x4a, 0x72, 0x9f, 0xb9, 0x02, 0xfe, 0xc7,
+	0x40, 0xe4, 0xff, 0x46, 0x7b, 0x4e, 0x1c, 0xce, 0x55, 0x9a, 0x2a, 0xee, 0x2f, 0x65, 0xcb, 0xc1,
+	0xac, 0xc1, 0xf8, 0x6f, 0x0d, 0x60, 0x76, 0xb3, 0x84, 0x99, 0x2d, 0x3a, 0x39, 0x9e, 0xb5, 0x65,
+	0x09, 0x2b, 0x22, 0xb1, 0x7a, 0xa3, 0xf2, 0xd2, 0xdc, 0xb2, 0x17, 0x73, 0x0a, 0x20, 0x2a, 0x68,
+	0x33, 0x75, 0x9c, 0x5f, 0xb4, 0xa0, 0xcd, 0x64, 0x41, 0x9b, 0xe1, 0x59, 0x54, 0x65, 0xcc, 0x12,
+	0xae, 0x20, 0x12, 0xe6, 0xaa, 0x1d, 0xdf, 0x1a, 0x30, 0xe3, 0x7f, 0xb5, 0x38, 0x4c, 0x45, 0xd5,
+	0x7d, 0xf2, 0x35, 0x94, 0x71, 0xc5, 0x9b, 0x13, 0xcb, 0x57, 0x77, 0xd5, 0x9d, 0xe5, 0x2e, 0x0e,
+	0xa2, 0x4d, 0x4c, 0xe6, 0xbb, 0x2b, 0xbe, 0xa4, 0x30, 0xdc, 0xe1, 0x59, 0x23, 0x0a, 0x77, 0xf8,
+	0x4d, 0x3e, 0x84, 0xba, 0x35, 0x0d, 0x3d, 0xd3, 0xb2, 0x4f, 0x59, 0x10, 0x3a, 0x9c, 0x29, 0xdb,
+	0xaf, 0x22, 0xb7, 0x1d, 0x31, 0x9b, 0x0f, 0xa0, 0x96, 0xc4, 0x7c, 0x5b, 0x9a, 0x51, 0x4c, 0xa6,
+	0x19, 0x7f, 0x0a, 0x30, 0xab, 0x94, 0xa1, 0x8f, 0x60, 0xd9, 0xcd, 0x1c, 0x46, 0x87, 0xdb, 0x22,
+	0x2d, 0x23, 0xa3, 0x83, 0x07, 0xae, 0x74, 0x19, 0xbf, 0x18, 0x95, 0xf1, 0x71, 0x31, 0xe3, 0xfa,
+	0xc3, 0x8c, 0x29, 0xae, 0xde, 0x55, 0x3c, 0x6f, 0xf2, 0x54, 0x30, 0x8c, 0xdf, 0xe6, 0xa4, 0xaf,
+	0xc8, 0x0b, 0x99, 0x4c, 0x87, 0x9b, 0x77, 0x65, 0xea, 0xfb, 0x00, 0x3c, 0xb4, 0x02, 0xcc, 0x99,
+	0xac, 0xa8, 0x7e, 0xd8, 0x9c, 0xbb, 0x07, 0x18, 0x44, 0x2f, 0x44, 0x68, 0x45, 0xf5, 0x6e, 0x87,
+	0xe4, 0x73, 0xa8, 0x0d, 0xbd, 0x89, 0x3f, 0x66, 0x6a, 0x70, 0xf1, 0xad, 0x83, 0xab, 0x71, 0xff,
+	0x76, 0x98, 0xa8, 0x5a, 0x96, 0xae, 0x5a, 0xb5, 0xfc, 0x8d, 0x26, 0xef, 0x95, 0x92, 0xd7, 0x5a,
+	0x64, 0x74, 0xc9, 0xdb, 0x89, 0x27, 0x4b, 0xde, 0x91, 0x7d, 0xd7, 0xc3, 0x89, 0xe6, 0xe7, 0x59,
+	0x5e, 0x2a, 0xbc, 0x39, 0x8b, 0xfd, 0x8f, 0x3c, 0x54, 0x22, 0xb3, 0xcc, 0xdb, 0xfe, 0x33, 0xa8,
+	0xc4, 0xcf, 0x73, 0x1a, 0xb9, 0xb7, 0x6a, 0x78, 0xd6, 0x99, 0xbc, 0x04, 0x62, 0x8d, 0x46, 0x71,
+	0x76, 0x6a, 0x4e, 0xb9, 0x35, 0x8a, 0x2e, 0xf4, 0x3e, 0x5b, 0x40, 0x0f, 0xd1, 0x76, 0x76, 0x88,
+	0xe3, 0xa9, 0x6e, 0x8d, 0x46, 0x29, 0x0e, 0xf9, 0x33, 0xb8, 0x91, 0x9e, 0xc3, 0x3c, 0x3a, 0x37,
+	0x7d, 0xc7, 0x56, 0x87, 0xe8, 0xed, 0x45, 0x6f, 0xd5, 0x5a, 0x29, 0xf8, 0x2f, 0xcf, 0x0f, 0x1c,
+	0x5b, 0xea, 0x9c, 0x04, 0x73, 0x0d, 0xcd, 0xbf, 0x80, 0xf7, 0xde, 0xd0, 0xfd, 0x12, 0x1b, 0xf4,
+	0xd2, 0xaf, 0x45, 0x96, 0x57, 0x42, 0xc2, 0x7a, 0xbf, 0xd6, 0x60, 0x7d, 0xae, 0x03, 0x69, 0x27,
+	0xd3, 0xea, 0xbb, 0x19, 0xe7, 0xe9, 0x1c, 0x1c, 0x4a, 0x78, 0x1c, 0x4b, 0xbe, 0xba, 0x90, 0x49,
+	0x67, 0xcd, 0x9f, 0x64, 0x42, 0x2a, 0x81, 0x14, 0x82, 0xf1, 0x6f, 0x79, 0x28, 0x47, 0xe8, 0xe2,
+	0x08, 0x7c, 0xce, 0x43, 0x36, 0x31, 0xe3, 0xfa, 0x9c, 0x46, 0x41, 0xb2, 0x44, 0xd5, 0xe8, 0x7d,
+	0xa8, 0xe0, 0x49, 0x5b, 0x36, 0xe7, 0x44, 0x73, 0x19, 0x19, 0xa2, 0xf1, 0x03, 0xa8, 0x86, 0x5e,
+	0x68, 0x8d, 0xcd, 0x50, 0x6c, 0xef, 0x79, 0x39, 0x5a, 0xb0, 0xc4, 0xe6, 0x4e, 0x7e, 0x00, 0xeb,
+	0xe1, 0x71, 0xe0, 0x85, 0xe1, 0x18, 0x53, 0x4b, 0x91, 0xe8, 0xc8, 0xbc, 0xa4, 0x40, 0xf5, 0xb8,
+	0x41, 0x26, 0x40, 0x1c, 0xa3, 0xf7, 0xac, 0x33, 0xba, 0xae, 0x08, 0x22, 0x05, 0xba, 0x1a, 0x73,
+	0xd1, 0xb5, 0x71, 0xf3, 0xf4, 0x65, 0x02, 0x21, 0x62, 0x85, 0x46, 0x23, 0x92, 0x98, 0xb0, 0x36,
+	0x61, 0x16, 0x9f, 0x06, 0xcc, 0x36, 0x5f, 0x3a, 0x6c, 0x6c, 0xcb, 0xca, 0x45, 0x3d, 0xf3, 0xe9,
+	0x20, 0x52, 0x4b, 0xeb, 0xb1, 0x18, 0x4d, 0xeb, 0x11, 0x9c, 0xa4, 0x31, 0x73, 0x90, 0x5f, 0x64,
+	0x0d, 0xaa, 0xfd, 0xe7, 0xfd, 0x41, 0x77, 0xcf, 0xdc, 0xdb, 0xdf, 0xea, 0xaa, 0x07, 0x41, 0xfd,
+	0x2e, 0x95, 0xa4, 0x86, 0xed, 0x83, 0xfd, 0x41, 0x7b, 0xd7, 0x1c, 0xec, 0x74, 0x9e, 0xf6, 0xf5,
+	0x1c, 0xb9, 0x01, 0xeb, 0x83, 0x6d, 0xba, 0x3f, 0x18, 0xec, 0x76, 0xb7, 0xcc, 0x83, 0x2e, 0xdd,
+	0xd9, 0xdf, 0xea, 0xeb, 0x79, 0x2c, 0xb4, 0xce, 0xd8, 0x83, 0x9d, 0xbd, 0xae, 0x5e, 0xc0, 0x27,
+	0x20, 0x07, 0x5d, 0xda, 0xe9, 0xf6, 0x06, 0x7a, 0xd1, 0xf8, 0x55, 0x1e, 0xaa, 0x09, 0x2b, 0xa2,
+	0x23, 0x07, 0x5c, 0x1e, 0x43, 0x0a, 0x14, 0x3f, 0xc5, 0x05, 0xa6, 0x35, 0x3c, 0x96, 0xd6, 0x29,
+	0x50, 0x49, 0x88, 0xa3, 0x87, 0x75, 0x96, 0x58, 0xe7, 0x05, 0x5a, 0x9e, 0x58, 0x67, 0x12, 0xe4,
+	0x7b, 0x50, 0x3b, 0x61, 0x81, 0xcb, 0xc6, 0xaa, 0x5d, 0x5a, 0xa4, 0x2a, 0x79, 0xb2, 0xcb, 0x1d,
+	0xd0, 0x55, 0x97, 0x19, 0x8c, 0x34, 0x47, 0x5d, 0xf2, 0xf7, 0x22, 0xb0, 0x0d, 0x28, 0xca, 0xe6,
+	0x15, 0x39, 0xbf, 0x20, 0x70, 0x9b, 0xe2, 0xaf, 0x2d, 0x5f, 0xa4, 0x7c, 0x05, 0x2a, 0xbe, 0xc9,
+	0xd1, 0xbc, 0x7d, 0x4a, 0xc2, 0x3e, 0xf7, 0x17, 0x77, 0xe7, 0x37, 0x99, 0xe8, 0x38, 0x36, 0xd1,
+	0x0a, 0xe4, 0x69, 0xf4, 0x8a, 0xa6, 0xd3, 0xee, 0x6c, 0xa3, 0x59, 0x56, 0xa1, 0xb2, 0xd7, 0xfe,
+	0xa9, 0x79, 0xd8, 0x17, 0x65, 0x6f, 0xa2, 0x43, 0xed, 0x69, 0x97, 0xf6, 0xba, 0xbb, 0x8a, 0x93,
+	0x27, 0x1b, 0xa0, 0x2b, 0xce, 0xac, 0x5f, 0x01, 0x11, 0xe4, 0x67, 0x11, 0xcb, 0xa4, 0xfd, 0x67,
+	0xed, 0x03, 0xbd, 0x64, 0xfc, 0x4f, 0x0e, 0xd6, 0xe4, 0xb6, 0x10, 0xdf, 0xf7, 0xbf, 0xf9, 0xbe,
+	0x33, 0x59, 0x06, 0xca, 0xa5, 0xcb, 0x40, 0x51, 0x12, 0x2a, 0x76, 0xf5, 0xfc, 0x2c, 0x09, 0x15,
+	0xe5, 0xa3, 0x54, 0xc4, 0x2f, 0x2c, 0x12, 0xf1, 0x1b, 0xb0, 0x32, 0x61, 0x3c, 0xb6, 0x5b, 0x85,
+	0x46, 0x24, 0x71, 0xa0, 0x6a, 0xb9, 0xae, 0x17, 0x5a, 0xb2, 0xb6, 0x5a, 0x5a, 0x68, 0x33, 0xbc,
+	0xf0, 0x8f, 0x5b, 0xed, 0x19, 0x92, 0x0c, 0xcc, 0x49, 0xec, 0xe6, 0x4f, 0x40, 0xbf, 0xd8, 0x61,
+	0x91, 0xed, 0xf0, 0xfb, 0x3f, 0x9c, 0xed, 0x86, 0x0c, 0xd7, 0x85, 0xba, 0x94, 0xd0, 0xaf, 0x21,
+	0x41, 0x0f, 0x7b, 0xbd, 0x9d, 0xde, 0x13, 0x5d, 0xc3, 0x5b, 0x8d, 0xee, 0x4f, 0x77, 0xf0, 0x65,
+	0x5e, 0x6e, 0xf3, 0xd7, 0xeb, 0x50, 0x92, 0x42, 0x92, 0x6f, 0x55, 0x26, 0x90, 0x7c, 0x4b, 0x4a,
+	0x7e, 0xb2, 0x70, 0x46, 0x9d, 0x7a, 0x9f, 0xda, 0x7c, 0xb4, 0xf4, 0x78, 0x75, 0x77, 0x77, 0x8d,
+	0xfc, 0x8d, 0x06, 0xb5, 0xd4, 0xbd, 0x5d, 0xd6, 0xda, 0xf2, 0x25, 0x4f, 0x57, 0x9b, 0x3f, 0x5e,
+	0x6a, 0x6c, 0x2c, 0xcb, 0x2f, 0x35, 0xa8, 0x26, 0x1e, 0x6d, 0x92, 0xfb, 0xcb, 0x3c, 0xf4, 0x94,
+	0x92, 0x3c, 0x58, 0xfe, 0x8d, 0xa8, 0x71, 0xed, 0x13, 0x8d, 0xfc, 0xb5, 0x06, 0xd5, 0xc4, 0xf3,
+	0xc5, 0xcc, 0xa2, 0xcc, 0x3f, 0xb6, 0x6c, 0x3e, 0x58, 0x66, 0x68, 0xac, 0x93, 0xbf, 0xd4, 0xa0,
+	0x12, 0x3f, 0x45, 0x24, 0xf7, 0x16, 0x7f, 0xbc, 0x28, 0x85, 0xf8, 0x6c, 0xd9, 0x57, 0x8f, 0xc6,
+	0x35, 0xf2, 0xe7, 0x50, 0x8e, 0xde, 0xed, 0x91, 0xac, 0xbb, 0xd7, 0x85, 0x47, 0x81, 0xcd, 0x7b,
+	0x0b, 0x8f, 0x4b, 0x4e, 0x1f, 0x3d, 0xa6, 0xcb, 0x3c, 0xfd, 0x85, 0x67, 0x7f, 0xcd, 0x7b, 0x0b,
+	0x8f, 0x8b, 0xa7, 0x47, 0x4f, 0x48, 0xbc, 0xb9, 0xcb, 0xec, 0x09, 0xf3, 0x8f, 0xfd, 0x9a, 0x0f,
+	0x96, 0x19, 0x9a, 0x12, 0x24, 0xf1, 0x6a, 0x2f, 0xb3, 0x20, 0xf3, 0x2f, 0x03, 0x9b, 0x0f, 0x96,
+	0x19, 0x1a, 0x0b, 0xf2, 0x0b, 0x2d, 0x79, 0x2e, 0xb8, 0xb7, 0xf0, 0xe3, 0xb4, 0x05, 0x5d, 0x72,
+	0xee, 0x79, 0x9c, 0x58, 0xa0, 0xbf, 0x50, 0x55, 0x0c, 0xf9, 0xb6, 0x8d, 0x2c, 0x02, 0x96, 0x7a,
+	0x0e, 0xd7, 0xfc, 0x74, 0xb9, 0xcd, 0x46, 0x08, 0xf1, 0x57, 0x1a, 0xc0, 0xec, 0x15, 0x5c, 0x66,
+	0x21, 0xe6, 0x9e, 0xdf, 0x35, 0xef, 0x2f, 0x31, 0x32, 0xb9, 0x40, 0xa2, 0x57, 0x3a, 0x99, 0x17,
+	0xc8, 0x85, 0x57, 0x7a, 0xcd, 0x7b, 0x0b, 0x8f, 0x8b, 0xa7, 0xff, 0x17, 0x0d, 0xd6, 0xe7, 0x5e,
+	0x09, 0x91, 0x47, 0x57, 0x7c, 0x28, 0xd6, 0xfc, 0x62, 0x79, 0x80, 0x48, 0xb4, 0x3b, 0xda, 0x27,
+	0x1a, 0xf9, 0x5b, 0x0d, 0x56, 0xd3, 0xaf, 0x27, 0x32, 0xef, 0x52, 0x97, 0xbc, 0x37, 0x6a, 0x3e,
+	0x5c, 0x6e, 0x70, 0xac, 0xad, 0xbf, 0xd7, 0xa0, 0xae, 0xd6, 0x77, 0x24, 0xcf, 0xc3, 0xc5, 0xc2,
+	0xc2, 0x05, 0x81, 0x3e, 0x5f, 0x72, 0x74, 0x24, 0xd1, 0x97, 0x2b, 0x7f, 0x5c, 0x94, 0xd9, 0x5b,
+	0x49, 0xfc, 0xfc, 0xe8, 0xff, 0x07, 0x00, 0x0c, 0xaf, 0x8a, 0x21, 0xf2, 0x33, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
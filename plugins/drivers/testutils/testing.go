@@ -93,6 +93,7 @@ var tinyChroot = map[string]string{
 	"/usr/bin/echo":   "/usr/bin/echo",
 	"/usr/bin/touch":  "/usr/bin/touch",
 	"/usr/bin/stat":   "/usr/bin/stat",
+	"/usr/bin/id":     "/usr/bin/id",
 
 	// destination: /etc/
 	"/etc/ld.so.cache":  "/etc/ld.so.cache",
@@ -66,6 +66,7 @@ func taskConfigFromProto(pb *proto.TaskConfig) *TaskConfig {
 		AllocID:          pb.AllocId,
 		NetworkIsolation: NetworkIsolationSpecFromProto(pb.NetworkIsolationSpec),
 		DNS:              dnsConfigFromProto(pb.Dns),
+		KillSignal:       pb.KillSignal,
 	}
 }
 
@@ -91,6 +92,7 @@ func taskConfigToProto(cfg *TaskConfig) *proto.TaskConfig {
 		AllocId:              cfg.AllocID,
 		NetworkIsolationSpec: NetworkIsolationSpecToProto(cfg.NetworkIsolation),
 		Dns:                  dnsConfigToProto(cfg.DNS),
+		KillSignal:           cfg.KillSignal,
 	}
 	return pb
 }